@@ -0,0 +1,177 @@
+// Package auth issues the tokens handed out at login and identifies the user
+// making each request from them.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// secret is the key tokens are signed with, set by SetSecret at startup.
+var secret []byte
+
+// ErrNoSecret is returned when tokens are issued or parsed before SetSecret.
+var ErrNoSecret = errors.New("no JWT secret has been set")
+
+// Codes given in the extensions of the GraphQL error when a request needs a
+// user and has none, or has one without the role it needs.
+const (
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+)
+
+// RoleAdmin is the role of users who may run operations on the whole system,
+// such as backups.
+const RoleAdmin = "admin"
+
+// Error is a request that needs a user, or a user with a role, and has none.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions returns the details of the error for the GraphQL response.
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+// User is the user a token was issued to.
+type User struct {
+	ID   string
+	Role string
+}
+
+type userKey struct{}
+
+// SetSecret sets the key tokens are signed with. The key must not be empty, as
+// anyone could sign tokens with an empty one.
+func SetSecret(key string) error {
+	if key == "" {
+		return ErrNoSecret
+	}
+	secret = []byte(key)
+	return nil
+}
+
+// IssueToken returns a signed token for the user that expires after ttl.
+func IssueToken(userID, role string, ttl time.Duration) (string, error) {
+	if len(secret) == 0 {
+		return "", ErrNoSecret
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userId": userID,
+		"role":   role,
+		"exp":    time.Now().Add(ttl).Unix(),
+	})
+	return token.SignedString(secret)
+}
+
+// ParseToken checks a token's signature and expiry and returns its user.
+func ParseToken(token string) (*User, error) {
+	if len(secret) == 0 {
+		return nil, ErrNoSecret
+	}
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	userID, _ := claims["userId"].(string)
+	if userID == "" {
+		return nil, errors.New("token has no user")
+	}
+	role, _ := claims["role"].(string)
+	return &User{ID: userID, Role: role}, nil
+}
+
+// WithUser returns a copy of ctx carrying the user.
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the user making the request, if it carried a token.
+func UserFrom(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userKey{}).(*User)
+	return user, ok
+}
+
+// RequireUser returns the user making the request, or an error for requests
+// without a token.
+func RequireUser(ctx context.Context) (*User, error) {
+	if user, ok := UserFrom(ctx); ok {
+		return user, nil
+	}
+	return nil, &Error{Code: CodeUnauthenticated, Message: "log in to do this"}
+}
+
+// RequireRole returns the user making the request, or an error for requests
+// without a token or from a user without the role.
+func RequireRole(ctx context.Context, role string) (*User, error) {
+	user, err := RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != role {
+		return nil, &Error{Code: CodeForbidden, Message: fmt.Sprintf("only %s users can do this", role)}
+	}
+	return user, nil
+}
+
+// userFromHeader parses an Authorization header, "Bearer <token>". It returns
+// nil without an error when the header is empty.
+func userFromHeader(header string) (*User, error) {
+	if header == "" {
+		return nil, nil
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return nil, errors.New("authorization must be a bearer token")
+	}
+	return ParseToken(token)
+}
+
+// Middleware adds the user from the request's bearer token to its context.
+// Requests without a valid token, including those whose token has expired,
+// carry on without a user, so that operations open to everyone, such as
+// logging in again, still work; those needing a user refuse them.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := userFromHeader(r.Header.Get("Authorization"))
+		if err != nil {
+			log.Debug().Err(err).Msg("Ignoring invalid token")
+		}
+		if user != nil {
+			r = r.WithContext(WithUser(r.Context(), user))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WebsocketInit adds the user from the Authorization value of a websocket's
+// init payload to the context of its subscriptions, as Middleware does for
+// HTTP requests.
+func WebsocketInit(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+	user, err := userFromHeader(payload.Authorization())
+	if err != nil {
+		log.Debug().Err(err).Msg("Ignoring invalid token")
+	}
+	if user != nil {
+		ctx = WithUser(ctx, user)
+	}
+	return ctx, nil, nil
+}
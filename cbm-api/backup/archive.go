@@ -0,0 +1,264 @@
+// Package backup snapshots MongoDB collections into a compressed, checksummed
+// archive and reads them back, for moving between environments and
+// recovering from bad migrations.
+//
+// An archive is a zip file holding each collection's documents as
+// concatenated BSON, as mongodump writes them, and a manifest recording when
+// the backup was taken, each collection's document count, SHA-256 and
+// indexes.
+package backup
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FormatVersion is the version of the archive layout. Bump it when a change
+// would be read wrongly by an older restore.
+const FormatVersion = 1
+
+// manifestFile is where the manifest sits in an archive.
+const manifestFile = "manifest.json"
+
+// Manifest describes an archive.
+type Manifest struct {
+	FormatVersion int          `json:"formatVersion"`
+	Database      string       `json:"database"`
+	StartedAt     string       `json:"startedAt"` // the point in time the backup shows, give or take writes during it unless Snapshot
+	FinishedAt    string       `json:"finishedAt"`
+	Snapshot      bool         `json:"snapshot,omitempty"` // every collection was read at one point in time
+	Collections   []Collection `json:"collections"`
+}
+
+// Collection describes one collection in an archive.
+type Collection struct {
+	Name      string            `json:"name"`
+	File      string            `json:"file"`
+	Documents int64             `json:"documents"`
+	Bytes     int64             `json:"bytes"`
+	SHA256    string            `json:"sha256"`
+	Indexes   []json.RawMessage `json:"indexes,omitempty"` // as listed by MongoDB, in extended JSON
+}
+
+// Filter picks collections by name: those included, all when none are, less
+// those excluded.
+type Filter struct {
+	Include []string
+	Exclude []string
+}
+
+// ParseList splits a comma separated list of collection names.
+func ParseList(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Match reports whether a collection passes the filter.
+func (f Filter) Match(name string) bool {
+	if len(f.Include) > 0 && !slices.Contains(f.Include, name) {
+		return false
+	}
+	return !slices.Contains(f.Exclude, name)
+}
+
+// Writer writes an archive, one collection at a time.
+type Writer struct {
+	zip      *zip.Writer
+	manifest Manifest
+	open     *CollectionWriter
+}
+
+// NewWriter starts an archive of the database as of startedAt.
+func NewWriter(w io.Writer, database string, startedAt time.Time) *Writer {
+	return &Writer{
+		zip: zip.NewWriter(w),
+		manifest: Manifest{
+			FormatVersion: FormatVersion,
+			Database:      database,
+			StartedAt:     startedAt.UTC().Format(time.RFC3339),
+			Collections:   []Collection{},
+		},
+	}
+}
+
+// MarkSnapshot records that the collections are read at one point in time.
+func (w *Writer) MarkSnapshot() {
+	w.manifest.Snapshot = true
+}
+
+// Collection starts writing a collection with its indexes, closing the one
+// written before.
+func (w *Writer) Collection(name string, indexes []bson.Raw) (*CollectionWriter, error) {
+	w.closeCollection()
+
+	info := Collection{Name: name, File: "collections/" + name + ".bson"}
+	for _, index := range indexes {
+		ext, err := bson.MarshalExtJSON(index, true, false)
+		if err != nil {
+			return nil, fmt.Errorf("encoding index of %s: %w", name, err)
+		}
+		info.Indexes = append(info.Indexes, ext)
+	}
+
+	file, err := w.zip.Create(info.File)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.New()
+	w.open = &CollectionWriter{w: io.MultiWriter(file, sum), sum: sum, info: info}
+	return w.open, nil
+}
+
+func (w *Writer) closeCollection() {
+	if w.open == nil {
+		return
+	}
+	info := w.open.info
+	info.SHA256 = hex.EncodeToString(w.open.sum.Sum(nil))
+	w.manifest.Collections = append(w.manifest.Collections, info)
+	w.open = nil
+}
+
+// Close writes the manifest, finished at finishedAt, and the end of the
+// archive, returning the manifest.
+func (w *Writer) Close(finishedAt time.Time) (*Manifest, error) {
+	w.closeCollection()
+	w.manifest.FinishedAt = finishedAt.UTC().Format(time.RFC3339)
+
+	file, err := w.zip.Create(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(w.manifest); err != nil {
+		return nil, err
+	}
+	if err := w.zip.Close(); err != nil {
+		return nil, err
+	}
+	return &w.manifest, nil
+}
+
+// CollectionWriter writes the documents of one collection.
+type CollectionWriter struct {
+	w    io.Writer
+	sum  hash.Hash
+	info Collection
+}
+
+// Write adds a document.
+func (c *CollectionWriter) Write(doc bson.Raw) error {
+	if _, err := c.w.Write(doc); err != nil {
+		return err
+	}
+	c.info.Documents++
+	c.info.Bytes += int64(len(doc))
+	return nil
+}
+
+// Archive is an archive opened for reading.
+type Archive struct {
+	Manifest Manifest
+	files    map[string]*zip.File
+}
+
+// Open reads an archive's manifest, refusing archives of another format
+// version.
+func Open(r io.ReaderAt, size int64) (*Archive, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+
+	archive := &Archive{files: map[string]*zip.File{}}
+	for _, file := range reader.File {
+		archive.files[file.Name] = file
+	}
+
+	manifest, ok := archive.files[manifestFile]
+	if !ok {
+		return nil, errors.New("archive has no manifest")
+	}
+	in, err := manifest.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	if err := json.NewDecoder(in).Decode(&archive.Manifest); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+	if archive.Manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("archive has format version %d, this restore reads version %d", archive.Manifest.FormatVersion, FormatVersion)
+	}
+
+	for _, collection := range archive.Manifest.Collections {
+		if _, ok := archive.files[collection.File]; !ok {
+			return nil, fmt.Errorf("archive is missing %s of collection %s", collection.File, collection.Name)
+		}
+	}
+	return archive, nil
+}
+
+// Verify checks every collection against the manifest's checksums and counts,
+// so a damaged archive is refused before anything is restored from it.
+func (a *Archive) Verify() error {
+	for _, collection := range a.Manifest.Collections {
+		if err := a.Documents(collection, func(bson.Raw) error { return nil }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Documents calls fn with each document of a collection, then checks the
+// collection against its checksum and count. Documents seen before a mismatch
+// is found have already been passed to fn, so Verify first.
+func (a *Archive) Documents(collection Collection, fn func(bson.Raw) error) error {
+	in, err := a.files[collection.File].Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	sum := sha256.New()
+	reader := io.TeeReader(in, sum)
+	var count int64
+	for {
+		doc, err := bson.NewFromIOReader(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", collection.Name, err)
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+		count++
+	}
+
+	if got := hex.EncodeToString(sum.Sum(nil)); got != collection.SHA256 {
+		return fmt.Errorf("collection %s fails its checksum: got %s, want %s", collection.Name, got, collection.SHA256)
+	}
+	if count != collection.Documents {
+		return fmt.Errorf("collection %s has %d documents, the manifest says %d", collection.Name, count, collection.Documents)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// insertBatch is how many documents a restore inserts at a time.
+const insertBatch = 1000
+
+// Backup writes the collections of the database that pass the filter to w,
+// in name order, with their indexes.
+//
+// Where the server supports snapshot reads, MongoDB 5.0 or later on a replica
+// set or sharded cluster, the documents are read from one point in time and
+// the manifest is marked as a snapshot. A backup that outlasts the history the
+// server keeps, 5 minutes by default, then fails rather than mix points in
+// time. Elsewhere, as on the standalone server of docker-compose.yml, each
+// collection is read as it stands when reached, so writes made during the
+// backup can leave related collections out of step.
+func Backup(ctx context.Context, db *mongo.Database, w io.Writer, filter Filter) (*Manifest, error) {
+	archive := NewWriter(w, db.Name(), time.Now())
+
+	all, err := db.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return nil, fmt.Errorf("listing collections: %w", err)
+	}
+	var names []string
+	for _, name := range all {
+		if !strings.HasPrefix(name, "system.") && filter.Match(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var session mongo.Session
+	if len(names) > 0 {
+		session = snapshotSession(ctx, db, names[0])
+	}
+	if session != nil {
+		defer session.EndSession(ctx)
+		archive.MarkSnapshot()
+	}
+
+	for _, name := range names {
+		if err := backupCollection(ctx, session, db.Collection(name), archive); err != nil {
+			return nil, fmt.Errorf("backing up %s: %w", name, err)
+		}
+	}
+
+	return archive.Close(time.Now())
+}
+
+// snapshotSession starts a session whose reads all see the database as it was
+// at the first, which it makes on the probe collection. It returns nil where
+// the server cannot read from a snapshot.
+func snapshotSession(ctx context.Context, db *mongo.Database, probe string) mongo.Session {
+	session, err := db.Client().StartSession(options.Session().SetSnapshot(true))
+	if err != nil {
+		return nil
+	}
+	err = db.Collection(probe).FindOne(mongo.NewSessionContext(ctx, session), bson.D{}).Err()
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		session.EndSession(ctx)
+		return nil
+	}
+	return session
+}
+
+// backupCollection writes a collection to the archive, reading its documents
+// in the session when there is one.
+func backupCollection(ctx context.Context, session mongo.Session, collection *mongo.Collection, archive *Writer) error {
+	indexCursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return err
+	}
+	var indexes []bson.Raw
+	if err := indexCursor.All(ctx, &indexes); err != nil {
+		return err
+	}
+
+	out, err := archive.Collection(collection.Name(), indexes)
+	if err != nil {
+		return err
+	}
+
+	if session != nil {
+		ctx = mongo.NewSessionContext(ctx, session)
+	}
+	cursor, err := collection.Find(ctx, bson.D{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		if err := out.Write(cursor.Current); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// Restore replaces the collections of the database that pass the filter with
+// those in the archive, indexes included, after verifying the whole archive.
+// Collections not in the archive are left alone. It returns the collections
+// restored.
+func Restore(ctx context.Context, db *mongo.Database, archive *Archive, filter Filter) ([]Collection, error) {
+	if err := archive.Verify(); err != nil {
+		return nil, err
+	}
+
+	restored := []Collection{}
+	for _, collection := range archive.Manifest.Collections {
+		if !filter.Match(collection.Name) {
+			continue
+		}
+		if err := restoreCollection(ctx, db, archive, collection); err != nil {
+			return restored, fmt.Errorf("restoring %s: %w", collection.Name, err)
+		}
+		restored = append(restored, collection)
+	}
+	return restored, nil
+}
+
+func restoreCollection(ctx context.Context, db *mongo.Database, archive *Archive, info Collection) error {
+	collection := db.Collection(info.Name)
+	if err := collection.Drop(ctx); err != nil {
+		return err
+	}
+
+	// Create the collection even when it is empty, with the indexes it had.
+	var specs bson.A
+	for _, index := range info.Indexes {
+		var spec bson.D
+		if err := bson.UnmarshalExtJSON(index, true, &spec); err != nil {
+			return fmt.Errorf("decoding index: %w", err)
+		}
+		spec = dropKeys(spec, "v", "ns")
+		if nameOf(spec) == "_id_" {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	if err := db.CreateCollection(ctx, info.Name); err != nil {
+		return err
+	}
+	if len(specs) > 0 {
+		if err := db.RunCommand(ctx, bson.D{{Key: "createIndexes", Value: info.Name}, {Key: "indexes", Value: specs}}).Err(); err != nil {
+			return fmt.Errorf("creating indexes: %w", err)
+		}
+	}
+
+	batch := make([]interface{}, 0, insertBatch)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := collection.InsertMany(ctx, batch)
+		batch = batch[:0]
+		return err
+	}
+	err := archive.Documents(info, func(doc bson.Raw) error {
+		batch = append(batch, doc)
+		if len(batch) == insertBatch {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+func dropKeys(doc bson.D, keys ...string) bson.D {
+	kept := doc[:0]
+	for _, e := range doc {
+		if !slices.Contains(keys, e.Key) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+func nameOf(spec bson.D) string {
+	for _, e := range spec {
+		if e.Key == "name" {
+			name, _ := e.Value.(string)
+			return name
+		}
+	}
+	return ""
+}
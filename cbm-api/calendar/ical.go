@@ -0,0 +1,284 @@
+// Package calendar renders tasks and projects as an iCalendar (RFC 5545) feed
+// that calendar clients can subscribe to.
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+)
+
+// Filter narrows a feed, and is carried in its URL so that each subscription
+// keeps its own.
+type Filter struct {
+	Labels        []string // tasks and projects with any of these labels
+	Departments   []string // tasks in any of these departments
+	TasksAsEvents bool     // tasks as events on their due date, for clients without to-dos
+}
+
+// ParseFilter reads a filter from a feed URL's query. Lists may be given as
+// repeated parameters, comma separated, or both.
+func ParseFilter(query url.Values) Filter {
+	return Filter{
+		Labels:        splitList(query["labels"]),
+		Departments:   splitList(query["departments"]),
+		TasksAsEvents: query.Get("tasksAsEvents") == "true",
+	}
+}
+
+func splitList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// Encode returns the filter as a URL query, empty for no filter.
+func (f Filter) Encode() string {
+	query := url.Values{}
+	if len(f.Labels) > 0 {
+		query.Set("labels", strings.Join(f.Labels, ","))
+	}
+	if len(f.Departments) > 0 {
+		query.Set("departments", strings.Join(f.Departments, ","))
+	}
+	if f.TasksAsEvents {
+		query.Set("tasksAsEvents", "true")
+	}
+	return query.Encode()
+}
+
+// URL returns the address of a feed, on PUBLIC_URL if set, else on the local
+// server.
+func URL(token string, filter Filter) string {
+	base := strings.TrimRight(os.Getenv("PUBLIC_URL"), "/")
+	if base == "" {
+		base = "http://localhost:8080"
+	}
+	feedURL := base + "/calendar/" + url.PathEscape(token) + ".ics"
+	if query := filter.Encode(); query != "" {
+		feedURL += "?" + query
+	}
+	return feedURL
+}
+
+// MatchTask reports whether a task passes the filter.
+func (f Filter) MatchTask(task *model.Task) bool {
+	if len(f.Departments) > 0 && (task.Department == nil || !slices.Contains(f.Departments, *task.Department)) {
+		return false
+	}
+	return f.matchLabels(task.Labels)
+}
+
+// MatchProject reports whether a project passes the filter's labels.
+func (f Filter) MatchProject(project *model.Project) bool {
+	return f.matchLabels(project.Labels)
+}
+
+func (f Filter) matchLabels(labels []*string) bool {
+	if len(f.Labels) == 0 {
+		return true
+	}
+	for _, label := range labels {
+		if label != nil && slices.Contains(f.Labels, *label) {
+			return true
+		}
+	}
+	return false
+}
+
+// Feed is what a calendar shows.
+type Feed struct {
+	Name     string
+	Tasks    []*model.Task
+	Projects []*model.Project
+	Filter   Filter
+}
+
+// Render writes the feed as of now. Tasks become to-dos starting on their
+// defer date and due on their due date, or events on their due date with
+// TasksAsEvents. Projects become events on their due date. Tasks and projects
+// without a date, or with one that does not parse, are left out.
+func Render(w io.Writer, feed Feed, now time.Time) error {
+	c := &writer{w: w}
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:-//cryptobotmanager//cbm-api//EN")
+	c.line("CALSCALE:GREGORIAN")
+	c.line("METHOD:PUBLISH")
+	c.property("X-WR-CALNAME", escape(feed.Name))
+
+	for _, task := range feed.Tasks {
+		due, hasDue := dateProperty(task.DueDate)
+		start, hasStart := dateProperty(task.DeferDate)
+
+		if feed.Filter.TasksAsEvents {
+			if !hasDue {
+				continue
+			}
+			c.line("BEGIN:VEVENT")
+			c.property("UID", "task-"+task.ID+"@cbm-api")
+			c.property("DTSTAMP", stamp)
+			c.property("DTSTART"+due.params, due.value)
+		} else {
+			if !hasDue && !hasStart {
+				continue
+			}
+			c.line("BEGIN:VTODO")
+			c.property("UID", "task-"+task.ID+"@cbm-api")
+			c.property("DTSTAMP", stamp)
+			if hasStart {
+				c.property("DTSTART"+start.params, start.value)
+			}
+			if hasDue {
+				c.property("DUE"+due.params, due.value)
+			}
+			if task.Status == gtd.Complete {
+				c.property("STATUS", "COMPLETED")
+			} else {
+				c.property("STATUS", "NEEDS-ACTION")
+			}
+		}
+
+		c.property("SUMMARY", escape(task.Title))
+		if task.Description != nil && *task.Description != "" {
+			c.property("DESCRIPTION", escape(*task.Description))
+		}
+		if categories := categories(task.Labels); categories != "" {
+			c.property("CATEGORIES", categories)
+		}
+
+		if feed.Filter.TasksAsEvents {
+			c.line("END:VEVENT")
+		} else {
+			c.line("END:VTODO")
+		}
+	}
+
+	for _, project := range feed.Projects {
+		due, ok := dateProperty(project.DueDate)
+		if !ok {
+			continue
+		}
+		c.line("BEGIN:VEVENT")
+		c.property("UID", "project-"+project.ID+"@cbm-api")
+		c.property("DTSTAMP", stamp)
+		c.property("DTSTART"+due.params, due.value)
+		c.property("SUMMARY", escape(project.Title+" due"))
+		if project.Description != nil && *project.Description != "" {
+			c.property("DESCRIPTION", escape(*project.Description))
+		}
+		if categories := categories(project.Labels); categories != "" {
+			c.property("CATEGORIES", categories)
+		}
+		c.line("END:VEVENT")
+	}
+
+	c.line("END:VCALENDAR")
+	return c.err
+}
+
+// icalDate is a date property's parameters and value.
+type icalDate struct {
+	params string
+	value  string
+}
+
+// dateProperty formats a task or project date: a date alone as a whole day,
+// a time in UTC.
+func dateProperty(date *string) (icalDate, bool) {
+	if date == nil {
+		return icalDate{}, false
+	}
+	t, dateOnly, ok := gtd.ParseDate(*date)
+	if !ok {
+		return icalDate{}, false
+	}
+	if dateOnly {
+		return icalDate{params: ";VALUE=DATE", value: t.Format("20060102")}, true
+	}
+	return icalDate{value: t.UTC().Format("20060102T150405Z")}, true
+}
+
+func categories(labels []*string) string {
+	var escaped []string
+	for _, label := range labels {
+		if label != nil && *label != "" {
+			escaped = append(escaped, escape(*label))
+		}
+	}
+	return strings.Join(escaped, ",")
+}
+
+// escape escapes text for a TEXT property value.
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(text)
+}
+
+// writer writes content lines, folded at 75 octets and ended with CRLF. The
+// first error is kept and later writes are skipped.
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+func (c *writer) property(name, value string) {
+	c.line(name + ":" + value)
+}
+
+func (c *writer) line(line string) {
+	if c.err != nil {
+		return
+	}
+	_, c.err = fmt.Fprint(c.w, fold(line)+"\r\n")
+}
+
+// fold splits a line longer than 75 octets, continuing it on lines that start
+// with a space, without splitting a UTF-8 character.
+func fold(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && !utf8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		width = limit - 1 // the leading space counts
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// utf8Start reports whether b starts a UTF-8 character rather than continuing one.
+func utf8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,303 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/auth"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ActivityKey identifies the task or project activity entries are about.
+type ActivityKey struct {
+	TargetType model.ActivityTarget
+	TargetID   string
+}
+
+// fieldDiff is a field's value before and after a change, as text.
+type fieldDiff struct {
+	name     string
+	from, to *string
+}
+
+// changedFields lists the fields whose value differs.
+func changedFields(fields []fieldDiff) []*model.FieldChange {
+	changes := []*model.FieldChange{}
+	for _, f := range fields {
+		if stringValue(f.from) != stringValue(f.to) || (f.from == nil) != (f.to == nil) {
+			changes = append(changes, &model.FieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+	return changes
+}
+
+// diffTasks lists the fields that differ between two states of a task.
+func diffTasks(before, after storedTask) []*model.FieldChange {
+	return changedFields([]fieldDiff{
+		{"title", &before.Title, &after.Title},
+		{"description", before.Description, after.Description},
+		{"status", &before.Status, &after.Status},
+		{"labels", pointerListString(before.Labels), pointerListString(after.Labels)},
+		{"assignedTo", before.AssignedTo, after.AssignedTo},
+		{"dueDate", before.DueDate, after.DueDate},
+		{"deferDate", before.DeferDate, after.DeferDate},
+		{"department", before.Department, after.Department},
+		{"projectId", before.ProjectID, after.ProjectID},
+		{"duration", intPointerString(before.Duration), intPointerString(after.Duration)},
+		{"estimate", intPointerString(before.Estimate), intPointerString(after.Estimate)},
+		{"parentId", before.ParentID, after.ParentID},
+		{"blockedBy", listString(before.BlockedBy), listString(after.BlockedBy)},
+		{"recurrence", before.Recurrence, after.Recurrence},
+	})
+}
+
+// diffProjects lists the fields that differ between two states of a project.
+func diffProjects(before, after *model.Project) []*model.FieldChange {
+	return changedFields([]fieldDiff{
+		{"title", &before.Title, &after.Title},
+		{"sop", boolString(before.Sop), boolString(after.Sop)},
+		{"description", before.Description, after.Description},
+		{"labels", pointerListString(before.Labels), pointerListString(after.Labels)},
+		{"assignedTo", before.AssignedTo, after.AssignedTo},
+		{"dueDate", before.DueDate, after.DueDate},
+		{"status", &before.Status, &after.Status},
+	})
+}
+
+func boolString(v bool) *string {
+	s := strconv.FormatBool(v)
+	return &s
+}
+
+func pointerListString(v []*string) *string {
+	list := make([]string, 0, len(v))
+	for _, s := range v {
+		if s != nil {
+			list = append(list, *s)
+		}
+	}
+	return listString(list)
+}
+
+// newActivity returns an entry for something that happened to the target at
+// now, by the user making the request if there is one.
+func newActivity(ctx context.Context, targetType model.ActivityTarget, targetID string, kind model.ActivityKind, now time.Time) *model.ActivityEntry {
+	entry := &model.ActivityEntry{
+		ID:         primitive.NewObjectID().Hex(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		Kind:       kind,
+		At:         now.UTC().Format(time.RFC3339),
+		Changes:    []*model.FieldChange{},
+	}
+	if user, ok := auth.UserFrom(ctx); ok {
+		entry.ActorID = &user.ID
+	}
+	return entry
+}
+
+// recordActivity appends entries to the activity log. The changes they record
+// have already been made, so a failure is logged rather than returned.
+func (db *DB) recordActivity(ctx context.Context, entries ...*model.ActivityEntry) {
+	collection := db.client.Database("go_trading_db").Collection("Activity")
+
+	if len(entries) == 0 {
+		return
+	}
+	docs := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		docs = append(docs, entry)
+	}
+	if _, err := collection.InsertMany(ctx, docs); err != nil {
+		log.Error().Err(err).Int("entries", len(entries)).Msg("Error recording activity:")
+	}
+}
+
+// GetActivityByTargets retrieves the activity of each task or project in one
+// query, oldest first, in the order of the keys given.
+func (db *DB) GetActivityByTargets(ctx context.Context, keys []ActivityKey) ([][]*model.ActivityEntry, error) {
+	collection := db.client.Database("go_trading_db").Collection("Activity")
+
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, key.TargetID)
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"targetid": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving activity:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*model.ActivityEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		log.Error().Err(err).Msg("Error decoding activity:")
+		return nil, err
+	}
+
+	byKey := make(map[ActivityKey][]*model.ActivityEntry, len(keys))
+	for _, entry := range entries {
+		key := ActivityKey{TargetType: entry.TargetType, TargetID: entry.TargetID}
+		byKey[key] = append(byKey[key], entry)
+	}
+
+	activity := make([][]*model.ActivityEntry, len(keys))
+	for i, key := range keys {
+		activity[i] = byKey[key]
+		if activity[i] == nil {
+			activity[i] = []*model.ActivityEntry{}
+		}
+	}
+	return activity, nil
+}
+
+// AddComment records a comment by the author on a task or project at now,
+// with the users it mentions.
+func (db *DB) AddComment(ctx context.Context, input model.AddCommentInput, authorID string, now time.Time) (*model.ActivityEntry, error) {
+	collection := db.client.Database("go_trading_db").Collection("Activity")
+
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, errors.New("a comment needs a body")
+	}
+
+	targets := map[model.ActivityTarget]string{
+		model.ActivityTargetTask:    "Tasks",
+		model.ActivityTargetProject: "Projects",
+	}
+	targetCollection, ok := targets[input.TargetType]
+	if !ok {
+		return nil, fmt.Errorf("unknown activity target %s", input.TargetType)
+	}
+	count, err := db.client.Database("go_trading_db").Collection(targetCollection).CountDocuments(ctx, bson.M{"id": input.TargetID})
+	if err != nil {
+		log.Error().Err(err).Msg("Error checking comment target:")
+		return nil, err
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("%s %s not found", strings.ToLower(string(input.TargetType)), input.TargetID)
+	}
+
+	entry := newActivity(ctx, input.TargetType, input.TargetID, model.ActivityKindCommented, now)
+	entry.ActorID = &authorID
+	entry.Comment = &model.Comment{Body: body, ThreadID: entry.ID}
+
+	if input.ReplyTo != nil {
+		var parent model.ActivityEntry
+		filter := bson.M{"id": *input.ReplyTo, "kind": model.ActivityKindCommented, "targettype": input.TargetType, "targetid": input.TargetID}
+		err := collection.FindOne(ctx, filter).Decode(&parent)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("comment %s not found on %s %s", *input.ReplyTo, strings.ToLower(string(input.TargetType)), input.TargetID)
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Error retrieving comment to reply to:")
+			return nil, err
+		}
+		entry.Comment.ReplyTo = input.ReplyTo
+		entry.Comment.ThreadID = parent.Comment.ThreadID
+	}
+
+	users, err := db.ReadAllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry.Comment.Mentions = ResolveMentions(body, users)
+
+	if _, err := collection.InsertOne(ctx, entry); err != nil {
+		log.Error().Err(err).Msg("Error inserting comment:")
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ReadMentions retrieves the comments mentioning the user, newest first.
+func (db *DB) ReadMentions(ctx context.Context, userID string) ([]*model.ActivityEntry, error) {
+	collection := db.client.Database("go_trading_db").Collection("Activity")
+
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: -1}, {Key: "id", Value: -1}})
+	cursor, err := collection.Find(ctx, bson.M{"comment.mentions": userID}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving mentions:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*model.ActivityEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		log.Error().Err(err).Msg("Error decoding mentions:")
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// mentionPattern matches @name, @first.last and @email, not the @ inside an
+// email address.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.])@([\w.+-]+(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+
+// ResolveMentions returns the IDs of the users mentioned in a comment, in the
+// order first mentioned. A mention matches a user's email, the part of it
+// before the @, their first.last name, or their first name when no other user
+// shares it. Mentions matching no one are ignored.
+func ResolveMentions(body string, users []*model.User) []string {
+	handles := map[string][]string{}
+	firstNames := map[string][]string{}
+	for _, user := range users {
+		if user.IsDeleted {
+			continue
+		}
+		email := strings.ToLower(user.Email)
+		local, _, _ := strings.Cut(email, "@")
+		fullName := strings.ToLower(user.FirstName + "." + user.LastName)
+		for _, handle := range []string{email, local, fullName} {
+			if handle != "" && handle != "." {
+				handles[handle] = append(handles[handle], user.ID)
+			}
+		}
+		if user.FirstName != "" {
+			firstName := strings.ToLower(user.FirstName)
+			firstNames[firstName] = append(firstNames[firstName], user.ID)
+		}
+	}
+
+	mentions := []string{}
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		handle := strings.ToLower(strings.TrimRight(match[1], ".-"))
+
+		ids := unique(handles[handle])
+		if len(ids) == 0 {
+			ids = unique(firstNames[handle])
+		}
+		if len(ids) != 1 || seen[ids[0]] {
+			continue
+		}
+		seen[ids[0]] = true
+		mentions = append(mentions, ids[0])
+	}
+	return mentions
+}
+
+func unique(ids []string) []string {
+	var out []string
+	for _, id := range ids {
+		if !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateBacktestRun stores the result of a backtest, kept apart from the live trading collections.
+func (db *DB) CreateBacktestRun(ctx context.Context, input model.BacktestRunInput) (*model.BacktestRun, error) {
+	collection := db.client.Database("go_trading_db").Collection("BacktestRuns")
+
+	trades := make([]*model.BacktestTrade, 0, len(input.Trades))
+	for _, t := range input.Trades {
+		trades = append(trades, &model.BacktestTrade{
+			Symbol:           t.Symbol,
+			Outcome:          t.Outcome,
+			OpenTime:         t.OpenTime,
+			CloseTime:        t.CloseTime,
+			OpenPrice:        t.OpenPrice,
+			ClosePrice:       t.ClosePrice,
+			PercentageChange: t.PercentageChange,
+			Fee:              t.Fee,
+			Balance:          t.Balance,
+		})
+	}
+
+	equityCurve := make([]*model.EquityPoint, 0, len(input.EquityCurve))
+	for _, p := range input.EquityCurve {
+		equityCurve = append(equityCurve, &model.EquityPoint{Timestamp: p.Timestamp, Balance: p.Balance})
+	}
+
+	run := &model.BacktestRun{
+		RunID:           primitive.NewObjectID().Hex(),
+		BotInstanceName: input.BotInstanceName,
+		Parameters: &model.BacktestParameters{
+			TradeDuration:        input.Parameters.TradeDuration,
+			IncrementsAtr:        input.Parameters.IncrementsAtr,
+			LongSMADuration:      input.Parameters.LongSMADuration,
+			ShortSMADuration:     input.Parameters.ShortSMADuration,
+			MovingAveMomentum:    input.Parameters.MovingAveMomentum,
+			TakeProfitPercentage: input.Parameters.TakeProfitPercentage,
+			StopLossPercentage:   input.Parameters.StopLossPercentage,
+			ATRtollerance:        input.Parameters.ATRtollerance,
+			FeePercentage:        input.Parameters.FeePercentage,
+		},
+		DatasetID:       input.DatasetID,
+		From:            input.From,
+		To:              input.To,
+		StartingBalance: input.StartingBalance,
+		EndingBalance:   input.EndingBalance,
+		FeesTotal:       input.FeesTotal,
+		WINCounter:      input.WINCounter,
+		LOSSCounter:     input.LOSSCounter,
+		TIMEOUTCounter:  input.TIMEOUTCounter,
+		CreatedOn:       input.CreatedOn,
+		Stats: &model.BacktestStats{
+			Trades:       input.Stats.Trades,
+			NetPnL:       input.Stats.NetPnL,
+			WinRate:      input.Stats.WinRate,
+			Cagr:         input.Stats.Cagr,
+			MaxDrawdown:  input.Stats.MaxDrawdown,
+			Sharpe:       input.Stats.Sharpe,
+			Sortino:      input.Stats.Sortino,
+			ProfitFactor: input.Stats.ProfitFactor,
+			Expectancy:   input.Stats.Expectancy,
+			AvgHoldTime:  input.Stats.AvgHoldTime,
+			Exposure:     input.Stats.Exposure,
+		},
+		EquityCurve: equityCurve,
+		Trades:      trades,
+	}
+
+	_, err := collection.InsertOne(ctx, run)
+	if err != nil {
+		log.Error().Err(err).Msg("Error inserting backtest run into the database:")
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// ReadBacktestRun retrieves a backtest run by ID.
+func (db *DB) ReadBacktestRun(ctx context.Context, runID string) (*model.BacktestRun, error) {
+	collection := db.client.Database("go_trading_db").Collection("BacktestRuns")
+
+	var run model.BacktestRun
+	err := collection.FindOne(ctx, bson.M{"runid": runID}).Decode(&run)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Warn().Str("runID", runID).Msg("No backtest run found")
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error getting backtest run from the database:")
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// ReadBacktestRuns retrieves backtest runs, most recent first, optionally for a single bot or dataset.
+func (db *DB) ReadBacktestRuns(ctx context.Context, botInstanceName, datasetID *string, limit *int) ([]*model.BacktestRun, error) {
+	collection := db.client.Database("go_trading_db").Collection("BacktestRuns")
+
+	filter := bson.M{}
+	if botInstanceName != nil {
+		filter["botinstancename"] = *botInstanceName
+	}
+	if datasetID != nil {
+		filter["datasetid"] = *datasetID
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdon", Value: -1}})
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying backtest runs:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	runs := []*model.BacktestRun{}
+	if err := cursor.All(ctx, &runs); err != nil {
+		log.Error().Err(err).Msg("Error decoding backtest runs:")
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// CompareBacktestRuns retrieves the given runs in the order their IDs were
+// supplied. IDs that do not match a run are skipped.
+func (db *DB) CompareBacktestRuns(ctx context.Context, runIDs []string) ([]*model.BacktestRun, error) {
+	collection := db.client.Database("go_trading_db").Collection("BacktestRuns")
+
+	cursor, err := collection.Find(ctx, bson.M{"runid": bson.M{"$in": runIDs}})
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying backtest runs to compare:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []*model.BacktestRun
+	if err := cursor.All(ctx, &found); err != nil {
+		log.Error().Err(err).Msg("Error decoding backtest runs to compare:")
+		return nil, err
+	}
+
+	byID := make(map[string]*model.BacktestRun, len(found))
+	for _, run := range found {
+		byID[run.RunID] = run
+	}
+
+	runs := []*model.BacktestRun{}
+	for _, id := range runIDs {
+		if run, ok := byID[id]; ok {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs, nil
+}
+
+// DeleteBacktestRun removes a backtest run by ID.
+func (db *DB) DeleteBacktestRun(ctx context.Context, runID string) (bool, error) {
+	collection := db.client.Database("go_trading_db").Collection("BacktestRuns")
+
+	result, err := collection.DeleteOne(ctx, bson.M{"runid": runID})
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting backtest run:")
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
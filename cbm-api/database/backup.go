@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"io"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/backup"
+	"github.com/rs/zerolog/log"
+)
+
+// Backup writes the collections passing the filter to w as an archive.
+func (db *DB) Backup(ctx context.Context, w io.Writer, filter backup.Filter) (*backup.Manifest, error) {
+	manifest, err := backup.Backup(ctx, db.client.Database("go_trading_db"), w, filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Error backing up database:")
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// Restore replaces the collections passing the filter with those in the
+// archive, then applies any migrations added since the backup was taken.
+func (db *DB) Restore(ctx context.Context, archive *backup.Archive, filter backup.Filter) ([]backup.Collection, error) {
+	restored, err := backup.Restore(ctx, db.client.Database("go_trading_db"), archive, filter)
+	if err != nil {
+		log.Error().Err(err).Int("restored", len(restored)).Msg("Error restoring database:")
+		return restored, err
+	}
+
+	if _, err := db.Migrate(ctx, 0); err != nil {
+		return restored, err
+	}
+
+	return restored, nil
+}
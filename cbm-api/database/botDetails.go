@@ -2,10 +2,12 @@ package database
 
 import (
 	"context"
+	"fmt"
 
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // CreateStrategy creates a new strategy in the database.
@@ -26,19 +28,39 @@ func (db *DB) CreateStrategy(ctx context.Context, input model.StrategyInput) (*m
 		NetGainCounter:       input.NetGainCounter,
 		NetLossCounter:       input.NetLossCounter,
 		AccountBalance:       input.AccountBalance,
+		StartingBalance:      &input.AccountBalance,
 		MovingAveMomentum:    input.MovingAveMomentum,
 		TakeProfitPercentage: &input.TakeProfitPercentage,
 		StopLossPercentage:   &input.StopLossPercentage,
 		ATRtollerance:        input.ATRtollerance,
+		MinFearGreed:         input.MinFearGreed,
+		MaxFearGreed:         input.MaxFearGreed,
+		AllowedSentiments:    input.AllowedSentiments,
 		FeesTotal:            input.FeesTotal,
 		Tested:               input.Tested,
 		Owner:                &input.Owner,
 		CreatedOn:            input.CreatedOn,
 	}
+	if input.Lifecycle != nil {
+		strategy.Lifecycle = *input.Lifecycle
+	}
+	applyLifecycle(strategy)
+
+	version, err := db.createStrategyVersion(ctx, strategy)
+	if err != nil {
+		return nil, err
+	}
+	strategy.VersionID = &version.VersionID
+	strategy.Version = &version.Version
 
-	_, err := collection.InsertOne(ctx, strategy)
+	_, err = collection.InsertOne(ctx, strategy)
 	if err != nil {
 		log.Error().Err(err).Msg("Error inserting strategy into the database:")
+		db.deleteStrategyVersion(ctx, version.VersionID)
+		return nil, err
+	}
+
+	if err := db.recordLifecycleTransition(ctx, strategy.BotInstanceName, nil, strategy.Lifecycle); err != nil {
 		return nil, err
 	}
 
@@ -57,6 +79,7 @@ func (db *DB) ReadStrategyByName(ctx context.Context, botInstanceName string) (*
 		log.Error().Err(err).Msg("Error getting strategy from the database:")
 		return nil, err
 	}
+	applyLifecycle(&strategy)
 
 	return &strategy, nil
 }
@@ -77,6 +100,9 @@ func (db *DB) ReadAllStrategies(ctx context.Context) ([]*model.Strategy, error)
 		log.Error().Err(err).Msg("Error decoding all strategies:")
 		return nil, err
 	}
+	for _, strategy := range strategies {
+		applyLifecycle(strategy)
+	}
 
 	return strategies, nil
 }
@@ -103,21 +129,62 @@ func (db *DB) UpdateStrategy(ctx context.Context, botInstanceName string, input
 		TakeProfitPercentage: &input.TakeProfitPercentage,
 		StopLossPercentage:   &input.StopLossPercentage,
 		ATRtollerance:        input.ATRtollerance,
+		MinFearGreed:         input.MinFearGreed,
+		MaxFearGreed:         input.MaxFearGreed,
+		AllowedSentiments:    input.AllowedSentiments,
 		FeesTotal:            input.FeesTotal,
 		Tested:               input.Tested,
 		Owner:                &input.Owner,
 		CreatedOn:            input.CreatedOn,
 	}
+	current, err := db.ReadStrategyByName(ctx, botInstanceName)
+	if err != nil {
+		return nil, err
+	}
+	updatedStrategy.StartingBalance = current.StartingBalance
+	updatedStrategy.Lifecycle = current.Lifecycle
+	if input.Lifecycle != nil && *input.Lifecycle != current.Lifecycle {
+		if !CanTransition(current.Lifecycle, *input.Lifecycle) {
+			return nil, fmt.Errorf("cannot move strategy %s from %s to %s", botInstanceName, current.Lifecycle, *input.Lifecycle)
+		}
+		updatedStrategy.Lifecycle = *input.Lifecycle
+	}
+	applyLifecycle(updatedStrategy)
+
+	// Parameters are versioned: a change creates a new immutable version, so
+	// trade outcomes recorded against earlier versions keep their parameters.
+	latest, err := db.readLatestStrategyVersion(ctx, botInstanceName)
+	if err != nil {
+		return nil, err
+	}
+	created := latest == nil || !sameParameters(latest, updatedStrategy)
+	if created {
+		latest, err = db.createStrategyVersion(ctx, updatedStrategy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	updatedStrategy.VersionID = &latest.VersionID
+	updatedStrategy.Version = &latest.Version
 
 	filter := bson.D{{"botinstancename", botInstanceName}}
 	update := bson.D{{"$set", updatedStrategy}}
 
-	_, err := collection.UpdateOne(ctx, filter, update)
+	_, err = collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		log.Error().Err(err).Msg("Error updating strategy in the database:")
+		if created {
+			db.deleteStrategyVersion(ctx, latest.VersionID)
+		}
 		return nil, err
 	}
 
+	if updatedStrategy.Lifecycle != current.Lifecycle {
+		if err := db.recordLifecycleTransition(ctx, botInstanceName, &current.Lifecycle, updatedStrategy.Lifecycle); err != nil {
+			return nil, err
+		}
+	}
+
 	return updatedStrategy, nil
 }
 
@@ -153,7 +220,7 @@ func (db *DB) UpdateCountersAndBalance(ctx context.Context, botInstanceName stri
 				}
 				return 0
 			}()},
-			{"netwincounter", func() int {
+			{"netgaincounter", func() int {
 				if incrementNetGain {
 					return 1
 				}
@@ -181,20 +248,75 @@ func (db *DB) UpdateCountersAndBalance(ctx context.Context, botInstanceName stri
 	return nil
 }
 
-// UpdateTested updates the tested status in the database for a specific strategy.
+// UpdateMarkAsTested is the legacy switch for the tested status. It moves the
+// strategy's lifecycle to match, a tested bot being validated and an untested
+// one paper trading, and so is refused where that move is not allowed.
 func (db *DB) UpdateMarkAsTested(ctx context.Context, botInstanceName string, tested bool) error {
+	_, err := db.UpdateStrategyLifecycle(ctx, botInstanceName, lifecycleFromTested(&tested))
+	return err
+}
+
+// UpdateStrategyLifecycle moves a strategy to a new lifecycle state and returns the updated strategy.
+// Moves not listed in allowedTransitions are rejected.
+func (db *DB) UpdateStrategyLifecycle(ctx context.Context, botInstanceName string, lifecycle model.StrategyLifecycle) (*model.Strategy, error) {
 	collection := db.client.Database("go_trading_db").Collection("BotDetails")
 
+	current, err := db.ReadStrategyByName(ctx, botInstanceName)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("strategy %s not found", botInstanceName)
+		}
+		return nil, err
+	}
+	if current.Lifecycle == lifecycle {
+		return current, nil
+	}
+	if !CanTransition(current.Lifecycle, lifecycle) {
+		return nil, fmt.Errorf("cannot move strategy %s from %s to %s", botInstanceName, current.Lifecycle, lifecycle)
+	}
+
 	filter := bson.D{{"botinstancename", botInstanceName}}
-	update := bson.D{{"$set", bson.D{{"tested", tested}}}}
+	update := bson.D{{"$set", bson.D{
+		{"lifecycle", lifecycle},
+		{"tested", isTested(lifecycle)},
+	}}}
 
-	_, err := collection.UpdateOne(ctx, filter, update)
+	_, err = collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to update tested status.")
-		return err
+		log.Error().Err(err).Msg("Failed to update strategy lifecycle.")
+		return nil, err
 	}
 
-	return nil
+	if err := db.recordLifecycleTransition(ctx, botInstanceName, &current.Lifecycle, lifecycle); err != nil {
+		return nil, err
+	}
+
+	return db.ReadStrategyByName(ctx, botInstanceName)
+}
+
+// lifecycleFromTested maps the Tested flag onto a lifecycle state. Untested bots
+// are the ones being paper traded; tested bots have finished evaluation.
+func lifecycleFromTested(tested *bool) model.StrategyLifecycle {
+	if tested != nil && *tested {
+		return model.StrategyLifecycleValidated
+	}
+	return model.StrategyLifecyclePaper
+}
+
+// isTested reports whether a strategy in the lifecycle state counts as tested,
+// which is every state except those that trade.
+func isTested(lifecycle model.StrategyLifecycle) bool {
+	return lifecycle != model.StrategyLifecyclePaper && lifecycle != model.StrategyLifecycleLive
+}
+
+// applyLifecycle fills in the lifecycle of strategies saved before it existed
+// and keeps Tested in step with it, so older clients still see the right flag.
+func applyLifecycle(strategy *model.Strategy) {
+	if !strategy.Lifecycle.IsValid() {
+		strategy.Lifecycle = lifecycleFromTested(strategy.Tested)
+	}
+	tested := isTested(strategy.Lifecycle)
+	strategy.Tested = &tested
 }
 
 // DeleteStrategy deletes a strategy from the database.
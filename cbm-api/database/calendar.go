@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/calendar"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrUnknownCalendarToken is returned for a feed token that was never issued
+// or has been reset.
+var ErrUnknownCalendarToken = errors.New("unknown calendar token")
+
+// calendarToken is the secret in a user's feed URL, which calendar clients
+// send in place of a login.
+type calendarToken struct {
+	Token     string    `bson:"token"`
+	UserID    string    `bson:"userid"`
+	CreatedAt time.Time `bson:"createdat"`
+}
+
+// CalendarToken returns the user's feed token, issuing one if they have none.
+// With reset the old token is replaced, so URLs already handed out stop working.
+func (db *DB) CalendarToken(ctx context.Context, userID string, reset bool) (string, error) {
+	collection := db.client.Database("go_trading_db").Collection("CalendarTokens")
+
+	if !reset {
+		var existing calendarToken
+		err := collection.FindOne(ctx, bson.M{"userid": userID}).Decode(&existing)
+		if err == nil {
+			return existing.Token, nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			log.Error().Err(err).Msg("Error retrieving calendar token:")
+			return "", err
+		}
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := calendarToken{Token: hex.EncodeToString(secret), UserID: userID, CreatedAt: time.Now()}
+
+	if _, err := collection.DeleteMany(ctx, bson.M{"userid": userID}); err != nil {
+		log.Error().Err(err).Msg("Error revoking calendar tokens:")
+		return "", err
+	}
+	if _, err := collection.InsertOne(ctx, token); err != nil {
+		log.Error().Err(err).Msg("Error inserting calendar token:")
+		return "", err
+	}
+
+	return token.Token, nil
+}
+
+// ReadCalendarFeed retrieves what the feed of a token shows: the open tasks
+// assigned to its user that pass the filter, and the due dates of the open
+// projects assigned to them or holding those tasks.
+func (db *DB) ReadCalendarFeed(ctx context.Context, token string, filter calendar.Filter) (*calendar.Feed, error) {
+	tokens := db.client.Database("go_trading_db").Collection("CalendarTokens")
+	users := db.client.Database("go_trading_db").Collection("Customers")
+	tasksCollection := db.client.Database("go_trading_db").Collection("Tasks")
+	projectsCollection := db.client.Database("go_trading_db").Collection("Projects")
+
+	var issued calendarToken
+	err := tokens.FindOne(ctx, bson.M{"token": token}).Decode(&issued)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrUnknownCalendarToken
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving calendar token:")
+		return nil, err
+	}
+
+	var user model.User
+	err = users.FindOne(ctx, bson.M{"id": issued.UserID}).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) || user.IsDeleted {
+		return nil, ErrUnknownCalendarToken
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving calendar user:")
+		return nil, err
+	}
+
+	assignee := assigneePattern(&user)
+	stored, err := findTasks(ctx, tasksCollection, bson.M{
+		"assignedTo": assignee,
+		"status":     bson.M{"$nin": []string{gtd.Complete, gtd.SomedayMaybe}},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving calendar tasks:")
+		return nil, err
+	}
+
+	feed := &calendar.Feed{
+		Name:     fmt.Sprintf("%s %s tasks", user.FirstName, user.LastName),
+		Tasks:    []*model.Task{},
+		Projects: []*model.Project{},
+		Filter:   filter,
+	}
+	var projectIDs []string
+	for _, task := range toModelTasks(stored) {
+		if !filter.MatchTask(task) {
+			continue
+		}
+		feed.Tasks = append(feed.Tasks, task)
+		if task.ProjectID != nil {
+			projectIDs = append(projectIDs, *task.ProjectID)
+		}
+	}
+
+	cursor, err := projectsCollection.Find(ctx, bson.M{
+		"sop":     false,
+		"status":  bson.M{"$nin": []string{"completed", "archived"}},
+		"duedate": bson.M{"$ne": nil},
+		"$or": bson.A{
+			bson.M{"assignedto": assignee},
+			bson.M{"id": bson.M{"$in": projectIDs}},
+		},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving calendar projects:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []*model.Project
+	if err := cursor.All(ctx, &projects); err != nil {
+		log.Error().Err(err).Msg("Error decoding calendar projects:")
+		return nil, err
+	}
+
+	// Projects holding a shown task are shown; ones only assigned to the user
+	// pass the filter's labels, and are left out when it picks departments.
+	holding := map[string]bool{}
+	for _, id := range projectIDs {
+		holding[id] = true
+	}
+	for _, project := range projects {
+		if holding[project.ID] || (len(filter.Departments) == 0 && filter.MatchProject(project)) {
+			feed.Projects = append(feed.Projects, project)
+		}
+	}
+
+	return feed, nil
+}
+
+// assigneePattern matches the ways a task or project may be assigned to the
+// user: their ID, email, or first and last name, ignoring case. A first name
+// alone is left out, as it may be another user's.
+func assigneePattern(user *model.User) bson.M {
+	names := []string{user.ID, user.Email}
+	if user.FirstName != "" && user.LastName != "" {
+		names = append(names, user.FirstName+" "+user.LastName, user.FirstName+"."+user.LastName)
+	}
+
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != "" {
+			quoted = append(quoted, regexp.QuoteMeta(name))
+		}
+	}
+	return bson.M{"$regex": "^(" + strings.Join(quoted, "|") + ")$", "$options": "i"}
+}
@@ -111,6 +111,28 @@ func (db *DB) ReadFearAndGreedIndexAtTimestamp(ctx context.Context, timestamp in
 	return &index, nil
 }
 
+// ReadFearAndGreedIndexForTime returns the index in force at the given time,
+// which is the latest daily value recorded at or before it.
+func (db *DB) ReadFearAndGreedIndexForTime(ctx context.Context, timestamp int) (*model.FearAndGreedIndex, error) {
+	collection := db.client.Database("go_trading_db").Collection("fear_and_greed_index")
+
+	filter := bson.M{"timestamp": bson.M{"$lte": timestamp}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+
+	var index model.FearAndGreedIndex
+	err := collection.FindOne(ctx, filter, opts).Decode(&index)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Warn().Int("timestamp", timestamp).Msg("No fear and greed index found at or before this time")
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Failed to find fear and greed index for time")
+		return nil, err
+	}
+
+	return &index, nil
+}
+
 func (db *DB) ReadFearAndGreedIndexCount(ctx context.Context) (int, error) {
 	collection := db.client.Database("go_trading_db").Collection("fear_and_greed_index")
 
@@ -0,0 +1,224 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// schedulerJobs are the jobs the scheduler service runs, and so the only ones
+// a manual run can be queued for.
+var schedulerJobs = []string{"fetchPrices", "fetchFearAndGreedIndex", "fetchLiquidity", "promoteDeferredTasks"}
+
+// CreateJobRun records a run the scheduler has started or skipped. A job has
+// at most one run queued and one running, so recording a second fails.
+func (db *DB) CreateJobRun(ctx context.Context, input model.JobRunInput) (*model.JobRun, error) {
+	run, err := db.insertJobRun(ctx, input)
+	if err != nil {
+		log.Error().Err(err).Msg("Error inserting job run into the database:")
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (db *DB) insertJobRun(ctx context.Context, input model.JobRunInput) (*model.JobRun, error) {
+	collection := db.client.Database("go_trading_db").Collection("JobRuns")
+
+	now := int(time.Now().Unix())
+	run := &model.JobRun{
+		RunID:    primitive.NewObjectID().Hex(),
+		Job:      input.Job,
+		Slot:     input.Slot,
+		Trigger:  input.Trigger,
+		Status:   input.Status,
+		QueuedAt: now,
+		Error:    input.Error,
+	}
+	if input.Status == model.JobStatusRunning {
+		run.StartedAt = &now
+	}
+
+	if _, err := collection.InsertOne(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// FinishJobRun records how a running job finished. It returns nil if the run
+// does not exist.
+func (db *DB) FinishJobRun(ctx context.Context, runID string, input model.JobRunResult) (*model.JobRun, error) {
+	collection := db.client.Database("go_trading_db").Collection("JobRuns")
+
+	update := bson.M{"$set": bson.M{
+		"status":     input.Status,
+		"finishedat": int(time.Now().Unix()),
+		"durationms": input.DurationMs,
+		"error":      input.Error,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var run model.JobRun
+	err := collection.FindOneAndUpdate(ctx, bson.M{"runid": runID}, update, opts).Decode(&run)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Warn().Str("runID", runID).Msg("No job run found to finish")
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error finishing job run:")
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// ClaimJobRun marks the oldest queued run of the job as running and returns
+// it, or nil if none is queued or one is already running. The update is
+// atomic, so a run is only ever claimed once.
+func (db *DB) ClaimJobRun(ctx context.Context, job string) (*model.JobRun, error) {
+	collection := db.client.Database("go_trading_db").Collection("JobRuns")
+
+	filter := bson.M{"job": job, "status": model.JobStatusQueued}
+	update := bson.M{"$set": bson.M{
+		"status":    model.JobStatusRunning,
+		"startedat": int(time.Now().Unix()),
+	}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "queuedat", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var run model.JobRun
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&run)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			log.Warn().Str("job", job).Msg("Job already has a run going, leaving the manual run queued")
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error claiming job run:")
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// TriggerJob queues a manual run of the job. If one is already queued it is
+// returned instead, so repeated triggers run the job once. A job has at most
+// one run queued, so of two triggers at once, the second gets the first's run.
+func (db *DB) TriggerJob(ctx context.Context, name string) (*model.JobRun, error) {
+	if !slices.Contains(schedulerJobs, name) {
+		return nil, fmt.Errorf("unknown job %q, expected one of %v", name, schedulerJobs)
+	}
+
+	collection := db.client.Database("go_trading_db").Collection("JobRuns")
+
+	run, err := db.insertJobRun(ctx, model.JobRunInput{
+		Job:     name,
+		Trigger: model.JobTriggerManual,
+		Status:  model.JobStatusQueued,
+	})
+	if err == nil {
+		return run, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		log.Error().Err(err).Msg("Error queuing job run:")
+		return nil, err
+	}
+
+	var queued model.JobRun
+	err = collection.FindOne(ctx, bson.M{"job": name, "status": model.JobStatusQueued}).Decode(&queued)
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading the queued job run:")
+		return nil, err
+	}
+
+	return &queued, nil
+}
+
+// FailStaleJobRuns marks runs left running for longer than olderThan seconds
+// as failed, as a scheduler that died mid run leaves them, so that the job
+// can run again. It returns how many it marked.
+func (db *DB) FailStaleJobRuns(ctx context.Context, olderThan int) (int, error) {
+	collection := db.client.Database("go_trading_db").Collection("JobRuns")
+
+	now := int(time.Now().Unix())
+	reason := "left running by a scheduler that stopped"
+	filter := bson.M{"status": model.JobStatusRunning, "startedat": bson.M{"$lt": now - olderThan}}
+	update := bson.M{"$set": bson.M{
+		"status":     model.JobStatusFailed,
+		"finishedat": now,
+		"error":      reason,
+	}}
+
+	result, err := collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.Error().Err(err).Msg("Error failing stale job runs:")
+		return 0, err
+	}
+
+	return int(result.ModifiedCount), nil
+}
+
+// ReadJobRuns retrieves job runs, most recent first, optionally for a single job or status.
+func (db *DB) ReadJobRuns(ctx context.Context, job *string, status *model.JobStatus, limit *int) ([]*model.JobRun, error) {
+	collection := db.client.Database("go_trading_db").Collection("JobRuns")
+
+	filter := bson.M{}
+	if job != nil {
+		filter["job"] = *job
+	}
+	if status != nil {
+		filter["status"] = *status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "queuedat", Value: -1}})
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying job runs:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	runs := []*model.JobRun{}
+	if err := cursor.All(ctx, &runs); err != nil {
+		log.Error().Err(err).Msg("Error decoding job runs:")
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// ReadLastJobSlot retrieves the latest schedule slot recorded for the job,
+// whatever the run's status, or nil if the job has never been scheduled.
+func (db *DB) ReadLastJobSlot(ctx context.Context, job string) (*int, error) {
+	collection := db.client.Database("go_trading_db").Collection("JobRuns")
+
+	filter := bson.M{"job": job, "slot": bson.M{"$ne": nil}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "slot", Value: -1}})
+
+	var run model.JobRun
+	err := collection.FindOne(ctx, filter, opts).Decode(&run)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error getting last job slot:")
+		return nil, err
+	}
+
+	return run.Slot, nil
+}
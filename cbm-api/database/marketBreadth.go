@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateMarketBreadth stores a tick's breadth snapshot, replacing any already
+// stored for the same timestamp so replays can be re-run.
+func (db *DB) CreateMarketBreadth(ctx context.Context, input model.MarketBreadthInput) (*model.MarketBreadth, error) {
+	collection := db.client.Database("go_trading_db").Collection("MarketBreadth")
+
+	filter := bson.M{"timestamp": input.Timestamp}
+	_, err := collection.ReplaceOne(ctx, filter, input, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Error().Err(err).Msg("Error saving market breadth:")
+		return nil, err
+	}
+
+	var breadth model.MarketBreadth
+	if err := collection.FindOne(ctx, filter).Decode(&breadth); err != nil {
+		log.Error().Err(err).Msg("Error reading back market breadth:")
+		return nil, err
+	}
+
+	return &breadth, nil
+}
+
+// DeleteMarketBreadth deletes the breadth snapshot for the given timestamp.
+func (db *DB) DeleteMarketBreadth(ctx context.Context, timestamp int) (bool, error) {
+	collection := db.client.Database("go_trading_db").Collection("MarketBreadth")
+
+	result, err := collection.DeleteOne(ctx, bson.M{"timestamp": timestamp})
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting market breadth:")
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// ReadMarketBreadthAt retrieves the latest breadth snapshot at or before the given timestamp.
+func (db *DB) ReadMarketBreadthAt(ctx context.Context, timestamp int) (*model.MarketBreadth, error) {
+	collection := db.client.Database("go_trading_db").Collection("MarketBreadth")
+
+	filter := bson.M{"timestamp": bson.M{"$lte": timestamp}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+
+	var breadth model.MarketBreadth
+	err := collection.FindOne(ctx, filter, opts).Decode(&breadth)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Warn().Int("timestamp", timestamp).Msg("No market breadth found at or before this time")
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error getting market breadth:")
+		return nil, err
+	}
+
+	return &breadth, nil
+}
+
+// ReadMarketBreadth retrieves the breadth snapshots between the given
+// timestamps, most recent first.
+func (db *DB) ReadMarketBreadth(ctx context.Context, from, to, limit *int) ([]*model.MarketBreadth, error) {
+	collection := db.client.Database("go_trading_db").Collection("MarketBreadth")
+
+	filter := bson.M{}
+	timestamp := bson.M{}
+	if from != nil {
+		timestamp["$gte"] = *from
+	}
+	if to != nil {
+		timestamp["$lte"] = *to
+	}
+	if len(timestamp) > 0 {
+		filter["timestamp"] = timestamp
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying market breadth:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	snapshots := []*model.MarketBreadth{}
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		log.Error().Err(err).Msg("Error decoding market breadth:")
+		return nil, err
+	}
+
+	return snapshots, nil
+}
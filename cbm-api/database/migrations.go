@@ -0,0 +1,574 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/migrate"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migrations are the schema changes of go_trading_db, in order. Append new
+// ones; never renumber or edit one that has shipped.
+var Migrations = []migrate.Migration{
+	{
+		Version:     1,
+		Description: "create indexes",
+		Up:          createIndexes,
+		Down:        dropIndexes,
+	},
+	{
+		Version:     2,
+		Description: "rename camelCase Customers fields to the keys users are read by",
+		Up:          renameUserFields,
+		// No Down: which fields were written under the camelCase keys is lost.
+	},
+	{
+		Version:     3,
+		Description: "fold BotDetails netwincounter into netgaincounter",
+		Up:          foldNetWinCounter,
+		// No Down: the net gains counted under netwincounter are lost in the sum.
+	},
+	{
+		Version:     4,
+		Description: "allow one queued and one running JobRuns run per job",
+		Up:          uniqueJobRuns,
+		Down:        dropUniqueJobRuns,
+	},
+	{
+		Version:     5,
+		Description: "allow one running TimeEntries timer per user",
+		Up:          uniqueRunningTimers,
+		Down:        dropUniqueRunningTimers,
+	},
+	{
+		Version:     6,
+		Description: "allow one StrategyVersions version per number per strategy",
+		Up:          uniqueStrategyVersions,
+		Down:        dropUniqueStrategyVersions,
+	},
+	{
+		Version:     7,
+		Description: "rename camelCase Projects fields to the keys projects are read by",
+		Up:          renameProjectFields,
+		// No Down: which fields were written under the camelCase keys is lost.
+	},
+}
+
+// collectionIndexes are the indexes a collection needs.
+type collectionIndexes struct {
+	collection string
+	indexes    []mongo.IndexModel
+}
+
+// indexes are the indexes created by migration 1. Add indexes in a new
+// migration rather than here.
+var indexes = []collectionIndexes{
+	{"HistoricPrices", []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "pair.symbol", Value: 1},
+				{Key: "timestamp", Value: -1},
+			},
+			Options: options.Index().SetName("symbol_timestamp_desc"),
+		},
+		{
+			Keys:    bson.D{{Key: "timestamp", Value: -1}},
+			Options: options.Index().SetName("timestamp_desc"),
+		},
+	}},
+	// One reading per timestamp
+	{"fear_and_greed_index", []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "timestamp", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("timestamp_unique"),
+		},
+	}},
+	// One snapshot per tick
+	{"MarketBreadth", []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "timestamp", Value: -1}},
+			Options: options.Index().SetUnique(true).SetName("timestamp_unique"),
+		},
+	}},
+	// A bot's most recent trades
+	{"TradesOpened", []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "botname", Value: 1},
+				{Key: "timestamp", Value: -1},
+			},
+			Options: options.Index().SetName("botname_timestamp_desc"),
+		},
+	}},
+	// A job's runs and its latest schedule slot
+	{"JobRuns", []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "runid", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("runid_unique"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "job", Value: 1},
+				{Key: "queuedat", Value: -1},
+			},
+			Options: options.Index().SetName("job_queuedat_desc"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "job", Value: 1},
+				{Key: "slot", Value: -1},
+			},
+			Options: options.Index().SetName("job_slot_desc"),
+		},
+	}},
+	// Running timers, a task's entries and reports
+	{"TimeEntries", []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "userid", Value: 1},
+				{Key: "stoppedat", Value: 1},
+			},
+			Options: options.Index().SetName("userid_stoppedat"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "taskid", Value: 1},
+				{Key: "startedat", Value: -1},
+			},
+			Options: options.Index().SetName("taskid_startedat_desc"),
+		},
+		{
+			Keys:    bson.D{{Key: "startedat", Value: -1}},
+			Options: options.Index().SetName("startedat_desc"),
+		},
+	}},
+	// A task's or project's history and a user's mentions
+	{"Activity", []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "targetid", Value: 1},
+				{Key: "at", Value: 1},
+			},
+			Options: options.Index().SetName("targetid_at"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "comment.mentions", Value: 1},
+				{Key: "at", Value: -1},
+			},
+			Options: options.Index().SetName("mentions_at_desc"),
+		},
+	}},
+	// One record, so one claim, per migration
+	{migrate.Collection, []mongo.IndexModel{migrate.VersionIndex}},
+	// Looking up a feed and a user's token
+	{"CalendarTokens", []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token", Value: 1}},
+			Options: options.Index().SetName("token_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "userid", Value: 1}},
+			Options: options.Index().SetName("userid"),
+		},
+	}},
+}
+
+func createIndexes(ctx context.Context, db *mongo.Database) error {
+	for _, c := range indexes {
+		if _, err := db.Collection(c.collection).Indexes().CreateMany(ctx, c.indexes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropIndexes(ctx context.Context, db *mongo.Database) error {
+	for _, c := range indexes {
+		for _, index := range c.indexes {
+			_, err := db.Collection(c.collection).Indexes().DropOne(ctx, *index.Options.Name)
+			if err != nil && !isNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isNotFound reports whether a command failed on a missing index or collection,
+// which a rollback that failed part way leaves behind.
+func isNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && (cmdErr.Code == 26 || cmdErr.Code == 27) // NamespaceNotFound, IndexNotFound
+}
+
+// userFieldRenames are the fields UpdateUser used to write under keys the
+// model.User decoder never reads, to the keys it does.
+var userFieldRenames = bson.M{
+	"firstName":              "firstname",
+	"lastName":               "lastname",
+	"mobileNumber":           "mobilenumber",
+	"verifiedEmail":          "verifiedemail",
+	"verifiedMobile":         "verifiedmobile",
+	"openToTrade":            "opentotrade",
+	"binanceAPI":             "binanceapi",
+	"preferredContactMethod": "preferredcontactmethod",
+	"invitedBy":              "invitedby",
+	"joinedBallot":           "joinedballot",
+	"isPaidMember":           "ispaidmember",
+	"isDeleted":              "isdeleted",
+	"upatedAt":               "updatedat",
+}
+
+// renameUserFields moves each field to its lowercase key, where the update
+// is newer than the value written on creation.
+func renameUserFields(ctx context.Context, db *mongo.Database) error {
+	customers := db.Collection("Customers")
+	for from, to := range userFieldRenames {
+		filter := bson.M{from: bson.M{"$exists": true}}
+		if _, err := customers.UpdateMany(ctx, filter, bson.M{"$rename": bson.M{from: to}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectFieldRenames are the fields UpdateProject used to write under keys
+// the model.Project decoder never reads, to the keys it does.
+var projectFieldRenames = bson.M{
+	"assignedTo": "assignedto",
+	"dueDate":    "duedate",
+	"updatedAt":  "updatedat",
+}
+
+// renameProjectFields moves each field to its lowercase key. Both keys are
+// set on projects updated since UpdateProject was fixed, so the camelCase
+// ones are dropped where the lowercase updatedat is the newer.
+func renameProjectFields(ctx context.Context, db *mongo.Database) error {
+	projects := db.Collection("Projects")
+
+	unset := bson.M{}
+	for from := range projectFieldRenames {
+		unset[from] = ""
+	}
+	stale := bson.M{"$expr": bson.M{"$lt": bson.A{"$updatedAt", "$updatedat"}}}
+	if _, err := projects.UpdateMany(ctx, stale, bson.M{"$unset": unset}); err != nil {
+		return err
+	}
+
+	for from, to := range projectFieldRenames {
+		filter := bson.M{from: bson.M{"$exists": true}}
+		if _, err := projects.UpdateMany(ctx, filter, bson.M{"$rename": bson.M{from: to}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// foldNetWinCounter adds the net gains counted under netwincounter to
+// netgaincounter, where Strategy.NetGainCounter is read from.
+func foldNetWinCounter(ctx context.Context, db *mongo.Database) error {
+	filter := bson.M{"netwincounter": bson.M{"$exists": true}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"netgaincounter": bson.M{"$add": bson.A{
+			bson.M{"$ifNull": bson.A{"$netgaincounter", 0}},
+			"$netwincounter",
+		}}}}},
+		{{Key: "$unset", Value: "netwincounter"}},
+	}
+	_, err := db.Collection("BotDetails").UpdateMany(ctx, filter, update)
+	return err
+}
+
+// jobRunIndexes let a job have one run queued and one running, so that
+// triggers racing each other queue one run, and a scheduler cannot start a
+// job that is running. They are two indexes, not one partial on $in, as that
+// needs MongoDB 6.0.
+var jobRunIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "job", Value: 1}},
+		Options: options.Index().SetName("job_queued_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": model.JobStatusQueued}),
+	},
+	{
+		Keys: bson.D{{Key: "job", Value: 1}},
+		Options: options.Index().SetName("job_running_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": model.JobStatusRunning}),
+	},
+}
+
+// uniqueJobRuns settles the jobs with more than one run queued or running,
+// keeping the oldest queued and newest running, then creates jobRunIndexes.
+func uniqueJobRuns(ctx context.Context, db *mongo.Database) error {
+	runs := db.Collection("JobRuns")
+	extras := []struct {
+		status model.JobStatus
+		keep   bson.D // the sort putting the run to keep first
+		set    bson.M
+	}{
+		{model.JobStatusQueued, bson.D{{Key: "queuedat", Value: 1}}, bson.M{"status": model.JobStatusSkipped, "error": "queued twice"}},
+		{model.JobStatusRunning, bson.D{{Key: "startedat", Value: -1}}, bson.M{"status": model.JobStatusFailed, "error": "superseded by a later run"}},
+	}
+	for _, extra := range extras {
+		cursor, err := runs.Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"status": extra.status}}},
+			{{Key: "$sort", Value: extra.keep}},
+			{{Key: "$group", Value: bson.M{"_id": "$job", "runs": bson.M{"$push": "$runid"}}}},
+		})
+		if err != nil {
+			return err
+		}
+		var groups []struct {
+			Runs []string `bson:"runs"`
+		}
+		if err := cursor.All(ctx, &groups); err != nil {
+			return err
+		}
+		for _, group := range groups {
+			if len(group.Runs) < 2 {
+				continue
+			}
+			filter := bson.M{"runid": bson.M{"$in": group.Runs[1:]}}
+			if _, err := runs.UpdateMany(ctx, filter, bson.M{"$set": extra.set}); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := runs.Indexes().CreateMany(ctx, jobRunIndexes)
+	return err
+}
+
+func dropUniqueJobRuns(ctx context.Context, db *mongo.Database) error {
+	for _, index := range jobRunIndexes {
+		_, err := db.Collection("JobRuns").Indexes().DropOne(ctx, *index.Options.Name)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// runningTimerIndex lets a user have one timer running, so that two starts
+// racing each other cannot both insert one. Running entries store stoppedat as
+// null, and a partial index cannot match on null by equality, only by type.
+var runningTimerIndex = mongo.IndexModel{
+	Keys: bson.D{{Key: "userid", Value: 1}},
+	Options: options.Index().SetName("userid_running_unique").SetUnique(true).
+		SetPartialFilterExpression(bson.M{"stoppedat": bson.M{"$type": "null"}}),
+}
+
+// uniqueRunningTimers stops the timers of the users with more than one
+// running, each when the user's next one started, as starting a timer does,
+// then creates runningTimerIndex.
+func uniqueRunningTimers(ctx context.Context, db *mongo.Database) error {
+	entries := db.Collection("TimeEntries")
+	cursor, err := entries.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"stoppedat": nil}}},
+		{{Key: "$sort", Value: bson.D{{Key: "startedat", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$userid", "entries": bson.M{"$push": "$$ROOT"}}}},
+	})
+	if err != nil {
+		return err
+	}
+	var groups []struct {
+		Entries []model.TimeEntry `bson:"entries"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return err
+	}
+
+	stoppedTasks := map[string]bool{}
+	for _, group := range groups {
+		for i := 0; i+1 < len(group.Entries); i++ {
+			entry, next := group.Entries[i], group.Entries[i+1]
+			started, err := time.Parse(time.RFC3339, entry.StartedAt)
+			if err != nil {
+				return fmt.Errorf("time entry %s has an invalid start %q", entry.ID, entry.StartedAt)
+			}
+			stopped, err := time.Parse(time.RFC3339, next.StartedAt)
+			if err != nil {
+				return fmt.Errorf("time entry %s has an invalid start %q", next.ID, next.StartedAt)
+			}
+			seconds := int(max(stopped.Sub(started), 0) / time.Second)
+			update := bson.M{"$set": bson.M{"stoppedat": next.StartedAt, "durationseconds": seconds}}
+			if _, err := entries.UpdateOne(ctx, bson.M{"id": entry.ID, "stoppedat": nil}, update); err != nil {
+				return err
+			}
+			stoppedTasks[entry.TaskID] = true
+		}
+	}
+	for taskID := range stoppedTasks {
+		if err := rollUpDuration(ctx, db, taskID); err != nil {
+			return err
+		}
+	}
+
+	_, err = entries.Indexes().CreateOne(ctx, runningTimerIndex)
+	return err
+}
+
+func dropUniqueRunningTimers(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("TimeEntries").Indexes().DropOne(ctx, *runningTimerIndex.Options.Name)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// strategyVersionIndex numbers each of a strategy's versions once, so that
+// updates racing each other cannot both create the same version.
+var strategyVersionIndex = mongo.IndexModel{
+	Keys: bson.D{
+		{Key: "botinstancename", Value: 1},
+		{Key: "version", Value: 1},
+	},
+	Options: options.Index().SetName("botinstancename_version_unique").SetUnique(true),
+}
+
+// uniqueStrategyVersions renumbers the versions sharing a number with an
+// earlier one to follow the strategy's latest, keeping them as trade outcomes
+// may refer to them, then creates strategyVersionIndex.
+func uniqueStrategyVersions(ctx context.Context, db *mongo.Database) error {
+	versions := db.Collection("StrategyVersions")
+	cursor, err := versions.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "createdon", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"botinstancename": "$botinstancename", "version": "$version"},
+			"versions": bson.M{"$push": "$versionid"},
+		}}},
+		{{Key: "$match", Value: bson.M{"versions.1": bson.M{"$exists": true}}}},
+	})
+	if err != nil {
+		return err
+	}
+	var groups []struct {
+		ID struct {
+			BotInstanceName string `bson:"botinstancename"`
+		} `bson:"_id"`
+		Versions []string `bson:"versions"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return err
+	}
+
+	next := map[string]int{}
+	for _, group := range groups {
+		bot := group.ID.BotInstanceName
+		if _, found := next[bot]; !found {
+			var latest model.StrategyVersion
+			opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+			if err := versions.FindOne(ctx, bson.M{"botinstancename": bot}, opts).Decode(&latest); err != nil {
+				return err
+			}
+			next[bot] = latest.Version + 1
+		}
+		for _, versionID := range group.Versions[1:] {
+			number := next[bot]
+			next[bot]++
+			if _, err := versions.UpdateOne(ctx, bson.M{"versionid": versionID}, bson.M{"$set": bson.M{"version": number}}); err != nil {
+				return err
+			}
+			if _, err := db.Collection("BotDetails").UpdateMany(ctx, bson.M{"versionid": versionID}, bson.M{"$set": bson.M{"version": number}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = versions.Indexes().CreateOne(ctx, strategyVersionIndex)
+	return err
+}
+
+func dropUniqueStrategyVersions(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("StrategyVersions").Indexes().DropOne(ctx, *strategyVersionIndex.Options.Name)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) migrator() (*migrate.Migrator, error) {
+	return migrate.New(db.client.Database("go_trading_db"), Migrations)
+}
+
+// MigrationStatus lists the migrations with when each was applied.
+func (db *DB) MigrationStatus(ctx context.Context) ([]migrate.Status, error) {
+	migrator, err := db.migrator()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading migration status:")
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// Migrate applies the pending migrations up to target, all of them when
+// target is 0.
+func (db *DB) Migrate(ctx context.Context, target int) ([]migrate.Migration, error) {
+	migrator, err := db.migrator()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := migrator.Up(ctx, target)
+	if err != nil {
+		log.Error().Err(err).Int("applied", len(applied)).Msg("Error migrating database:")
+		return applied, err
+	}
+
+	return applied, nil
+}
+
+// Rollback undoes the applied migrations above target.
+func (db *DB) Rollback(ctx context.Context, target int) ([]migrate.Migration, error) {
+	migrator, err := db.migrator()
+	if err != nil {
+		return nil, err
+	}
+
+	undone, err := migrator.Down(ctx, target)
+	if err != nil {
+		log.Error().Err(err).Int("undone", len(undone)).Msg("Error rolling back database:")
+		return undone, err
+	}
+
+	return undone, nil
+}
+
+// UnlockMigrations releases the migration claims of runs that died, of every
+// migration or only of version when it is not 0.
+func (db *DB) UnlockMigrations(ctx context.Context, version int) ([]int, error) {
+	migrator, err := db.migrator()
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked, err := migrator.Unlock(ctx, version)
+	if err != nil {
+		log.Error().Err(err).Ints("unlocked", unlocked).Msg("Error unlocking migrations:")
+		return unlocked, err
+	}
+
+	return unlocked, nil
+}
+
+// VerifySchema checks the database is at the version of the latest migration.
+func (db *DB) VerifySchema(ctx context.Context) error {
+	migrator, err := db.migrator()
+	if err != nil {
+		return err
+	}
+	return migrator.Verify(ctx)
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxPageSize caps the page a client can ask for.
+const maxPageSize = 200
+
+// pageCursor marks a position in a sorted list: the sort key's value, nil when
+// the document has none, and the ID that breaks ties.
+type pageCursor struct {
+	Value *string `json:"v"`
+	ID    string  `json:"id"`
+}
+
+// EncodeCursor returns the opaque cursor for a document with the sort value and ID.
+func EncodeCursor(value *string, id string) string {
+	data, _ := json.Marshal(pageCursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor returns the sort value and ID a cursor was made from.
+func DecodeCursor(cursor string) (*string, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid cursor %q", cursor)
+	}
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, "", fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return c.Value, c.ID, nil
+}
+
+// keysetFilter matches the documents after the cursor in a list sorted on key
+// then id. Documents without the key sort first ascending and last descending.
+func keysetFilter(key string, desc bool, value *string, id string) bson.M {
+	cmp, idCmp := "$gt", "$gt"
+	if desc {
+		cmp, idCmp = "$lt", "$lt"
+	}
+	missing := bson.M{key: nil}
+
+	switch {
+	case value == nil && !desc:
+		return bson.M{"$or": bson.A{
+			bson.M{key: nil, "id": bson.M{idCmp: id}},
+			bson.M{key: bson.M{"$ne": nil}},
+		}}
+	case value == nil && desc:
+		return bson.M{key: nil, "id": bson.M{idCmp: id}}
+	}
+
+	after := bson.A{
+		bson.M{key: bson.M{cmp: *value}},
+		bson.M{key: *value, "id": bson.M{idCmp: id}},
+	}
+	if desc {
+		after = append(after, missing)
+	}
+	return bson.M{"$or": after}
+}
+
+// page is one page of a sorted, filtered collection.
+type page[T any] struct {
+	items    []T
+	cursors  []string
+	pageInfo *model.PageInfo
+	total    int
+}
+
+// readPage reads up to first documents matching the filter, sorted on key then
+// id, after the cursor if one is given. sortValue and id give a document's
+// position for its cursor.
+func readPage[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, key string, desc bool, first int, after *string, sortValue func(T) *string, id func(T) string) (*page[T], error) {
+	if first < 1 {
+		return nil, fmt.Errorf("first must be at least 1, got %d", first)
+	}
+	first = min(first, maxPageSize)
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if after != nil {
+		value, afterID, err := DecodeCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		filter = bson.M{"$and": bson.A{filter, keysetFilter(key, desc, value, afterID)}}
+	}
+
+	direction := 1
+	if desc {
+		direction = -1
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: key, Value: direction}, {Key: "id", Value: direction}}).
+		SetLimit(int64(first + 1))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []T
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	p := &page[T]{
+		pageInfo: &model.PageInfo{HasPreviousPage: after != nil},
+		total:    int(total),
+	}
+	if len(items) > first {
+		items = items[:first]
+		p.pageInfo.HasNextPage = true
+	}
+	p.items = items
+
+	for _, item := range items {
+		p.cursors = append(p.cursors, EncodeCursor(sortValue(item), id(item)))
+	}
+	if len(p.cursors) > 0 {
+		p.pageInfo.StartCursor = &p.cursors[0]
+		p.pageInfo.EndCursor = &p.cursors[len(p.cursors)-1]
+	}
+
+	return p, nil
+}
@@ -100,6 +100,47 @@ func (db *DB) ReadHistoricPricesBySymbol(symbol string, limit int) ([]*model.His
 
 }
 
+// ReadHistoricPricesBySymbolBefore fetches the prices for the symbol at or before
+// the given timestamp, most recent first, up to the given limit.
+func (db *DB) ReadHistoricPricesBySymbolBefore(ctx context.Context, symbol string, timestamp, limit int) ([]*model.HistoricPrices, error) {
+	collection := db.client.Database("go_trading_db").Collection("HistoricPrices")
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, bson.M{
+		"pair.symbol": symbol,
+		"timestamp":   bson.M{"$lte": timestamp},
+	}, findOptions)
+	if err != nil {
+		log.Error().Err(err).Msg("Error fetching historic prices before timestamp")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rawResults []*model.HistoricPrices
+	if err := cursor.All(ctx, &rawResults); err != nil {
+		return nil, err
+	}
+
+	// Filter to return ONLY the matched symbol in the response
+	filteredResults := []*model.HistoricPrices{}
+	for _, entry := range rawResults {
+		for _, pair := range entry.Pair {
+			if pair.Symbol == symbol {
+				filteredResults = append(filteredResults, &model.HistoricPrices{
+					Timestamp: entry.Timestamp,
+					Pair:      []*model.Pair{pair},
+				})
+				break
+			}
+		}
+	}
+
+	return filteredResults, nil
+}
+
 // ReadHistoricPricesAtTimestamp fetches historic prices at a specific timestamp.
 func (db *DB) ReadHistoricPricesAtTimestamp(timestamp int) ([]model.HistoricPrices, error) {
 	log.Info().Msgf("Querying prices from DB at Timestamp: %d", timestamp)
@@ -9,6 +9,8 @@ import (
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // / CreateActivityReport saves a new activity report to the database.
@@ -29,6 +31,8 @@ func (db *DB) CreateActivityReport(input *model.NewActivityReport) *model.Activi
 		TopBGain:       input.TopBGain,
 		TopCGain:       input.TopCGain,
 		FearGreedIndex: input.FearGreedIndex,
+		Breadth:        input.Breadth,
+		MarketStatus:   input.MarketStatus,
 	}
 }
 
@@ -71,3 +75,24 @@ func (db *DB) ReadAllActivityReports() []*model.ActivityReport {
 
 	return ActivityReports
 }
+
+// ReadActivityReportAt retrieves the latest activity report at or before the given timestamp.
+func (db *DB) ReadActivityReportAt(ctx context.Context, timestamp int) (*model.ActivityReport, error) {
+	collection := db.client.Database("go_trading_db").Collection("ActivityReports")
+
+	filter := bson.M{"timestamp": bson.M{"$lte": timestamp}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+
+	var report model.ActivityReport
+	err := collection.FindOne(ctx, filter, opts).Decode(&report)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Warn().Int("timestamp", timestamp).Msg("No activity report found at or before this time")
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error getting activity report at timestamp:")
+		return nil, err
+	}
+
+	return &report, nil
+}
@@ -2,7 +2,8 @@ package database
 
 import (
 	"context"
-
+	"math"
+	"sort"
 	"time"
 
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
@@ -240,13 +241,17 @@ func (db *DB) UpsertSymbolStats(input *model.UpsertSymbolStatsInput) *model.Symb
 		}
 	}
 
-	return &model.SymbolStats{
+	upserted := &model.SymbolStats{
 		Symbol:               input.Symbol,
 		PositionCounts:       positionCounts,
+		Decayed:              existing.Decayed,
+		History:              existing.History,
 		LiquidityEstimate:    mergedLiquidity,
 		MaxLiquidityEstimate: input.MaxLiquidityEstimate,
 		MinLiquidityEstimate: input.MinLiquidityEstimate,
 	}
+	FillSymbolStats(upserted, time.Now())
+	return upserted
 }
 
 // ReadAllSymbolStats retrieves all symbol statistics from the database.
@@ -267,6 +272,7 @@ func (db *DB) ReadAllSymbolStats() []*model.SymbolStats {
 		if err != nil {
 			log.Error().Err(err).Msg("Error decoding document:")
 		}
+		FillSymbolStats(&stat, time.Now())
 		symbolStats = append(symbolStats, &stat)
 	}
 
@@ -281,6 +287,7 @@ func (db *DB) ReadSingleSymbolStatsBySymbol(symbol string) *model.SymbolStats {
 	res := collection.FindOne(ctx, bson.M{"symbol": symbol})
 	symbolStats := model.SymbolStats{}
 	res.Decode(&symbolStats)
+	FillSymbolStats(&symbolStats, time.Now())
 	return &symbolStats
 }
 
@@ -299,3 +306,227 @@ func (db *DB) DeleteSymbolStats(ctx context.Context, symbol string) (bool, error
 
 	return result.DeletedCount > 0, nil
 }
+
+// ==========================
+// === Top Movers ===
+// ==========================
+
+const (
+	topPositions        = 10
+	symbolStatsHalfLife = 3 * 24 * time.Hour
+	symbolHistoryWindow = 30 * 24 * time.Hour
+)
+
+// moverWindows are the periods the position history is summarised over.
+var moverWindows = []struct {
+	window model.MoverWindow
+	length time.Duration
+}{
+	{model.MoverWindowDay, 24 * time.Hour},
+	{model.MoverWindowWeek, 7 * 24 * time.Hour},
+	{model.MoverWindowMonth, symbolHistoryWindow},
+}
+
+// RecordTopMovers adds a tick's top movers to their symbol stats: the
+// cumulative position means, the decayed score and the position history. The
+// symbols are read in one query and written back in one bulk write.
+func (db *DB) RecordTopMovers(ctx context.Context, input model.RecordTopMoversInput) (int, error) {
+	collection := db.client.Database("go_trading_db").Collection("SymbolStats")
+
+	if len(input.Movers) == 0 {
+		return 0, nil
+	}
+
+	symbols := make([]string, 0, len(input.Movers))
+	for _, mover := range input.Movers {
+		symbols = append(symbols, mover.Symbol)
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"symbol": bson.M{"$in": symbols}})
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading symbol stats for top movers:")
+		return 0, err
+	}
+	var existing []*model.SymbolStats
+	if err := cursor.All(ctx, &existing); err != nil {
+		log.Error().Err(err).Msg("Error decoding symbol stats for top movers:")
+		return 0, err
+	}
+	bySymbol := map[string]*model.SymbolStats{}
+	for _, stats := range existing {
+		bySymbol[stats.Symbol] = stats
+	}
+
+	models := make([]mongo.WriteModel, 0, len(input.Movers))
+	for _, mover := range input.Movers {
+		if mover.Position < 1 || mover.Position > topPositions {
+			log.Warn().Str("symbol", mover.Symbol).Int("position", mover.Position).Msg("Skipping mover outside the top positions")
+			continue
+		}
+
+		stats, ok := bySymbol[mover.Symbol]
+		if !ok {
+			stats = &model.SymbolStats{Symbol: mover.Symbol}
+			bySymbol[mover.Symbol] = stats
+		}
+		AddAppearance(stats, input.Timestamp, mover.Position, mover.Gain)
+
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"symbol": mover.Symbol}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"symbol":         stats.Symbol,
+				"positionCounts": stats.PositionCounts,
+				"decayed":        stats.Decayed,
+				"history":        stats.History,
+			}}).
+			SetUpsert(true))
+	}
+	if len(models) == 0 {
+		return 0, nil
+	}
+
+	_, err = collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		log.Error().Err(err).Msg("Error writing top movers:")
+		return 0, err
+	}
+
+	return len(models), nil
+}
+
+// ReadTopMovers ranks the symbols by their score over the window, highest first.
+func (db *DB) ReadTopMovers(ctx context.Context, window *model.MoverWindow, limit *int) ([]*model.TopMover, error) {
+	collection := db.client.Database("go_trading_db").Collection("SymbolStats")
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading symbol stats for top movers:")
+		return nil, err
+	}
+	var all []*model.SymbolStats
+	if err := cursor.All(ctx, &all); err != nil {
+		log.Error().Err(err).Msg("Error decoding symbol stats for top movers:")
+		return nil, err
+	}
+
+	chosen := model.MoverWindowDay
+	if window != nil {
+		chosen = *window
+	}
+	now := time.Now()
+
+	movers := []*model.TopMover{}
+	for _, stats := range all {
+		FillSymbolStats(stats, now)
+
+		mover := &model.TopMover{Symbol: stats.Symbol}
+		if chosen == model.MoverWindowDecayed {
+			if stats.Decayed == nil {
+				continue
+			}
+			mover.Score = stats.Decayed.Score
+			mover.AvgGain = stats.Decayed.AvgGain
+		} else {
+			for _, w := range stats.Windows {
+				if w.Window == chosen {
+					mover.Score = w.Score
+					mover.Appearances = w.Appearances
+					mover.AvgPosition = w.AvgPosition
+					mover.AvgGain = w.AvgGain
+				}
+			}
+		}
+		if mover.Score > 0 {
+			movers = append(movers, mover)
+		}
+	}
+
+	sort.Slice(movers, func(i, j int) bool {
+		if movers[i].Score == movers[j].Score {
+			return movers[i].Symbol < movers[j].Symbol
+		}
+		return movers[i].Score > movers[j].Score
+	})
+	if limit != nil && *limit > 0 && *limit < len(movers) {
+		movers = movers[:*limit]
+	}
+	for i := range movers {
+		movers[i].Rank = i + 1
+	}
+
+	return movers, nil
+}
+
+// AddAppearance records the symbol placing at the position with the given gain.
+func AddAppearance(stats *model.SymbolStats, timestamp, position int, gain float64) {
+	for len(stats.PositionCounts) < topPositions {
+		stats.PositionCounts = append(stats.PositionCounts, &model.Mean{})
+	}
+	mean := stats.PositionCounts[position-1]
+	mean.Avg = (mean.Avg*float64(mean.Count) + gain) / float64(mean.Count+1)
+	mean.Count++
+
+	weight := positionWeight(position)
+	decayed := DecayTo(stats.Decayed, timestamp)
+	decayed.AvgGain = (decayed.AvgGain*decayed.Score + gain*weight) / (decayed.Score + weight)
+	decayed.Score += weight
+	stats.Decayed = decayed
+
+	cutoff := timestamp - int(symbolHistoryWindow.Seconds())
+	history := []*model.PositionAppearance{}
+	for _, appearance := range stats.History {
+		if appearance.Timestamp > cutoff {
+			history = append(history, appearance)
+		}
+	}
+	stats.History = append(history, &model.PositionAppearance{Timestamp: timestamp, Position: position, Gain: gain})
+}
+
+// positionWeight scores first place 1 down to 0.1 for tenth.
+func positionWeight(position int) float64 {
+	return float64(topPositions+1-position) / topPositions
+}
+
+// DecayTo returns a copy of the decayed stats aged to the timestamp.
+func DecayTo(decayed *model.DecayedStats, timestamp int) *model.DecayedStats {
+	if decayed == nil {
+		return &model.DecayedStats{UpdatedAt: timestamp}
+	}
+	aged := *decayed
+	if elapsed := timestamp - decayed.UpdatedAt; elapsed > 0 {
+		aged.Score *= math.Pow(0.5, float64(elapsed)/symbolStatsHalfLife.Seconds())
+		aged.UpdatedAt = timestamp
+	}
+	return &aged
+}
+
+// FillSymbolStats ages the decayed score to now and summarises the position
+// history into the 24h, 7d and 30d windows ending now.
+func FillSymbolStats(stats *model.SymbolStats, now time.Time) {
+	if stats.Decayed != nil {
+		stats.Decayed = DecayTo(stats.Decayed, int(now.Unix()))
+	}
+
+	stats.Windows = []*model.WindowStats{}
+	for _, w := range moverWindows {
+		summary := &model.WindowStats{Window: w.window, PositionCounts: make([]int, topPositions)}
+		cutoff := int(now.Add(-w.length).Unix())
+
+		var positions, gains float64
+		for _, appearance := range stats.History {
+			if appearance.Timestamp <= cutoff {
+				continue
+			}
+			summary.Appearances++
+			summary.Score += positionWeight(appearance.Position)
+			summary.PositionCounts[appearance.Position-1]++
+			positions += float64(appearance.Position)
+			gains += appearance.Gain
+		}
+		if summary.Appearances > 0 {
+			summary.AvgPosition = positions / float64(summary.Appearances)
+			summary.AvgGain = gains / float64(summary.Appearances)
+		}
+		stats.Windows = append(stats.Windows, summary)
+	}
+}
@@ -35,6 +35,7 @@ func (db *DB) CreateTradeOutcomeReport(input *model.NewTradeOutcomeReport) *mode
 		Volume:           input.Volume,
 		FearGreedIndex:   input.FearGreedIndex,
 		MarketStatus:     input.MarketStatus,
+		VersionID:        input.VersionID,
 	}
 }
 
@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// sopOffset matches a template date given relative to the start date, e.g.
+// "+3d", "2w" or "-12h".
+var sopOffset = regexp.MustCompile(`^([+-]?)(\d+)([hdw])$`)
+
+// sopDateLayouts are the absolute date formats a template date can be given in.
+var sopDateLayouts = []string{time.RFC3339, time.DateOnly}
+
+// SopDates rebases the due and defer dates of an SOP template onto the date a
+// copy of it starts.
+type SopDates struct {
+	start  time.Time
+	layout string    // of the start date, used for every rebased date
+	anchor time.Time // earliest absolute date in the template
+}
+
+// NewSopDates returns the rebasing from the template's dates onto startDate.
+// Absolute dates keep their distance from the earliest of them, which falls
+// on the start date.
+func NewSopDates(startDate string, templateDates []string) (*SopDates, error) {
+	start, layout, err := parseSopDate(startDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date %q: %w", startDate, err)
+	}
+
+	dates := &SopDates{start: start, layout: layout}
+	for _, date := range templateDates {
+		if date == "" || sopOffset.MatchString(date) {
+			continue
+		}
+		t, _, err := parseSopDate(date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template date %q: %w", date, err)
+		}
+		if dates.anchor.IsZero() || t.Before(dates.anchor) {
+			dates.anchor = t
+		}
+	}
+
+	return dates, nil
+}
+
+// Rebase returns the template date moved onto the start date, in the start
+// date's format. An empty date stays empty.
+func (d *SopDates) Rebase(date string) (string, error) {
+	if date == "" {
+		return "", nil
+	}
+
+	if m := sopOffset.FindStringSubmatch(date); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		var offset time.Duration
+		switch m[3] {
+		case "h":
+			offset = time.Duration(n) * time.Hour
+		case "d":
+			offset = time.Duration(n) * 24 * time.Hour
+		case "w":
+			offset = time.Duration(n) * 7 * 24 * time.Hour
+		}
+		return d.start.Add(offset).Format(d.layout), nil
+	}
+
+	t, _, err := parseSopDate(date)
+	if err != nil {
+		return "", fmt.Errorf("invalid template date %q: %w", date, err)
+	}
+	return d.start.Add(t.Sub(d.anchor)).Format(d.layout), nil
+}
+
+func parseSopDate(date string) (time.Time, string, error) {
+	for _, layout := range sopDateLayouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("expected an offset such as +3d or a date in RFC 3339 or YYYY-MM-DD format")
+}
+
+// InstantiateSop copies the SOP template and all its tasks into a new active
+// project starting on startDate. Tasks are copied whole, so fields added to
+// them later come along too, but get new IDs, rebased due and defer dates,
+// and start afresh in the inbox, whatever state the template's were saved in.
+// Tasks in a department with an assignee are reassigned.
+func (db *DB) InstantiateSop(ctx context.Context, sopID, title string, assignedTo *string, startDate string, departmentAssignees []*model.DepartmentAssigneeInput) (*model.Project, error) {
+	sop, err := db.ReadSingleProjectByID(ctx, sopID)
+	if err != nil {
+		return nil, err
+	}
+	if !sop.Sop {
+		return nil, fmt.Errorf("project %s is not an SOP template", sopID)
+	}
+
+	tasksCollection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	cursor, err := tasksCollection.Find(ctx, bson.M{"projectId": sopID})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving SOP tasks:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var templateTasks []bson.M
+	if err := cursor.All(ctx, &templateTasks); err != nil {
+		log.Error().Err(err).Msg("Error decoding SOP tasks:")
+		return nil, err
+	}
+
+	var templateDates []string
+	if sop.DueDate != nil {
+		templateDates = append(templateDates, *sop.DueDate)
+	}
+	for _, task := range templateTasks {
+		for _, key := range []string{"dueDate", "deferDate"} {
+			if date, ok := task[key].(string); ok {
+				templateDates = append(templateDates, date)
+			}
+		}
+	}
+
+	dates, err := NewSopDates(startDate, templateDates)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	project := &model.Project{
+		ID:          primitive.NewObjectID().Hex(),
+		Title:       title,
+		Sop:         false,
+		Description: sop.Description,
+		Labels:      sop.Labels,
+		AssignedTo:  sop.AssignedTo,
+		Status:      "active",
+
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if assignedTo != nil {
+		project.AssignedTo = assignedTo
+	}
+	if sop.DueDate != nil {
+		dueDate, err := dates.Rebase(*sop.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		project.DueDate = &dueDate
+	}
+
+	assignees := make(map[string]string, len(departmentAssignees))
+	for _, a := range departmentAssignees {
+		assignees[a.Department] = a.AssignedTo
+	}
+
+	// Give every task its new ID first, so subtask and blocker links between
+	// template tasks can point at the copies.
+	newIDs := make(map[string]string, len(templateTasks))
+	for _, task := range templateTasks {
+		if id, ok := task["id"].(string); ok {
+			newIDs[id] = primitive.NewObjectID().Hex()
+		}
+	}
+
+	tasks := make([]interface{}, 0, len(templateTasks))
+	for _, task := range templateTasks {
+		delete(task, "_id")
+		id, _ := task["id"].(string)
+		newID, ok := newIDs[id]
+		if !ok {
+			newID = primitive.NewObjectID().Hex()
+		}
+		task["id"] = newID
+		task["projectId"] = project.ID
+		// Clear what working and completing the template's task left on it.
+		task["status"] = gtd.Inbox
+		task["duration"] = nil
+		task["nextOccurrenceId"] = nil
+
+		if parentID, ok := task["parentId"].(string); ok {
+			if newID, ok := newIDs[parentID]; ok {
+				task["parentId"] = newID
+			}
+		}
+		if blockedBy, ok := task["blockedBy"].(bson.A); ok {
+			remapped := make(bson.A, 0, len(blockedBy))
+			for _, blocker := range blockedBy {
+				if newID, ok := newIDs[fmt.Sprint(blocker)]; ok {
+					blocker = newID
+				}
+				remapped = append(remapped, blocker)
+			}
+			task["blockedBy"] = remapped
+		}
+		task["createdAt"] = now
+		task["updatedAt"] = now
+
+		for _, key := range []string{"dueDate", "deferDate"} {
+			date, ok := task[key].(string)
+			if !ok {
+				continue
+			}
+			rebased, err := dates.Rebase(date)
+			if err != nil {
+				return nil, err
+			}
+			task[key] = rebased
+		}
+
+		if department, ok := task["department"].(string); ok {
+			if assignee, ok := assignees[department]; ok {
+				task["assignedTo"] = assignee
+			}
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	projectsCollection := db.client.Database("go_trading_db").Collection("Projects")
+	if _, err := projectsCollection.InsertOne(ctx, project); err != nil {
+		log.Error().Err(err).Msg("Error inserting project into the database:")
+		return nil, err
+	}
+
+	if len(tasks) > 0 {
+		if _, err := tasksCollection.InsertMany(ctx, tasks); err != nil {
+			log.Error().Err(err).Str("projectID", project.ID).Msg("Error inserting SOP tasks, removing project:")
+			if _, delErr := tasksCollection.DeleteMany(ctx, bson.M{"projectId": project.ID}); delErr != nil {
+				log.Error().Err(delErr).Str("projectID", project.ID).Msg("Error removing partly copied tasks:")
+			}
+			if _, delErr := db.DeleteProjectByID(ctx, project.ID); delErr != nil {
+				log.Error().Err(delErr).Str("projectID", project.ID).Msg("Error removing partly instantiated project:")
+			}
+			return nil, err
+		}
+	}
+
+	created := time.Now()
+	entries := []*model.ActivityEntry{newActivity(ctx, model.ActivityTargetProject, project.ID, model.ActivityKindCreated, created)}
+	for _, task := range tasks {
+		entries = append(entries, newActivity(ctx, model.ActivityTargetTask, task.(bson.M)["id"].(string), model.ActivityKindCreated, created))
+	}
+	db.recordActivity(ctx, entries...)
+
+	return project, nil
+}
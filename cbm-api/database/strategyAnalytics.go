@@ -0,0 +1,246 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Trade outcomes from the paper trader are stamped in milliseconds, older ones
+// in seconds. Timestamps from this on are taken to be milliseconds: in seconds
+// it is the year 5138, in milliseconds 1973.
+const millisecondTimestamps int = 1e11
+
+// ReadStrategyAnalytics aggregates the bot's trade outcomes between the given
+// UNIX times, optionally broken down into buckets.
+func (db *DB) ReadStrategyAnalytics(ctx context.Context, botName string, from, to *int, breakdownBy *model.AnalyticsBreakdown) (*model.StrategyAnalytics, error) {
+	reports, err := db.readTradeOutcomesBetween(ctx, bson.M{"botname": botName}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &model.StrategyAnalytics{
+		BotName:     botName,
+		From:        from,
+		To:          to,
+		Overall:     OutcomeStats(reports),
+		BreakdownBy: breakdownBy,
+		Buckets:     []*model.AnalyticsBucket{},
+	}
+	if breakdownBy == nil {
+		return analytics, nil
+	}
+
+	groups := map[string][]*model.TradeOutcomeReport{}
+	for _, report := range reports {
+		key := BucketKey(report, *breakdownBy)
+		groups[key] = append(groups[key], report)
+	}
+	for key, group := range groups {
+		analytics.Buckets = append(analytics.Buckets, &model.AnalyticsBucket{Key: key, Stats: OutcomeStats(group)})
+	}
+	sort.Slice(analytics.Buckets, func(i, j int) bool {
+		a, b := analytics.Buckets[i], analytics.Buckets[j]
+		if a.Stats.Trades == b.Stats.Trades {
+			return a.Key < b.Key
+		}
+		return a.Stats.Trades > b.Stats.Trades
+	})
+
+	return analytics, nil
+}
+
+// ReadAllStrategyAnalytics aggregates the trade outcomes of every bot between
+// the given UNIX times, best expectancy first. It needs a start, so as not to
+// load every outcome ever recorded.
+func (db *DB) ReadAllStrategyAnalytics(ctx context.Context, from int, to *int) ([]*model.StrategyAnalytics, error) {
+	reports, err := db.readTradeOutcomesBetween(ctx, bson.M{}, &from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	perBot := map[string][]*model.TradeOutcomeReport{}
+	for _, report := range reports {
+		perBot[report.BotName] = append(perBot[report.BotName], report)
+	}
+
+	all := []*model.StrategyAnalytics{}
+	for botName, group := range perBot {
+		all = append(all, &model.StrategyAnalytics{
+			BotName: botName,
+			From:    &from,
+			To:      to,
+			Overall: OutcomeStats(group),
+			Buckets: []*model.AnalyticsBucket{},
+		})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Overall.Expectancy == all[j].Overall.Expectancy {
+			return all[i].BotName < all[j].BotName
+		}
+		return all[i].Overall.Expectancy > all[j].Overall.Expectancy
+	})
+
+	return all, nil
+}
+
+// readTradeOutcomesBetween returns the matching trade outcomes between the
+// given UNIX times, oldest first. Both second and millisecond timestamps match.
+func (db *DB) readTradeOutcomesBetween(ctx context.Context, filter bson.M, from, to *int) ([]*model.TradeOutcomeReport, error) {
+	collection := db.client.Database("go_trading_db").Collection("TradeOutcomeReports")
+
+	for key, value := range TradeOutcomeTimeFilter(from, to) {
+		filter[key] = value
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying trade outcomes for analytics:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	reports := []*model.TradeOutcomeReport{}
+	if err := cursor.All(ctx, &reports); err != nil {
+		log.Error().Err(err).Msg("Error decoding trade outcomes for analytics:")
+		return nil, err
+	}
+
+	// Outcomes in seconds and in milliseconds are mixed, so order by the
+	// normalised time.
+	sort.SliceStable(reports, func(i, j int) bool {
+		return outcomeTime(reports[i]).Before(outcomeTime(reports[j]))
+	})
+
+	return reports, nil
+}
+
+// TradeOutcomeTimeFilter matches trade outcomes between the given UNIX times,
+// either of which may be nil for no bound. Each unit's range is bounded by
+// millisecondTimestamps, so that a bound in one unit cannot let in every
+// outcome stamped in the other.
+func TradeOutcomeTimeFilter(from, to *int) bson.M {
+	if from == nil && to == nil {
+		return bson.M{}
+	}
+
+	seconds := bson.M{"$lt": millisecondTimestamps}
+	milliseconds := bson.M{"$gte": millisecondTimestamps}
+	if from != nil {
+		seconds["$gte"] = *from
+		milliseconds["$gte"] = max(*from*1000, millisecondTimestamps)
+	}
+	if to != nil {
+		seconds["$lte"] = *to
+		milliseconds["$lte"] = *to*1000 + 999
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"timestamp": seconds},
+		bson.M{"timestamp": milliseconds},
+	}}
+}
+
+// OutcomeStats summarises trade outcomes given oldest first.
+func OutcomeStats(reports []*model.TradeOutcomeReport) *model.OutcomeStats {
+	stats := &model.OutcomeStats{Trades: len(reports)}
+	if len(reports) == 0 {
+		return stats
+	}
+
+	var gains, losses, total float64
+	equity, peak := 1.0, 1.0
+	streak := 0
+	for _, report := range reports {
+		change := report.PercentageChange
+		total += change
+
+		if change > 0 {
+			stats.Wins++
+			gains += change
+			if streak < 0 {
+				streak = 0
+			}
+			streak++
+			stats.LongestWinStreak = max(stats.LongestWinStreak, streak)
+		} else {
+			stats.Losses++
+			losses += change
+			if streak > 0 {
+				streak = 0
+			}
+			streak--
+			stats.LongestLossStreak = max(stats.LongestLossStreak, -streak)
+		}
+
+		equity *= 1 + change/100
+		if equity > peak {
+			peak = equity
+		}
+		stats.MaxDrawdown = max(stats.MaxDrawdown, (peak-equity)/peak*100)
+	}
+
+	stats.CurrentStreak = streak
+	stats.WinRate = float64(stats.Wins) / float64(stats.Trades) * 100
+	stats.Expectancy = total / float64(stats.Trades)
+	if stats.Wins > 0 {
+		stats.AvgGain = gains / float64(stats.Wins)
+	}
+	if stats.Losses > 0 {
+		stats.AvgLoss = losses / float64(stats.Losses)
+	}
+	if losses < 0 {
+		stats.ProfitFactor = gains / -losses
+	}
+
+	return stats
+}
+
+// BucketKey names the bucket a trade outcome falls in for the breakdown.
+func BucketKey(report *model.TradeOutcomeReport, breakdownBy model.AnalyticsBreakdown) string {
+	switch breakdownBy {
+	case model.AnalyticsBreakdownSymbol:
+		return report.Symbol
+	case model.AnalyticsBreakdownHour:
+		return fmt.Sprintf("%02d", outcomeTime(report).Hour())
+	case model.AnalyticsBreakdownWeekday:
+		return outcomeTime(report).Weekday().String()
+	case model.AnalyticsBreakdownMarketStatus:
+		return report.MarketStatus
+	case model.AnalyticsBreakdownFearGreed:
+		return FearGreedBucket(report.FearGreedIndex)
+	default:
+		return ""
+	}
+}
+
+// outcomeTime returns when the trade outcome was recorded, in UTC.
+func outcomeTime(report *model.TradeOutcomeReport) time.Time {
+	if report.Timestamp >= millisecondTimestamps {
+		return time.UnixMilli(int64(report.Timestamp)).UTC()
+	}
+	return time.Unix(int64(report.Timestamp), 0).UTC()
+}
+
+// FearGreedBucket maps an index onto the classifications used by alternative.me.
+func FearGreedBucket(index int) string {
+	switch {
+	case index < 0:
+		return "Unknown"
+	case index < 25:
+		return "Extreme Fear"
+	case index < 45:
+		return "Fear"
+	case index <= 55:
+		return "Neutral"
+	case index <= 75:
+		return "Greed"
+	default:
+		return "Extreme Greed"
+	}
+}
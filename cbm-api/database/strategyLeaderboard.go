@@ -0,0 +1,237 @@
+package database
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const defaultLeaderboardWindow = 7 // days
+
+// ReadStrategyLeaderboard ranks the strategies matching the owner and lifecycle
+// filters. Returns are measured from each strategy's starting balance so bots
+// of different sizes compare fairly; strategies saved before the starting
+// balance was recorded fall back to compounding their trade outcomes.
+func (db *DB) ReadStrategyLeaderboard(ctx context.Context, sortBy *model.LeaderboardSort, window *int, owner *string, lifecycle *model.StrategyLifecycle, limit *int) ([]*model.LeaderboardEntry, error) {
+	strategies, err := db.ReadAllStrategies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranked []*model.Strategy
+	var botNames []string
+	for _, strategy := range strategies {
+		if owner != nil && (strategy.Owner == nil || *strategy.Owner != *owner) {
+			continue
+		}
+		if lifecycle != nil && strategy.Lifecycle != *lifecycle {
+			continue
+		}
+		ranked = append(ranked, strategy)
+		botNames = append(botNames, strategy.BotInstanceName)
+	}
+	if len(ranked) == 0 {
+		return []*model.LeaderboardEntry{}, nil
+	}
+
+	now := time.Now()
+	windowStart, windowEnd := LeaderboardWindow(now, window)
+
+	totals, err := db.readTradeTotals(ctx, botNames)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := db.readTradeOutcomesBetween(ctx, bson.M{"botname": bson.M{"$in": botNames}}, &windowStart, &windowEnd)
+	if err != nil {
+		return nil, err
+	}
+	recent := map[string][]*model.TradeOutcomeReport{}
+	for _, report := range reports {
+		recent[report.BotName] = append(recent[report.BotName], report)
+	}
+
+	entries := []*model.LeaderboardEntry{}
+	for _, strategy := range ranked {
+		name := strategy.BotInstanceName
+		entries = append(entries, leaderboardEntry(strategy, totals[name], recent[name], now))
+	}
+
+	sortLeaderboard(entries, sortBy)
+	if limit != nil && *limit > 0 && *limit < len(entries) {
+		entries = entries[:*limit]
+	}
+
+	return entries, nil
+}
+
+// LeaderboardWindow returns the UNIX times the recent figures are measured
+// between: the last window days to now, 7 when window is nil or not positive.
+func LeaderboardWindow(now time.Time, window *int) (from, to int) {
+	days := defaultLeaderboardWindow
+	if window != nil && *window > 0 {
+		days = *window
+	}
+	return int(now.AddDate(0, 0, -days).Unix()), int(now.Unix())
+}
+
+// readTradeTotals sums each bot's trade outcomes in the database, so that the
+// lifetime figures do not need every outcome loaded.
+func (db *DB) readTradeTotals(ctx context.Context, botNames []string) (map[string]TradeTotals, error) {
+	collection := db.client.Database("go_trading_db").Collection("TradeOutcomeReports")
+
+	growth := bson.M{"$add": bson.A{1, bson.M{"$divide": bson.A{"$percentagechange", 100}}}}
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"botname": bson.M{"$in": botNames}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$botname",
+			"trades":     bson.M{"$sum": 1},
+			"wins":       bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$percentagechange", 0}}, 1, 0}}},
+			"sum":        bson.M{"$sum": "$percentagechange"},
+			"sumsquares": bson.M{"$sum": bson.M{"$multiply": bson.A{"$percentagechange", "$percentagechange"}}},
+			"loggrowth":  bson.M{"$sum": bson.M{"$ln": bson.M{"$max": bson.A{growth, minGrowth}}}},
+		}}},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error summing trade outcomes for the leaderboard:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		BotName     string `bson:"_id"`
+		TradeTotals `bson:",inline"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		log.Error().Err(err).Msg("Error decoding trade outcome sums for the leaderboard:")
+		return nil, err
+	}
+
+	totals := make(map[string]TradeTotals, len(groups))
+	for _, group := range groups {
+		totals[group.BotName] = group.TradeTotals
+	}
+	return totals, nil
+}
+
+// leaderboardEntry scores a strategy from its lifetime trade totals and the
+// trade outcomes in the window, given oldest first.
+func leaderboardEntry(strategy *model.Strategy, totals TradeTotals, recent []*model.TradeOutcomeReport, now time.Time) *model.LeaderboardEntry {
+	entry := &model.LeaderboardEntry{
+		BotInstanceName:     strategy.BotInstanceName,
+		Owner:               strategy.Owner,
+		Lifecycle:           strategy.Lifecycle,
+		AccountBalance:      strategy.AccountBalance,
+		StartingBalance:     strategy.AccountBalance,
+		Trades:              totals.Trades,
+		WinRate:             totals.WinRate(),
+		MaxDrawdown:         OutcomeStats(recent).MaxDrawdown,
+		AgeDays:             now.Sub(time.Unix(int64(strategy.CreatedOn), 0)).Hours() / 24,
+		RiskAdjustedReturn:  totals.RiskAdjustedReturn(),
+		RecentTrades:        len(recent),
+		RecentReturnPercent: TotalTrades(recent).CompoundedReturn(),
+	}
+
+	if strategy.StartingBalance != nil && *strategy.StartingBalance > 0 {
+		entry.StartingBalance = *strategy.StartingBalance
+		entry.ReturnPercent = (strategy.AccountBalance - entry.StartingBalance) / entry.StartingBalance * 100
+	} else {
+		entry.ReturnPercent = totals.CompoundedReturn()
+		entry.StartingBalance = strategy.AccountBalance / (1 + entry.ReturnPercent/100)
+	}
+
+	return entry
+}
+
+// sortLeaderboard orders the entries best first and numbers them, breaking
+// ties on bot name so the order is stable between calls.
+func sortLeaderboard(entries []*model.LeaderboardEntry, sortBy *model.LeaderboardSort) {
+	score := func(e *model.LeaderboardEntry) float64 { return e.ReturnPercent }
+	if sortBy != nil {
+		switch *sortBy {
+		case model.LeaderboardSortRiskAdjusted:
+			score = func(e *model.LeaderboardEntry) float64 { return e.RiskAdjustedReturn }
+		case model.LeaderboardSortTrades:
+			score = func(e *model.LeaderboardEntry) float64 { return float64(e.Trades) }
+		case model.LeaderboardSortRecent:
+			score = func(e *model.LeaderboardEntry) float64 { return e.RecentReturnPercent }
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := score(entries[i]), score(entries[j])
+		if a == b {
+			return entries[i].BotInstanceName < entries[j].BotInstanceName
+		}
+		return a > b
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+}
+
+// minGrowth stands in for the growth factor of a trade that lost everything,
+// as its log is undefined.
+const minGrowth = 1e-12
+
+// TradeTotals are sums over a bot's trade outcomes, enough for the figures
+// that do not depend on the order of the trades.
+type TradeTotals struct {
+	Trades     int     `bson:"trades"`
+	Wins       int     `bson:"wins"`
+	Sum        float64 `bson:"sum"`        // of the % changes
+	SumSquares float64 `bson:"sumsquares"` // of the % changes
+	LogGrowth  float64 `bson:"loggrowth"`  // of ln(1 + % change/100)
+}
+
+// TotalTrades sums trade outcomes as the leaderboard's aggregation does.
+func TotalTrades(reports []*model.TradeOutcomeReport) TradeTotals {
+	var totals TradeTotals
+	for _, report := range reports {
+		change := report.PercentageChange
+		totals.Trades++
+		if change > 0 {
+			totals.Wins++
+		}
+		totals.Sum += change
+		totals.SumSquares += change * change
+		totals.LogGrowth += math.Log(max(1+change/100, minGrowth))
+	}
+	return totals
+}
+
+// WinRate is the % of trades that gained.
+func (t TradeTotals) WinRate() float64 {
+	if t.Trades == 0 {
+		return 0
+	}
+	return float64(t.Wins) / float64(t.Trades) * 100
+}
+
+// CompoundedReturn is the % change from applying each trade's change in turn.
+func (t TradeTotals) CompoundedReturn() float64 {
+	return (math.Exp(t.LogGrowth) - 1) * 100
+}
+
+// RiskAdjustedReturn is the mean per-trade % change divided by its standard
+// deviation. Fewer than two trades, or trades with no spread, give zero.
+func (t TradeTotals) RiskAdjustedReturn() float64 {
+	if t.Trades < 2 {
+		return 0
+	}
+
+	n := float64(t.Trades)
+	mean := t.Sum / n
+	variance := (t.SumSquares - n*mean*mean) / (n - 1)
+	// Summing squares loses precision that a spread of zero shows up as.
+	if variance <= 1e-12*max(1, t.SumSquares/n) {
+		return 0
+	}
+
+	return mean / math.Sqrt(variance)
+}
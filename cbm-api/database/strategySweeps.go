@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"sort"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultSweepResultLimit caps how many ranked results are returned per sweep when no limit is given.
+const defaultSweepResultLimit = 10
+
+// CreateStrategySweep stores the results of a parameter sweep, ordered by rank.
+func (db *DB) CreateStrategySweep(ctx context.Context, input model.StrategySweepInput) (*model.StrategySweep, error) {
+	collection := db.client.Database("go_trading_db").Collection("StrategySweeps")
+
+	results := make([]*model.SweepResult, 0, len(input.Results))
+	for _, r := range input.Results {
+		results = append(results, &model.SweepResult{
+			Rank:                 r.Rank,
+			BotInstanceName:      r.BotInstanceName,
+			TradeDuration:        r.TradeDuration,
+			IncrementsAtr:        r.IncrementsAtr,
+			LongSMADuration:      r.LongSMADuration,
+			ShortSMADuration:     r.ShortSMADuration,
+			MovingAveMomentum:    r.MovingAveMomentum,
+			TakeProfitPercentage: r.TakeProfitPercentage,
+			StopLossPercentage:   r.StopLossPercentage,
+			ATRtollerance:        r.ATRtollerance,
+			Trades:               r.Trades,
+			NetPnL:               r.NetPnL,
+			WinRate:              r.WinRate,
+			MaxDrawdown:          r.MaxDrawdown,
+			Sharpe:               r.Sharpe,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Rank < results[j].Rank })
+
+	sweep := &model.StrategySweep{
+		SweepID:         primitive.NewObjectID().Hex(),
+		Sampling:        input.Sampling,
+		RankedBy:        input.RankedBy,
+		From:            input.From,
+		To:              input.To,
+		Permutations:    input.Permutations,
+		StartingBalance: input.StartingBalance,
+		CreatedOn:       input.CreatedOn,
+		Results:         results,
+	}
+
+	_, err := collection.InsertOne(ctx, sweep)
+	if err != nil {
+		log.Error().Err(err).Msg("Error inserting strategy sweep into the database:")
+		return nil, err
+	}
+
+	return sweep, nil
+}
+
+// ReadStrategySweep retrieves a sweep by ID with its top ranked results.
+func (db *DB) ReadStrategySweep(ctx context.Context, sweepID string, limit *int) (*model.StrategySweep, error) {
+	collection := db.client.Database("go_trading_db").Collection("StrategySweeps")
+
+	opts := options.FindOne().SetProjection(sweepResultsProjection(limit))
+
+	var sweep model.StrategySweep
+	err := collection.FindOne(ctx, bson.M{"sweepid": sweepID}, opts).Decode(&sweep)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Warn().Str("sweepID", sweepID).Msg("No strategy sweep found")
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error getting strategy sweep from the database:")
+		return nil, err
+	}
+
+	return &sweep, nil
+}
+
+// ReadAllStrategySweeps retrieves all sweeps, most recent first, each with its top ranked results.
+func (db *DB) ReadAllStrategySweeps(ctx context.Context, limit *int) ([]*model.StrategySweep, error) {
+	collection := db.client.Database("go_trading_db").Collection("StrategySweeps")
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdon", Value: -1}}).
+		SetProjection(sweepResultsProjection(limit))
+
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying all strategy sweeps:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sweeps := []*model.StrategySweep{}
+	if err := cursor.All(ctx, &sweeps); err != nil {
+		log.Error().Err(err).Msg("Error decoding strategy sweeps:")
+		return nil, err
+	}
+
+	return sweeps, nil
+}
+
+// DeleteStrategySweep removes a sweep by ID.
+func (db *DB) DeleteStrategySweep(ctx context.Context, sweepID string) (bool, error) {
+	collection := db.client.Database("go_trading_db").Collection("StrategySweeps")
+
+	result, err := collection.DeleteOne(ctx, bson.M{"sweepid": sweepID})
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting strategy sweep:")
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// sweepResultsProjection trims the stored results (already sorted by rank) to the requested limit.
+func sweepResultsProjection(limit *int) bson.M {
+	n := defaultSweepResultLimit
+	if limit != nil && *limit > 0 {
+		n = *limit
+	}
+	return bson.M{"results": bson.M{"$slice": n}}
+}
@@ -0,0 +1,268 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// allowedTransitions lists the lifecycle states each state may move to.
+var allowedTransitions = map[model.StrategyLifecycle][]model.StrategyLifecycle{
+	model.StrategyLifecycleDraft:       {model.StrategyLifecycleBacktesting, model.StrategyLifecycleRetired},
+	model.StrategyLifecycleBacktesting: {model.StrategyLifecycleValidated, model.StrategyLifecycleDraft, model.StrategyLifecycleRetired},
+	model.StrategyLifecycleValidated:   {model.StrategyLifecyclePaper, model.StrategyLifecycleBacktesting, model.StrategyLifecycleRetired},
+	model.StrategyLifecyclePaper:       {model.StrategyLifecycleLive, model.StrategyLifecyclePaused, model.StrategyLifecycleBacktesting, model.StrategyLifecycleRetired},
+	model.StrategyLifecycleLive:        {model.StrategyLifecyclePaused, model.StrategyLifecyclePaper, model.StrategyLifecycleRetired},
+	model.StrategyLifecyclePaused:      {model.StrategyLifecyclePaper, model.StrategyLifecycleLive, model.StrategyLifecycleRetired},
+	model.StrategyLifecycleRetired:     {},
+}
+
+// CanTransition reports whether a strategy may move between the two lifecycle states.
+func CanTransition(from, to model.StrategyLifecycle) bool {
+	for _, allowed := range allowedTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// versionAttempts is how many times a version is numbered before giving up on
+// updates racing it for the same number.
+const versionAttempts = 3
+
+// createStrategyVersion stores the strategy's current parameters as the next
+// immutable version and returns it.
+func (db *DB) createStrategyVersion(ctx context.Context, strategy *model.Strategy) (*model.StrategyVersion, error) {
+	collection := db.client.Database("go_trading_db").Collection("StrategyVersions")
+
+	for attempt := 1; ; attempt++ {
+		number := 1
+		latest, err := db.readLatestStrategyVersion(ctx, strategy.BotInstanceName)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil {
+			number = latest.Version + 1
+		}
+
+		version := &model.StrategyVersion{
+			VersionID:            primitive.NewObjectID().Hex(),
+			BotInstanceName:      strategy.BotInstanceName,
+			Version:              number,
+			TradeDuration:        strategy.TradeDuration,
+			IncrementsAtr:        strategy.IncrementsAtr,
+			LongSMADuration:      strategy.LongSMADuration,
+			ShortSMADuration:     strategy.ShortSMADuration,
+			MovingAveMomentum:    strategy.MovingAveMomentum,
+			TakeProfitPercentage: strategy.TakeProfitPercentage,
+			StopLossPercentage:   strategy.StopLossPercentage,
+			ATRtollerance:        strategy.ATRtollerance,
+			MinFearGreed:         strategy.MinFearGreed,
+			MaxFearGreed:         strategy.MaxFearGreed,
+			AllowedSentiments:    strategy.AllowedSentiments,
+			CreatedOn:            int(time.Now().Unix()),
+			Changes:              []*model.FieldChange{},
+		}
+
+		_, err = collection.InsertOne(ctx, version)
+		// Another update took the number, so number it after that one's
+		if mongo.IsDuplicateKeyError(err) && attempt < versionAttempts {
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Error inserting strategy version into the database:")
+			return nil, err
+		}
+
+		return version, nil
+	}
+}
+
+// deleteStrategyVersion removes a version the strategy was never saved with.
+func (db *DB) deleteStrategyVersion(ctx context.Context, versionID string) {
+	collection := db.client.Database("go_trading_db").Collection("StrategyVersions")
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"versionid": versionID}); err != nil {
+		log.Error().Err(err).Str("versionID", versionID).Msg("Error deleting unused strategy version:")
+	}
+}
+
+// readLatestStrategyVersion returns the highest numbered version of the strategy, or nil if it has none.
+func (db *DB) readLatestStrategyVersion(ctx context.Context, botInstanceName string) (*model.StrategyVersion, error) {
+	collection := db.client.Database("go_trading_db").Collection("StrategyVersions")
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var version model.StrategyVersion
+	err := collection.FindOne(ctx, bson.M{"botinstancename": botInstanceName}, opts).Decode(&version)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error getting latest strategy version:")
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// ReadStrategyVersion retrieves a single strategy version by ID.
+func (db *DB) ReadStrategyVersion(ctx context.Context, versionID string) (*model.StrategyVersion, error) {
+	collection := db.client.Database("go_trading_db").Collection("StrategyVersions")
+
+	var version model.StrategyVersion
+	err := collection.FindOne(ctx, bson.M{"versionid": versionID}).Decode(&version)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Warn().Str("versionID", versionID).Msg("No strategy version found")
+			return nil, nil
+		}
+		log.Error().Err(err).Msg("Error getting strategy version:")
+		return nil, err
+	}
+	version.Changes = []*model.FieldChange{}
+
+	return &version, nil
+}
+
+// recordLifecycleTransition appends a lifecycle change to the strategy's audit trail.
+func (db *DB) recordLifecycleTransition(ctx context.Context, botInstanceName string, from *model.StrategyLifecycle, to model.StrategyLifecycle) error {
+	collection := db.client.Database("go_trading_db").Collection("StrategyLifecycleTransitions")
+
+	_, err := collection.InsertOne(ctx, &model.LifecycleTransition{
+		BotInstanceName: botInstanceName,
+		From:            from,
+		To:              to,
+		Timestamp:       int(time.Now().Unix()),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error recording lifecycle transition:")
+		return err
+	}
+
+	return nil
+}
+
+// ReadStrategyHistory returns every version of the strategy, oldest first with
+// the changes from the version before, and its lifecycle transitions.
+func (db *DB) ReadStrategyHistory(ctx context.Context, botInstanceName string) (*model.StrategyHistory, error) {
+	strategy, err := db.ReadStrategyByName(ctx, botInstanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	versionsCollection := db.client.Database("go_trading_db").Collection("StrategyVersions")
+	cursor, err := versionsCollection.Find(ctx, bson.M{"botinstancename": botInstanceName}, options.Find().SetSort(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying strategy versions:")
+		return nil, err
+	}
+	versions := []*model.StrategyVersion{}
+	if err := cursor.All(ctx, &versions); err != nil {
+		log.Error().Err(err).Msg("Error decoding strategy versions:")
+		return nil, err
+	}
+	for i, version := range versions {
+		version.Changes = []*model.FieldChange{}
+		if i > 0 {
+			version.Changes = DiffVersions(versions[i-1], version)
+		}
+	}
+
+	transitionsCollection := db.client.Database("go_trading_db").Collection("StrategyLifecycleTransitions")
+	cursor, err = transitionsCollection.Find(ctx, bson.M{"botinstancename": botInstanceName}, options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying lifecycle transitions:")
+		return nil, err
+	}
+	transitions := []*model.LifecycleTransition{}
+	if err := cursor.All(ctx, &transitions); err != nil {
+		log.Error().Err(err).Msg("Error decoding lifecycle transitions:")
+		return nil, err
+	}
+
+	return &model.StrategyHistory{
+		BotInstanceName: botInstanceName,
+		Lifecycle:       strategy.Lifecycle,
+		Versions:        versions,
+		Transitions:     transitions,
+	}, nil
+}
+
+// sameParameters reports whether the strategy's parameters match the version.
+func sameParameters(version *model.StrategyVersion, strategy *model.Strategy) bool {
+	return len(DiffVersions(version, &model.StrategyVersion{
+		TradeDuration:        strategy.TradeDuration,
+		IncrementsAtr:        strategy.IncrementsAtr,
+		LongSMADuration:      strategy.LongSMADuration,
+		ShortSMADuration:     strategy.ShortSMADuration,
+		MovingAveMomentum:    strategy.MovingAveMomentum,
+		TakeProfitPercentage: strategy.TakeProfitPercentage,
+		StopLossPercentage:   strategy.StopLossPercentage,
+		ATRtollerance:        strategy.ATRtollerance,
+		MinFearGreed:         strategy.MinFearGreed,
+		MaxFearGreed:         strategy.MaxFearGreed,
+		AllowedSentiments:    strategy.AllowedSentiments,
+	})) == 0
+}
+
+// diffVersions lists the parameters that differ between two versions.
+func DiffVersions(previous, next *model.StrategyVersion) []*model.FieldChange {
+	return changedFields([]fieldDiff{
+		{"TradeDuration", intString(previous.TradeDuration), intString(next.TradeDuration)},
+		{"IncrementsATR", intString(previous.IncrementsAtr), intString(next.IncrementsAtr)},
+		{"LongSMADuration", intString(previous.LongSMADuration), intString(next.LongSMADuration)},
+		{"ShortSMADuration", intString(previous.ShortSMADuration), intString(next.ShortSMADuration)},
+		{"MovingAveMomentum", floatString(&previous.MovingAveMomentum), floatString(&next.MovingAveMomentum)},
+		{"TakeProfitPercentage", floatString(previous.TakeProfitPercentage), floatString(next.TakeProfitPercentage)},
+		{"StopLossPercentage", floatString(previous.StopLossPercentage), floatString(next.StopLossPercentage)},
+		{"ATRtollerance", floatString(previous.ATRtollerance), floatString(next.ATRtollerance)},
+		{"MinFearGreed", intPointerString(previous.MinFearGreed), intPointerString(next.MinFearGreed)},
+		{"MaxFearGreed", intPointerString(previous.MaxFearGreed), intPointerString(next.MaxFearGreed)},
+		{"AllowedSentiments", listString(previous.AllowedSentiments), listString(next.AllowedSentiments)},
+	})
+}
+
+func intString(v int) *string {
+	s := strconv.Itoa(v)
+	return &s
+}
+
+func intPointerString(v *int) *string {
+	if v == nil {
+		return nil
+	}
+	return intString(*v)
+}
+
+func listString(v []string) *string {
+	if len(v) == 0 {
+		return nil
+	}
+	s := strings.Join(v, ", ")
+	return &s
+}
+
+func floatString(v *float64) *string {
+	if v == nil {
+		return nil
+	}
+	s := strconv.FormatFloat(*v, 'f', -1, 64)
+	return &s
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
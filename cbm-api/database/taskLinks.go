@@ -0,0 +1,346 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxTaskGraphDepth caps how many links away TaskGraph follows.
+const maxTaskGraphDepth = 20
+
+// findTasks reads the tasks matching the filter.
+func findTasks(ctx context.Context, collection *mongo.Collection, filter bson.M) ([]storedTask, error) {
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []storedTask
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// validateTaskLinks checks that the parent and blockers of the task with the
+// ID exist, and that neither chain leads back to the task. The ID is empty for
+// a task not yet created.
+func validateTaskLinks(ctx context.Context, collection *mongo.Collection, id string, parentID *string, blockedBy []string) error {
+	if parentID != nil && *parentID != "" {
+		if *parentID == id {
+			return fmt.Errorf("task %s cannot be its own subtask", id)
+		}
+		// Walk up the parents, so the task does not become its own ancestor.
+		seen := map[string]bool{}
+		for next := *parentID; next != "" && !seen[next]; {
+			if next == id {
+				return fmt.Errorf("task %s cannot be a subtask of its own subtask %s", id, *parentID)
+			}
+			seen[next] = true
+
+			var parent storedTask
+			err := collection.FindOne(ctx, bson.M{"id": next}).Decode(&parent)
+			if errors.Is(err, mongo.ErrNoDocuments) && next == *parentID {
+				return fmt.Errorf("parent task %s not found", next)
+			}
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				break // a missing ancestor ends the chain
+			}
+			if err != nil {
+				return err
+			}
+
+			next = ""
+			if parent.ParentID != nil {
+				next = *parent.ParentID
+			}
+		}
+	}
+
+	if len(blockedBy) == 0 {
+		return nil
+	}
+	if slices.Contains(blockedBy, id) {
+		return fmt.Errorf("task %s cannot block itself", id)
+	}
+
+	blockers, err := findTasks(ctx, collection, bson.M{"id": bson.M{"$in": blockedBy}})
+	if err != nil {
+		return err
+	}
+	found := map[string]bool{}
+	for _, blocker := range blockers {
+		found[blocker.ID] = true
+	}
+	for _, blockerID := range blockedBy {
+		if !found[blockerID] {
+			return fmt.Errorf("blocking task %s not found", blockerID)
+		}
+	}
+	if id == "" {
+		return nil
+	}
+
+	// Walk the blockers' own blockers, so the task does not end up waiting on
+	// itself.
+	seen := map[string]bool{}
+	for len(blockers) > 0 {
+		var next []string
+		for _, blocker := range blockers {
+			seen[blocker.ID] = true
+			for _, upstream := range blocker.BlockedBy {
+				if upstream == id {
+					return fmt.Errorf("task %s cannot be blocked by %s, which already waits on it", id, blocker.ID)
+				}
+				if !seen[upstream] {
+					next = append(next, upstream)
+				}
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		if blockers, err = findTasks(ctx, collection, bson.M{"id": bson.M{"$in": next}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openBlockers returns the IDs of the blocking tasks not yet complete. Blockers
+// since deleted no longer block.
+func openBlockers(ctx context.Context, collection *mongo.Collection, blockedBy []string) ([]string, error) {
+	if len(blockedBy) == 0 {
+		return nil, nil
+	}
+
+	blockers, err := findTasks(ctx, collection, bson.M{"id": bson.M{"$in": blockedBy}, "status": bson.M{"$ne": gtd.Complete}})
+	if err != nil {
+		return nil, err
+	}
+
+	open := make([]string, 0, len(blockers))
+	for _, blocker := range blockers {
+		open = append(open, blocker.ID)
+	}
+	return open, nil
+}
+
+// spawnNextOccurrence creates the occurrence after a completed recurring task
+// and records it on the task, unless one was already spawned.
+func spawnNextOccurrence(ctx context.Context, collection *mongo.Collection, task storedTask, now time.Time) (*storedTask, error) {
+	if task.Recurrence == nil || task.NextOccurrenceID != nil {
+		return nil, nil
+	}
+
+	dueDate, deferDate, err := gtd.NextDates(*task.Recurrence, task.DueDate, task.DeferDate, now)
+	if err != nil {
+		return nil, err
+	}
+
+	status := gtd.NextAction
+	if deferDate != nil && !gtd.Due(deferDate, now) {
+		status = gtd.Scheduled
+	}
+
+	stamp := now.Format(time.RFC3339)
+	next := storedTask{
+		ID:          primitive.NewObjectID().Hex(),
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      status,
+		Labels:      task.Labels,
+		AssignedTo:  task.AssignedTo,
+		DueDate:     dueDate,
+		DeferDate:   deferDate,
+		Department:  task.Department,
+		ProjectID:   task.ProjectID,
+		Estimate:    task.Estimate,
+		ParentID:    task.ParentID,
+		Recurrence:  task.Recurrence,
+		CreatedAt:   stamp,
+		UpdatedAt:   stamp,
+	}
+
+	// Claim the task first, so completing it twice at once spawns one occurrence.
+	filter := bson.M{"id": task.ID, "nextOccurrenceId": nil}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"nextOccurrenceId": next.ID}})
+	if err != nil {
+		return nil, err
+	}
+	if result.ModifiedCount == 0 {
+		return nil, nil
+	}
+
+	if _, err := collection.InsertOne(ctx, next); err != nil {
+		if _, unsetErr := collection.UpdateOne(ctx, bson.M{"id": task.ID}, bson.M{"$set": bson.M{"nextOccurrenceId": nil}}); unsetErr != nil {
+			log.Error().Err(unsetErr).Str("taskID", task.ID).Msg("Error releasing recurring task after a failed spawn:")
+		}
+		return nil, err
+	}
+
+	log.Info().Str("taskID", task.ID).Str("nextTaskID", next.ID).Msg("Spawned next occurrence of recurring task")
+	return &next, nil
+}
+
+// unlinkTask removes a deleted task from the blockers of other tasks, and makes
+// its subtasks top-level tasks.
+func unlinkTask(ctx context.Context, collection *mongo.Collection, id string) error {
+	if _, err := collection.UpdateMany(ctx, bson.M{"blockedBy": id}, bson.M{"$pull": bson.M{"blockedBy": id}}); err != nil {
+		return err
+	}
+	_, err := collection.UpdateMany(ctx, bson.M{"parentId": id}, bson.M{"$set": bson.M{"parentId": nil}})
+	return err
+}
+
+// GetSubtasksByParentIDs retrieves the subtasks of each task in one query, in
+// the order of the IDs given.
+func (db *DB) GetSubtasksByParentIDs(ctx context.Context, parentIDs []string) ([][]*model.Task, error) {
+	collection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	stored, err := findTasks(ctx, collection, bson.M{"parentId": bson.M{"$in": parentIDs}})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving subtasks:")
+		return nil, err
+	}
+
+	byParent := make(map[string][]*model.Task, len(parentIDs))
+	for _, task := range stored {
+		byParent[*task.ParentID] = append(byParent[*task.ParentID], task.toModel())
+	}
+
+	subtasks := make([][]*model.Task, len(parentIDs))
+	for i, id := range parentIDs {
+		subtasks[i] = byParent[id]
+		if subtasks[i] == nil {
+			subtasks[i] = []*model.Task{}
+		}
+	}
+	return subtasks, nil
+}
+
+// GetTasksByIDs retrieves tasks in one query, in the order of the IDs given,
+// with nil for those not found.
+func (db *DB) GetTasksByIDs(ctx context.Context, ids []string) ([]*model.Task, error) {
+	collection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	stored, err := findTasks(ctx, collection, bson.M{"id": bson.M{"$in": ids}})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving tasks by IDs:")
+		return nil, err
+	}
+
+	byID := make(map[string]*model.Task, len(stored))
+	for _, task := range stored {
+		byID[task.ID] = task.toModel()
+	}
+
+	tasks := make([]*model.Task, len(ids))
+	for i, id := range ids {
+		tasks[i] = byID[id]
+	}
+	return tasks, nil
+}
+
+// TaskGraph retrieves the tasks linked to a task through parents, subtasks,
+// blockers and the tasks it blocks, up to depth links away.
+func (db *DB) TaskGraph(ctx context.Context, id string, depth int) (*model.TaskGraph, error) {
+	collection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	if depth < 0 {
+		return nil, fmt.Errorf("depth must not be negative, got %d", depth)
+	}
+	depth = min(depth, maxTaskGraphDepth)
+
+	var root storedTask
+	if err := collection.FindOne(ctx, bson.M{"id": id}).Decode(&root); err != nil {
+		log.Error().Err(err).Msg("Error retrieving task for its graph:")
+		return nil, err
+	}
+
+	graph := &model.TaskGraph{
+		Root:  root.toModel(),
+		Tasks: []*model.Task{root.toModel()},
+		Links: []*model.TaskLink{},
+	}
+
+	reached := map[string]bool{root.ID: true}
+	linked := map[model.TaskLink]bool{}
+	addLink := func(from, to string, kind model.TaskLinkKind) {
+		link := model.TaskLink{From: from, To: to, Kind: kind}
+		if !linked[link] {
+			linked[link] = true
+			graph.Links = append(graph.Links, &link)
+		}
+	}
+
+	frontier := []storedTask{root}
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		var ids, upstream []string
+		for _, task := range frontier {
+			ids = append(ids, task.ID)
+			upstream = append(upstream, task.BlockedBy...)
+			if task.ParentID != nil {
+				upstream = append(upstream, *task.ParentID)
+			}
+		}
+
+		neighbours, err := findTasks(ctx, collection, bson.M{"$or": bson.A{
+			bson.M{"id": bson.M{"$in": upstream}},
+			bson.M{"parentId": bson.M{"$in": ids}},
+			bson.M{"blockedBy": bson.M{"$in": ids}},
+		}})
+		if err != nil {
+			log.Error().Err(err).Msg("Error retrieving linked tasks:")
+			return nil, err
+		}
+
+		inFrontier := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			inFrontier[id] = true
+		}
+
+		var next []storedTask
+		for _, task := range neighbours {
+			if task.ParentID != nil && inFrontier[*task.ParentID] {
+				addLink(*task.ParentID, task.ID, model.TaskLinkKindSubtask)
+			}
+			for _, blocker := range task.BlockedBy {
+				if inFrontier[blocker] {
+					addLink(blocker, task.ID, model.TaskLinkKindBlocks)
+				}
+			}
+			if reached[task.ID] {
+				continue
+			}
+			reached[task.ID] = true
+			graph.Tasks = append(graph.Tasks, task.toModel())
+			next = append(next, task)
+		}
+		for _, task := range frontier {
+			if task.ParentID != nil && reached[*task.ParentID] {
+				addLink(*task.ParentID, task.ID, model.TaskLinkKindSubtask)
+			}
+			for _, blocker := range task.BlockedBy {
+				if reached[blocker] {
+					addLink(blocker, task.ID, model.TaskLinkKindBlocks)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// taskSortKeys maps task sorts to their document keys. Tasks are written with
+// camelCase keys.
+var taskSortKeys = map[model.TaskSort]string{
+	model.TaskSortCreatedAt: "createdAt",
+	model.TaskSortUpdatedAt: "updatedAt",
+	model.TaskSortDueDate:   "dueDate",
+	model.TaskSortTitle:     "title",
+}
+
+// projectSortKeys maps project sorts to their document keys. Projects are
+// written from model.Project, so with lowercase keys.
+var projectSortKeys = map[model.ProjectSort]string{
+	model.ProjectSortCreatedAt: "createdat",
+	model.ProjectSortUpdatedAt: "updatedat",
+	model.ProjectSortDueDate:   "duedate",
+	model.ProjectSortTitle:     "title",
+}
+
+// BuildTaskFilter returns the query matching tasks to the filter. Due dates
+// are compared as stored, so RFC 3339 and YYYY-MM-DD dates order together.
+func BuildTaskFilter(filter *model.TaskFilterInput) (bson.M, error) {
+	query := bson.M{}
+	if filter == nil {
+		return query, nil
+	}
+
+	if len(filter.Status) > 0 {
+		query["status"] = bson.M{"$in": filter.Status}
+	}
+	if len(filter.Labels) > 0 {
+		query["labels"] = bson.M{"$all": filter.Labels}
+	}
+	if filter.AssignedTo != nil {
+		query["assignedTo"] = *filter.AssignedTo
+	}
+	if filter.Department != nil {
+		query["department"] = *filter.Department
+	}
+	if filter.ProjectID != nil {
+		query["projectId"] = *filter.ProjectID
+	}
+
+	due := bson.M{}
+	if filter.DueFrom != nil {
+		if _, _, ok := gtd.ParseDate(*filter.DueFrom); !ok {
+			return nil, fmt.Errorf("invalid dueFrom %q, expected RFC 3339 or YYYY-MM-DD", *filter.DueFrom)
+		}
+		due["$gte"] = *filter.DueFrom
+	}
+	if filter.DueTo != nil {
+		to, dateOnly, ok := gtd.ParseDate(*filter.DueTo)
+		if !ok {
+			return nil, fmt.Errorf("invalid dueTo %q, expected RFC 3339 or YYYY-MM-DD", *filter.DueTo)
+		}
+		if dateOnly {
+			// Anything on the day sorts before the next day's date.
+			due["$lt"] = to.AddDate(0, 0, 1).Format("2006-01-02")
+		} else {
+			due["$lte"] = *filter.DueTo
+		}
+	}
+	if len(due) > 0 {
+		query["dueDate"] = due
+	}
+
+	if filter.Search != nil && *filter.Search != "" {
+		query["$or"] = searchFilter(*filter.Search, "title", "description")
+	}
+
+	return query, nil
+}
+
+// BuildProjectFilter returns the query matching projects to the filter.
+func BuildProjectFilter(filter *model.ProjectFilterInput) bson.M {
+	query := bson.M{}
+	if filter == nil {
+		return query
+	}
+
+	if filter.Sop != nil {
+		query["sop"] = *filter.Sop
+	}
+	if len(filter.Status) > 0 {
+		query["status"] = bson.M{"$in": filter.Status}
+	}
+	if len(filter.Labels) > 0 {
+		query["labels"] = bson.M{"$all": filter.Labels}
+	}
+	if filter.AssignedTo != nil {
+		query["assignedto"] = *filter.AssignedTo
+	}
+	if filter.Search != nil && *filter.Search != "" {
+		query["$or"] = searchFilter(*filter.Search, "title", "description")
+	}
+
+	return query
+}
+
+// searchFilter matches the text anywhere in any of the keys, ignoring case.
+func searchFilter(text string, keys ...string) bson.A {
+	pattern := primitive.Regex{Pattern: regexp.QuoteMeta(text), Options: "i"}
+	or := bson.A{}
+	for _, key := range keys {
+		or = append(or, bson.M{key: pattern})
+	}
+	return or
+}
+
+// ReadTasksPage reads a page of the tasks matching the filter.
+func (db *DB) ReadTasksPage(ctx context.Context, filter *model.TaskFilterInput, sort model.TaskSort, direction model.SortDirection, first int, after *string) (*model.TaskConnection, error) {
+	collection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	query, err := BuildTaskFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := taskSortKeys[sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown task sort %s", sort)
+	}
+	sortValue := func(t storedTask) *string {
+		switch sort {
+		case model.TaskSortUpdatedAt:
+			return &t.UpdatedAt
+		case model.TaskSortDueDate:
+			return t.DueDate
+		case model.TaskSortTitle:
+			return &t.Title
+		default:
+			return &t.CreatedAt
+		}
+	}
+
+	p, err := readPage(ctx, collection, query, key, direction == model.SortDirectionDesc, first, after, sortValue, func(t storedTask) string { return t.ID })
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading page of tasks:")
+		return nil, err
+	}
+
+	connection := &model.TaskConnection{
+		Edges:      make([]*model.TaskEdge, 0, len(p.items)),
+		PageInfo:   p.pageInfo,
+		TotalCount: p.total,
+	}
+	for i, task := range p.items {
+		connection.Edges = append(connection.Edges, &model.TaskEdge{Cursor: p.cursors[i], Node: task.toModel()})
+	}
+
+	return connection, nil
+}
+
+// ReadProjectsPage reads a page of the projects matching the filter.
+func (db *DB) ReadProjectsPage(ctx context.Context, filter *model.ProjectFilterInput, sort model.ProjectSort, direction model.SortDirection, first int, after *string) (*model.ProjectConnection, error) {
+	collection := db.client.Database("go_trading_db").Collection("Projects")
+
+	key, ok := projectSortKeys[sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown project sort %s", sort)
+	}
+	sortValue := func(p *model.Project) *string {
+		switch sort {
+		case model.ProjectSortUpdatedAt:
+			return &p.UpdatedAt
+		case model.ProjectSortDueDate:
+			return p.DueDate
+		case model.ProjectSortTitle:
+			return &p.Title
+		default:
+			return &p.CreatedAt
+		}
+	}
+
+	p, err := readPage(ctx, collection, BuildProjectFilter(filter), key, direction == model.SortDirectionDesc, first, after, sortValue, func(p *model.Project) string { return p.ID })
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading page of projects:")
+		return nil, err
+	}
+
+	connection := &model.ProjectConnection{
+		Edges:      make([]*model.ProjectEdge, 0, len(p.items)),
+		PageInfo:   p.pageInfo,
+		TotalCount: p.total,
+	}
+	for i, project := range p.items {
+		connection.Edges = append(connection.Edges, &model.ProjectEdge{Cursor: p.cursors[i], Node: project})
+	}
+
+	return connection, nil
+}
+
+// ReadProjects retrieves every project matching the filter, all of them
+// without one.
+func (db *DB) ReadProjects(ctx context.Context, filter *model.ProjectFilterInput) ([]*model.Project, error) {
+	collection := db.client.Database("go_trading_db").Collection("Projects")
+
+	cursor, err := collection.Find(ctx, BuildProjectFilter(filter))
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving filtered projects:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	projects := []*model.Project{}
+	if err := cursor.All(ctx, &projects); err != nil {
+		log.Error().Err(err).Msg("Error decoding filtered projects:")
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// GetTasksByProjectIDs retrieves the tasks of each project in one query, in
+// the order of the IDs given.
+func (db *DB) GetTasksByProjectIDs(ctx context.Context, projectIDs []string) ([][]*model.Task, error) {
+	collection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	cursor, err := collection.Find(ctx, bson.M{"projectId": bson.M{"$in": projectIDs}})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving tasks by project IDs:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stored []storedTask
+	if err := cursor.All(ctx, &stored); err != nil {
+		log.Error().Err(err).Msg("Error decoding tasks by project IDs:")
+		return nil, err
+	}
+
+	byProject := make(map[string][]*model.Task, len(projectIDs))
+	for _, task := range stored {
+		if task.ProjectID != nil {
+			byProject[*task.ProjectID] = append(byProject[*task.ProjectID], task.toModel())
+		}
+	}
+
+	tasks := make([][]*model.Task, len(projectIDs))
+	for i, id := range projectIDs {
+		tasks[i] = byProject[id]
+		if tasks[i] == nil {
+			tasks[i] = []*model.Task{}
+		}
+	}
+	return tasks, nil
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// PromoteDeferredTasks moves scheduled tasks whose deferDate has been reached
+// by now to nextAction, and returns them. Tasks with open blockers stay
+// scheduled until the blockers are complete.
+func (db *DB) PromoteDeferredTasks(ctx context.Context, now time.Time) ([]*model.Task, error) {
+	collection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	cursor, err := collection.Find(ctx, bson.M{"status": gtd.Scheduled})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving scheduled tasks:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var scheduled []storedTask
+	if err := cursor.All(ctx, &scheduled); err != nil {
+		log.Error().Err(err).Msg("Error decoding scheduled tasks:")
+		return nil, err
+	}
+
+	var blockerIDs []string
+	for _, task := range scheduled {
+		if gtd.Due(task.DeferDate, now) {
+			blockerIDs = append(blockerIDs, task.BlockedBy...)
+		}
+	}
+	open, err := openBlockers(ctx, collection, blockerIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("Error checking blockers of deferred tasks:")
+		return nil, err
+	}
+
+	updatedAt := now.Format(time.RFC3339)
+	var ids []string
+	var promoted []storedTask
+	for _, task := range scheduled {
+		if !gtd.Due(task.DeferDate, now) {
+			continue
+		}
+		if slices.ContainsFunc(task.BlockedBy, func(id string) bool { return slices.Contains(open, id) }) {
+			continue
+		}
+		task.Status, task.UpdatedAt = gtd.NextAction, updatedAt
+		ids = append(ids, task.ID)
+		promoted = append(promoted, task)
+	}
+	if len(ids) == 0 {
+		return []*model.Task{}, nil
+	}
+
+	// Match the status too, so a task moved on meanwhile is left alone.
+	filter := bson.M{"id": bson.M{"$in": ids}, "status": gtd.Scheduled}
+	update := bson.M{"$set": bson.M{"status": gtd.NextAction, "updatedAt": updatedAt}}
+	if _, err := collection.UpdateMany(ctx, filter, update); err != nil {
+		log.Error().Err(err).Msg("Error promoting deferred tasks:")
+		return nil, err
+	}
+
+	from, to := gtd.Scheduled, gtd.NextAction
+	entries := make([]*model.ActivityEntry, 0, len(promoted))
+	for _, task := range promoted {
+		entry := newActivity(ctx, model.ActivityTargetTask, task.ID, model.ActivityKindUpdated, now)
+		entry.Changes = []*model.FieldChange{{Field: "status", From: &from, To: &to}}
+		entries = append(entries, entry)
+	}
+	db.recordActivity(ctx, entries...)
+
+	log.Info().Int("count", len(promoted)).Msg("Promoted deferred tasks to nextAction")
+	return toModelTasks(promoted), nil
+}
+
+// WeeklyReview gathers what needs attention as of now: waitingFor tasks not
+// updated for staleAfterDays, open tasks past their due date, and open
+// projects none of whose open tasks is a next action.
+func (db *DB) WeeklyReview(ctx context.Context, now time.Time, staleAfterDays int) (*model.WeeklyReview, error) {
+	tasksCollection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	cursor, err := tasksCollection.Find(ctx, bson.M{"status": bson.M{"$nin": []string{gtd.Complete, gtd.SomedayMaybe}}})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving open tasks:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var open []storedTask
+	if err := cursor.All(ctx, &open); err != nil {
+		log.Error().Err(err).Msg("Error decoding open tasks:")
+		return nil, err
+	}
+
+	review := &model.WeeklyReview{
+		GeneratedAt:               now.Format(time.RFC3339),
+		StaleWaitingFor:           []*model.Task{},
+		Overdue:                   []*model.Task{},
+		ProjectsWithoutNextAction: []*model.Project{},
+	}
+
+	staleBefore := now.AddDate(0, 0, -staleAfterDays)
+	withNextAction := map[string]bool{}
+	for _, task := range open {
+		if task.Status == gtd.WaitingFor {
+			// A task never stamped with a parsable update is treated as stale.
+			updatedAt, _, ok := gtd.ParseDate(task.UpdatedAt)
+			if !ok || updatedAt.Before(staleBefore) {
+				review.StaleWaitingFor = append(review.StaleWaitingFor, task.toModel())
+			}
+		}
+		if gtd.Overdue(task.DueDate, now) {
+			review.Overdue = append(review.Overdue, task.toModel())
+		}
+		if task.Status == gtd.NextAction && task.ProjectID != nil {
+			withNextAction[*task.ProjectID] = true
+		}
+	}
+
+	projectsCollection := db.client.Database("go_trading_db").Collection("Projects")
+
+	filter := bson.M{"sop": false, "status": bson.M{"$nin": []string{"completed", "archived"}}}
+	projectCursor, err := projectsCollection.Find(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving open projects:")
+		return nil, err
+	}
+	defer projectCursor.Close(ctx)
+
+	var projects []*model.Project
+	if err := projectCursor.All(ctx, &projects); err != nil {
+		log.Error().Err(err).Msg("Error decoding open projects:")
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if withNextAction[project.ID] {
+			continue
+		}
+		project.Tasks = []*model.Task{}
+		for _, task := range open {
+			if task.ProjectID != nil && *task.ProjectID == project.ID {
+				project.Tasks = append(project.Tasks, task.toModel())
+			}
+		}
+		review.ProjectsWithoutNextAction = append(review.ProjectsWithoutNextAction, project)
+	}
+
+	return review, nil
+}
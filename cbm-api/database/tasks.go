@@ -2,13 +2,16 @@ package database
 
 import (
 	"context"
+	"fmt"
 
 	"time"
 
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -16,36 +19,129 @@ import (
 // === Tasks ===
 // ==========================
 
-// CreateTask inserts a new task into the Tasks collection.
+// storedTask is a Tasks document. Tasks are written with camelCase keys, so
+// they are read back through these tags rather than model.Task's defaults.
+type storedTask struct {
+	ID               string    `bson:"id"`
+	Title            string    `bson:"title"`
+	Description      *string   `bson:"description"`
+	Status           string    `bson:"status"`
+	Labels           []*string `bson:"labels"`
+	AssignedTo       *string   `bson:"assignedTo"`
+	DueDate          *string   `bson:"dueDate"`
+	DeferDate        *string   `bson:"deferDate"`
+	Department       *string   `bson:"department"`
+	ProjectID        *string   `bson:"projectId"`
+	Duration         *int      `bson:"duration"`
+	Estimate         *int      `bson:"estimate"`
+	ParentID         *string   `bson:"parentId"`
+	BlockedBy        []string  `bson:"blockedBy"`
+	Recurrence       *string   `bson:"recurrence"`
+	NextOccurrenceID *string   `bson:"nextOccurrenceId"` // set once a completed recurring task has spawned the next
+	CreatedAt        string    `bson:"createdAt"`
+	UpdatedAt        string    `bson:"updatedAt"`
+}
+
+func (s storedTask) toModel() *model.Task {
+	return &model.Task{
+		ID:               s.ID,
+		Title:            s.Title,
+		Description:      s.Description,
+		Status:           s.Status,
+		Labels:           s.Labels,
+		AssignedTo:       s.AssignedTo,
+		DueDate:          s.DueDate,
+		DeferDate:        s.DeferDate,
+		Department:       s.Department,
+		ProjectID:        s.ProjectID,
+		Duration:         s.Duration,
+		Estimate:         s.Estimate,
+		ParentID:         s.ParentID,
+		BlockedBy:        s.BlockedBy,
+		Recurrence:       s.Recurrence,
+		NextOccurrenceID: s.NextOccurrenceID,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
+
+func toModelTasks(stored []storedTask) []*model.Task {
+	tasks := make([]*model.Task, 0, len(stored))
+	for _, s := range stored {
+		tasks = append(tasks, s.toModel())
+	}
+	return tasks
+}
+
+// CreateTask inserts a new task into the Tasks collection. The status must be
+// one of the workflow's, inbox if not given, and the task cannot start as a
+// next action while its blockers are open, unless it is imported.
 func (db *DB) CreateTask(ctx context.Context, input model.CreateTaskInput) (*model.Task, error) {
 	collection := db.client.Database("go_trading_db").Collection("Tasks")
 
+	status := gtd.Inbox
+	if input.Status != nil {
+		status = *input.Status
+	}
+	if err := gtd.ValidateStatus(status); err != nil {
+		return nil, err
+	}
+	// Empty strings leave the task without a parent or recurrence.
+	parentID, recurrence := nilIfEmpty(input.ParentID), nilIfEmpty(input.Recurrence)
+	if recurrence != nil {
+		if err := gtd.ValidateRecurrence(*recurrence); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateTaskLinks(ctx, collection, "", parentID, input.BlockedBy); err != nil {
+		return nil, err
+	}
+	if !isImported(input.Imported) {
+		open, err := openBlockers(ctx, collection, input.BlockedBy)
+		if err != nil {
+			log.Error().Err(err).Msg("Error checking task blockers:")
+			return nil, err
+		}
+		if err := gtd.ValidateUnblocked(gtd.Inbox, status, open); err != nil {
+			return nil, err
+		}
+	}
+	id, err := newID(ctx, collection, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now().Format(time.RFC3339)
 
-	task := bson.M{
-		"id":          primitive.NewObjectID().Hex(),
-		"title":       input.Title,
-		"description": input.Description,
-		"status":      *input.Status,
-		"labels":      input.Labels,
-		"assignedTo":  input.AssignedTo,
-		"dueDate":     input.DueDate,
-		"deferDate":   input.DeferDate,
-		"department":  input.Department,
-		"projectId":   input.ProjectID, // ensure this stays a string
-		"duration":    input.Duration,
-		"createdAt":   now,
-		"updatedAt":   now,
-	}
-
-	var updated model.Task
-	_, err := collection.InsertOne(ctx, task)
+	task := storedTask{
+		ID:          id,
+		Title:       input.Title,
+		Description: input.Description,
+		Status:      status,
+		Labels:      input.Labels,
+		AssignedTo:  input.AssignedTo,
+		DueDate:     input.DueDate,
+		DeferDate:   input.DeferDate,
+		Department:  input.Department,
+		ProjectID:   input.ProjectID, // ensure this stays a string
+		Duration:    input.Duration,
+		Estimate:    input.Estimate,
+		ParentID:    parentID,
+		BlockedBy:   input.BlockedBy,
+		Recurrence:  recurrence,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	_, err = collection.InsertOne(ctx, task)
 	if err != nil {
 		log.Error().Err(err).Msg("Error inserting task into the database:")
 		return nil, err
 	}
 
-	return &updated, nil
+	db.recordActivity(ctx, newActivity(ctx, model.ActivityTargetTask, task.ID, model.ActivityKindCreated, time.Now()))
+
+	return task.toModel(), nil
 }
 
 // ReadAllTasks fetches all tasks from the Tasks collection.
@@ -59,13 +155,13 @@ func (db *DB) ReadAllTasks(ctx context.Context) ([]*model.Task, error) {
 	}
 	defer cursor.Close(ctx)
 
-	var tasks []*model.Task
+	var tasks []storedTask
 	if err := cursor.All(ctx, &tasks); err != nil {
 		log.Error().Err(err).Msg("Error decoding tasks:")
 		return nil, err
 	}
 
-	return tasks, nil
+	return toModelTasks(tasks), nil
 }
 
 // ReadTaskByID fetches a single task by its ID.
@@ -74,14 +170,14 @@ func (db *DB) ReadTaskByID(ctx context.Context, id string) (*model.Task, error)
 
 	filter := bson.M{"id": id}
 
-	var task model.Task
+	var task storedTask
 	err := collection.FindOne(ctx, filter).Decode(&task)
 	if err != nil {
 		log.Error().Err(err).Msg("Error retrieving task by ID:")
 		return nil, err
 	}
 
-	return &task, nil
+	return task.toModel(), nil
 }
 
 // ReadTasksByProjectID retrieves tasks associated with a specific project.
@@ -97,20 +193,69 @@ func (db *DB) GetTasksByProjectID(ctx context.Context, projectID string) ([]*mod
 	}
 	defer cursor.Close(ctx)
 
-	var tasks []*model.Task
+	var tasks []storedTask
 	if err := cursor.All(ctx, &tasks); err != nil {
 		log.Error().Err(err).Msg("Error decoding tasks by project ID:")
 		return nil, err
 	}
 
-	return tasks, nil
+	return toModelTasks(tasks), nil
 }
 
-// UpdateTask updates an existing task in the Tasks collection.
+// UpdateTask updates an existing task in the Tasks collection. A status change
+// must be one the workflow allows, and cannot make a next action of a task
+// whose blockers are open. Completing a recurring task spawns its next
+// occurrence. An imported update sets any of the workflow's statuses as it
+// was exported, without spawning one, as the export has it already.
 func (db *DB) UpdateTask(ctx context.Context, input model.UpdateTaskInput) (*model.Task, error) {
 	collection := db.client.Database("go_trading_db").Collection("Tasks")
 
 	filter := bson.M{"id": input.ID}
+
+	var current storedTask
+	if err := collection.FindOne(ctx, filter).Decode(&current); err != nil {
+		log.Error().Err(err).Msg("Error retrieving task to update:")
+		return nil, err
+	}
+
+	imported := isImported(input.Imported)
+	if input.Status != nil {
+		var err error
+		if imported {
+			err = gtd.ValidateStatus(*input.Status)
+		} else {
+			err = gtd.ValidateTransition(current.Status, *input.Status)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.Recurrence != nil && *input.Recurrence != "" {
+		if err := gtd.ValidateRecurrence(*input.Recurrence); err != nil {
+			return nil, err
+		}
+	}
+	if input.ParentID != nil || input.BlockedBy != nil {
+		if err := validateTaskLinks(ctx, collection, input.ID, input.ParentID, input.BlockedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Status != nil && !imported {
+		blockedBy := current.BlockedBy
+		if input.BlockedBy != nil {
+			blockedBy = input.BlockedBy
+		}
+		open, err := openBlockers(ctx, collection, blockedBy)
+		if err != nil {
+			log.Error().Err(err).Msg("Error checking task blockers:")
+			return nil, err
+		}
+		if err := gtd.ValidateUnblocked(current.Status, *input.Status, open); err != nil {
+			return nil, err
+		}
+	}
+
 	updateFields := bson.M{
 		"updatedAt": time.Now().Format(time.RFC3339),
 	}
@@ -146,6 +291,19 @@ func (db *DB) UpdateTask(ctx context.Context, input model.UpdateTaskInput) (*mod
 	if input.Duration != nil {
 		updateFields["duration"] = input.Duration
 	}
+	if input.Estimate != nil {
+		updateFields["estimate"] = input.Estimate
+	}
+	// Empty strings clear the parent and recurrence.
+	if input.ParentID != nil {
+		updateFields["parentId"] = nilIfEmpty(input.ParentID)
+	}
+	if input.BlockedBy != nil {
+		updateFields["blockedBy"] = input.BlockedBy
+	}
+	if input.Recurrence != nil {
+		updateFields["recurrence"] = nilIfEmpty(input.Recurrence)
+	}
 
 	update := bson.M{"$set": updateFields}
 
@@ -155,14 +313,63 @@ func (db *DB) UpdateTask(ctx context.Context, input model.UpdateTaskInput) (*mod
 		return nil, err
 	}
 
-	var updated model.Task
+	var updated storedTask
 	err = collection.FindOne(ctx, filter).Decode(&updated)
 	if err != nil {
 		log.Error().Err(err).Msg("Error retrieving updated task:")
 		return nil, err
 	}
 
-	return &updated, nil
+	now := time.Now()
+	if changes := diffTasks(current, updated); len(changes) > 0 {
+		entry := newActivity(ctx, model.ActivityTargetTask, updated.ID, model.ActivityKindUpdated, now)
+		entry.Changes = changes
+		db.recordActivity(ctx, entry)
+	}
+
+	if current.Status != gtd.Complete && updated.Status == gtd.Complete && !imported {
+		next, err := spawnNextOccurrence(ctx, collection, updated, now)
+		if err != nil {
+			log.Error().Err(err).Str("taskID", updated.ID).Msg("Error spawning next occurrence of recurring task:")
+			return nil, err
+		}
+		if next != nil {
+			updated.NextOccurrenceID = &next.ID
+			db.recordActivity(ctx, newActivity(ctx, model.ActivityTargetTask, next.ID, model.ActivityKindCreated, now))
+		}
+	}
+
+	return updated.toModel(), nil
+}
+
+func isImported(imported *bool) bool {
+	return imported != nil && *imported
+}
+
+// nilIfEmpty returns nil for an empty string, so that it is stored as null.
+func nilIfEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+// newID returns the ID a new document is given: a fresh one, or the one asked
+// for, so that imports keep theirs. It refuses an ID the collection already
+// has a document with.
+func newID(ctx context.Context, collection *mongo.Collection, id *string) (string, error) {
+	if id == nil || *id == "" {
+		return primitive.NewObjectID().Hex(), nil
+	}
+	count, err := collection.CountDocuments(ctx, bson.M{"id": *id})
+	if err != nil {
+		log.Error().Err(err).Msg("Error checking ID is free:")
+		return "", err
+	}
+	if count > 0 {
+		return "", fmt.Errorf("ID %s is already taken", *id)
+	}
+	return *id, nil
 }
 
 // DeleteTaskByID removes a task by its ID.
@@ -177,6 +384,14 @@ func (db *DB) DeleteTaskByID(ctx context.Context, id string) (bool, error) {
 		return false, err
 	}
 
+	if result.DeletedCount > 0 {
+		db.recordActivity(ctx, newActivity(ctx, model.ActivityTargetTask, id, model.ActivityKindDeleted, time.Now()))
+		if err := unlinkTask(ctx, collection, id); err != nil {
+			log.Error().Err(err).Str("taskID", id).Msg("Error unlinking deleted task:")
+			return false, err
+		}
+	}
+
 	return result.DeletedCount > 0, nil
 }
 
@@ -188,10 +403,15 @@ func (db *DB) DeleteTaskByID(ctx context.Context, id string) (bool, error) {
 func (db *DB) CreateProject(ctx context.Context, input model.CreateProjectInput) (*model.Project, error) {
 	collection := db.client.Database("go_trading_db").Collection("Projects")
 
+	id, err := newID(ctx, collection, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now().Format(time.RFC3339)
 
 	project := &model.Project{
-		ID:          primitive.NewObjectID().Hex(),
+		ID:          id,
 		Title:       input.Title,
 		Sop:         *input.Sop,
 		Description: input.Description,
@@ -204,12 +424,14 @@ func (db *DB) CreateProject(ctx context.Context, input model.CreateProjectInput)
 		UpdatedAt: now,
 	}
 
-	_, err := collection.InsertOne(ctx, project)
+	_, err = collection.InsertOne(ctx, project)
 	if err != nil {
 		log.Error().Err(err).Msg("Error inserting project into the database:")
 		return nil, err
 	}
 
+	db.recordActivity(ctx, newActivity(ctx, model.ActivityTargetProject, project.ID, model.ActivityKindCreated, time.Now()))
+
 	return project, nil
 }
 
@@ -229,36 +451,20 @@ func (db *DB) ReadSingleProjectByID(ctx context.Context, id string) (*model.Proj
 	return &project, nil
 }
 
-// ReadProjectsBySOP retrieves projects filtered by the SOP boolean field.
-func (db *DB) ReadProjectsBySOP(ctx context.Context, sop bool) ([]*model.Project, error) {
+// UpdateProject updates an existing project in the Projects collection.
+func (db *DB) UpdateProject(ctx context.Context, input model.UpdateProjectInput) (*model.Project, error) {
 	collection := db.client.Database("go_trading_db").Collection("Projects")
 
-	// Build the MongoDB filter
-	filter := bson.M{"sop": sop}
-
-	cursor, err := collection.Find(ctx, filter)
-	if err != nil {
-		log.Error().Err(err).Msg("Error retrieving filtered projects:")
-		return nil, err
-	}
-	defer cursor.Close(ctx)
+	filter := bson.M{"id": input.ID}
 
-	var projects []*model.Project
-	if err := cursor.All(ctx, &projects); err != nil {
-		log.Error().Err(err).Msg("Error decoding filtered projects:")
+	var current model.Project
+	if err := collection.FindOne(ctx, filter).Decode(&current); err != nil {
+		log.Error().Err(err).Msg("Error retrieving project to update:")
 		return nil, err
 	}
 
-	return projects, nil
-}
-
-// UpdateProject updates an existing project in the Projects collection.
-func (db *DB) UpdateProject(ctx context.Context, input model.UpdateProjectInput) (*model.Project, error) {
-	collection := db.client.Database("go_trading_db").Collection("Projects")
-
-	filter := bson.M{"id": input.ID}
 	updateFields := bson.M{
-		"updatedAt": time.Now().Format(time.RFC3339),
+		"updatedat": time.Now().Format(time.RFC3339),
 	}
 
 	if input.Title != nil {
@@ -268,10 +474,10 @@ func (db *DB) UpdateProject(ctx context.Context, input model.UpdateProjectInput)
 		updateFields["description"] = input.Description
 	}
 	if input.AssignedTo != nil {
-		updateFields["assignedTo"] = input.AssignedTo
+		updateFields["assignedto"] = input.AssignedTo
 	}
 	if input.DueDate != nil {
-		updateFields["dueDate"] = input.DueDate
+		updateFields["duedate"] = input.DueDate
 	}
 	if input.Status != nil {
 		updateFields["status"] = input.Status
@@ -298,6 +504,12 @@ func (db *DB) UpdateProject(ctx context.Context, input model.UpdateProjectInput)
 		return nil, err
 	}
 
+	if changes := diffProjects(&current, &updated); len(changes) > 0 {
+		entry := newActivity(ctx, model.ActivityTargetProject, updated.ID, model.ActivityKindUpdated, time.Now())
+		entry.Changes = changes
+		db.recordActivity(ctx, entry)
+	}
+
 	return &updated, nil
 }
 
@@ -313,5 +525,9 @@ func (db *DB) DeleteProjectByID(ctx context.Context, id string) (bool, error) {
 		return false, err
 	}
 
+	if result.DeletedCount > 0 {
+		db.recordActivity(ctx, newActivity(ctx, model.ActivityTargetProject, id, model.ActivityKindDeleted, time.Now()))
+	}
+
 	return result.DeletedCount > 0, nil
 }
@@ -0,0 +1,312 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+	"cryptobotmanager.com/cbm-backend/cbm-api/timetrack"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// StartTimer starts timing a task for the user at now. A timer of the user's
+// already running on the task is returned as it is; one running on another
+// task is stopped first.
+func (db *DB) StartTimer(ctx context.Context, taskID, userID string, now time.Time) (*model.TimeEntry, error) {
+	collection := db.client.Database("go_trading_db").Collection("TimeEntries")
+
+	if _, err := db.ReadTaskByID(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("task %s not found: %w", taskID, err)
+	}
+
+	running, err := db.ReadRunningTimer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil && running.TaskID == taskID {
+		return running, nil
+	}
+	if running != nil {
+		if _, err := db.stopEntry(ctx, running, now); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &model.TimeEntry{
+		ID:        primitive.NewObjectID().Hex(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: now.UTC().Format(time.RFC3339),
+	}
+	if _, err := collection.InsertOne(ctx, entry); err != nil {
+		// One timer runs per user, so a start racing this one got there first
+		if mongo.IsDuplicateKeyError(err) {
+			running, readErr := db.ReadRunningTimer(ctx, userID)
+			if readErr != nil {
+				return nil, readErr
+			}
+			if running != nil && running.TaskID == taskID {
+				return running, nil
+			}
+			return nil, fmt.Errorf("another timer was started at the same time, stop it to time task %s", taskID)
+		}
+		log.Error().Err(err).Msg("Error inserting time entry:")
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// StopTimer stops the user's timer on a task at now and rolls the task's time
+// entries up into its duration.
+func (db *DB) StopTimer(ctx context.Context, taskID, userID string, now time.Time) (*model.TimeEntry, error) {
+	collection := db.client.Database("go_trading_db").Collection("TimeEntries")
+
+	var running model.TimeEntry
+	err := collection.FindOne(ctx, bson.M{"taskid": taskID, "userid": userID, "stoppedat": nil}).Decode(&running)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("no timer is running on task %s", taskID)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving running timer:")
+		return nil, err
+	}
+
+	return db.stopEntry(ctx, &running, now)
+}
+
+// stopEntry stops a running entry at now and rolls its task's entries up into
+// the task's duration.
+func (db *DB) stopEntry(ctx context.Context, entry *model.TimeEntry, now time.Time) (*model.TimeEntry, error) {
+	collection := db.client.Database("go_trading_db").Collection("TimeEntries")
+
+	started, err := time.Parse(time.RFC3339, entry.StartedAt)
+	if err != nil {
+		return nil, fmt.Errorf("time entry %s has an invalid start %q", entry.ID, entry.StartedAt)
+	}
+	stoppedAt := now.UTC().Format(time.RFC3339)
+	seconds := int(max(now.Sub(started), 0) / time.Second)
+
+	// Match the entry still running, so stopping it twice at once counts it once.
+	filter := bson.M{"id": entry.ID, "stoppedat": nil}
+	update := bson.M{"$set": bson.M{"stoppedat": stoppedAt, "durationseconds": seconds}}
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Error().Err(err).Msg("Error stopping time entry:")
+		return nil, err
+	}
+	if result.ModifiedCount == 0 {
+		return nil, fmt.Errorf("time entry %s was already stopped", entry.ID)
+	}
+	entry.StoppedAt, entry.DurationSeconds = &stoppedAt, &seconds
+
+	if err := db.rollUpDuration(ctx, entry.TaskID); err != nil {
+		log.Error().Err(err).Str("taskID", entry.TaskID).Msg("Error rolling up task duration:")
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// rollUpDuration sets a task's duration to the minutes of its stopped entries.
+func (db *DB) rollUpDuration(ctx context.Context, taskID string) error {
+	return rollUpDuration(ctx, db.client.Database("go_trading_db"), taskID)
+}
+
+func rollUpDuration(ctx context.Context, database *mongo.Database, taskID string) error {
+	entries := database.Collection("TimeEntries")
+	tasks := database.Collection("Tasks")
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"taskid": taskID, "stoppedat": bson.M{"$ne": nil}}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "seconds": bson.M{"$sum": "$durationseconds"}}}},
+	}
+	cursor, err := entries.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var totals []struct {
+		Seconds int64 `bson:"seconds"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil {
+		return err
+	}
+
+	var seconds int64
+	if len(totals) > 0 {
+		seconds = totals[0].Seconds
+	}
+	_, err = tasks.UpdateOne(ctx, bson.M{"id": taskID}, bson.M{"$set": bson.M{"duration": timetrack.Minutes(seconds)}})
+	return err
+}
+
+// ReadRunningTimer retrieves the user's running timer, or nil when none runs.
+func (db *DB) ReadRunningTimer(ctx context.Context, userID string) (*model.TimeEntry, error) {
+	collection := db.client.Database("go_trading_db").Collection("TimeEntries")
+
+	var entry model.TimeEntry
+	err := collection.FindOne(ctx, bson.M{"userid": userID, "stoppedat": nil}).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving running timer:")
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// ReadTimeEntries retrieves the time entries of a task, a user or both, newest
+// first.
+func (db *DB) ReadTimeEntries(ctx context.Context, taskID, userID *string) ([]*model.TimeEntry, error) {
+	collection := db.client.Database("go_trading_db").Collection("TimeEntries")
+
+	filter := bson.M{}
+	if taskID != nil {
+		filter["taskid"] = *taskID
+	}
+	if userID != nil {
+		filter["userid"] = *userID
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "startedat", Value: -1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving time entries:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*model.TimeEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		log.Error().Err(err).Msg("Error decoding time entries:")
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// TimeReport totals the time tracked between from and to, as of now. A date
+// alone for to includes the whole of that day.
+func (db *DB) TimeReport(ctx context.Context, from, to string, now time.Time) (*model.TimeReport, error) {
+	collection := db.client.Database("go_trading_db").Collection("TimeEntries")
+
+	start, _, ok := gtd.ParseDate(from)
+	if !ok {
+		return nil, fmt.Errorf("invalid from %q, expected RFC 3339 or YYYY-MM-DD", from)
+	}
+	end, dateOnly, ok := gtd.ParseDate(to)
+	if !ok {
+		return nil, fmt.Errorf("invalid to %q, expected RFC 3339 or YYYY-MM-DD", to)
+	}
+	if dateOnly {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("to %s must be after from %s", to, from)
+	}
+
+	// Entries are stamped in UTC, so they compare as strings.
+	filter := bson.M{
+		"startedat": bson.M{"$lt": end.UTC().Format(time.RFC3339)},
+		"$or": bson.A{
+			bson.M{"stoppedat": nil},
+			bson.M{"stoppedat": bson.M{"$gt": start.UTC().Format(time.RFC3339)}},
+		},
+	}
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving time entries for report:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*model.TimeEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		log.Error().Err(err).Msg("Error decoding time entries for report:")
+		return nil, err
+	}
+
+	taskIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		taskIDs = append(taskIDs, entry.TaskID)
+	}
+	found, err := db.GetTasksByIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make(map[string]*model.Task, len(found))
+	var projectIDs []string
+	for _, task := range found {
+		if task == nil {
+			continue
+		}
+		tasks[task.ID] = task
+		if task.ProjectID != nil {
+			projectIDs = append(projectIDs, *task.ProjectID)
+		}
+	}
+
+	projectTitles, err := db.projectTitles(ctx, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return timetrack.Report(entries, tasks, projectTitles, start, end, now), nil
+}
+
+// projectTitles retrieves the titles of the projects by ID.
+func (db *DB) projectTitles(ctx context.Context, ids []string) (map[string]string, error) {
+	collection := db.client.Database("go_trading_db").Collection("Projects")
+
+	titles := map[string]string{}
+	if len(ids) == 0 {
+		return titles, nil
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"id": bson.M{"$in": ids}})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving project titles:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []*model.Project
+	if err := cursor.All(ctx, &projects); err != nil {
+		log.Error().Err(err).Msg("Error decoding project titles:")
+		return nil, err
+	}
+	for _, project := range projects {
+		titles[project.ID] = project.Title
+	}
+
+	return titles, nil
+}
+
+// Workload weighs the remaining estimated effort of each assignee's open tasks
+// against their due dates, as of now and up to days ahead.
+func (db *DB) Workload(ctx context.Context, now time.Time, days int) ([]*model.AssigneeWorkload, error) {
+	collection := db.client.Database("go_trading_db").Collection("Tasks")
+
+	if days < 0 {
+		return nil, fmt.Errorf("days must not be negative, got %d", days)
+	}
+
+	open, err := findTasks(ctx, collection, bson.M{"status": bson.M{"$nin": []string{gtd.Complete, gtd.SomedayMaybe}}})
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving open tasks for workload:")
+		return nil, err
+	}
+
+	return timetrack.Workload(toModelTasks(open), now, days), nil
+}
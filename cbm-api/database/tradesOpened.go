@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// storedTradeOpened is a TradesOpened document, read back with its ID.
+type storedTradeOpened struct {
+	ID                   primitive.ObjectID `bson:"_id"`
+	model.NewTradeOpened `bson:",inline"`
+}
+
+func (s storedTradeOpened) toModel() *model.TradeOpened {
+	return &model.TradeOpened{
+		ID:         s.ID.Hex(),
+		Timestamp:  s.Timestamp,
+		BotName:    s.BotName,
+		Symbol:     s.Symbol,
+		EntryPrice: s.EntryPrice,
+		TakeProfit: s.TakeProfit,
+		StopLoss:   s.StopLoss,
+		TimesOutAt: s.TimesOutAt,
+		VersionID:  s.VersionID,
+	}
+}
+
+// CreateTradeOpened records a trade a bot has opened.
+func (db *DB) CreateTradeOpened(ctx context.Context, input model.NewTradeOpened) (*model.TradeOpened, error) {
+	collection := db.client.Database("go_trading_db").Collection("TradesOpened")
+
+	res, err := collection.InsertOne(ctx, input)
+	if err != nil {
+		log.Error().Err(err).Msg("Error saving opened trade:")
+		return nil, err
+	}
+
+	return storedTradeOpened{ID: res.InsertedID.(primitive.ObjectID), NewTradeOpened: input}.toModel(), nil
+}
+
+// ReadTradesOpened retrieves the trades the bot has opened, most recent first.
+func (db *DB) ReadTradesOpened(ctx context.Context, botName string, limit *int) ([]*model.TradeOpened, error) {
+	collection := db.client.Database("go_trading_db").Collection("TradesOpened")
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"botname": botName}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Error querying opened trades:")
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stored []storedTradeOpened
+	if err := cursor.All(ctx, &stored); err != nil {
+		log.Error().Err(err).Msg("Error decoding opened trades:")
+		return nil, err
+	}
+
+	trades := make([]*model.TradeOpened, 0, len(stored))
+	for _, s := range stored {
+		trades = append(trades, s.toModel())
+	}
+	return trades, nil
+}
@@ -115,14 +115,14 @@ func (db *DB) UpdateUser(ctx context.Context, input model.UpdateUserInput) (*mod
 
 	filter := bson.M{"id": input.ID}
 	updateFields := bson.M{
-		"upatedAt": time.Now(), // your schema has a typo 'upatedAt'—you might want to fix it
+		"updatedat": time.Now(),
 	}
 
 	if input.FirstName != nil {
-		updateFields["firstName"] = *input.FirstName
+		updateFields["firstname"] = *input.FirstName
 	}
 	if input.LastName != nil {
-		updateFields["lastName"] = *input.LastName
+		updateFields["lastname"] = *input.LastName
 	}
 	if input.Email != nil {
 		updateFields["email"] = *input.Email
@@ -136,41 +136,41 @@ func (db *DB) UpdateUser(ctx context.Context, input model.UpdateUserInput) (*mod
 		updateFields["password"] = string(hashedPassword)
 	}
 	if input.MobileNumber != nil {
-		updateFields["mobileNumber"] = *input.MobileNumber
+		updateFields["mobilenumber"] = *input.MobileNumber
 	}
 	if input.VerifiedEmail != nil {
-		updateFields["verifiedEmail"] = *input.VerifiedEmail
+		updateFields["verifiedemail"] = *input.VerifiedEmail
 	}
 	if input.VerifiedMobile != nil {
-		updateFields["verifiedMobile"] = *input.VerifiedMobile
+		updateFields["verifiedmobile"] = *input.VerifiedMobile
 	}
 	if input.Role != nil {
 		updateFields["role"] = *input.Role
 	}
 	if input.OpenToTrade != nil {
-		updateFields["openToTrade"] = *input.OpenToTrade
+		updateFields["opentotrade"] = *input.OpenToTrade
 	}
 	if input.BinanceAPI != nil {
-		updateFields["binanceAPI"] = *input.BinanceAPI
+		updateFields["binanceapi"] = *input.BinanceAPI
 	}
 	if input.PreferredContactMethod != nil {
-		updateFields["preferredContactMethod"] = *input.PreferredContactMethod
+		updateFields["preferredcontactmethod"] = *input.PreferredContactMethod
 	}
 	if input.Notes != nil {
 		updateFields["notes"] = *input.Notes
 	}
 	if input.InvitedBy != nil {
-		updateFields["invitedBy"] = *input.InvitedBy
+		updateFields["invitedby"] = *input.InvitedBy
 	}
 	if input.JoinedBallot != nil {
-		updateFields["joinedBallot"] = *input.JoinedBallot
+		updateFields["joinedballot"] = *input.JoinedBallot
 	}
 	if input.IsPaidMember != nil {
-		updateFields["isPaidMember"] = *input.IsPaidMember
+		updateFields["ispaidmember"] = *input.IsPaidMember
 	}
 
-	// This is required, because your schema expects it to be provided explicitly
-	updateFields["isDeleted"] = input.IsDeleted
+	// isDeleted is required by the input, so it is always written
+	updateFields["isdeleted"] = input.IsDeleted
 
 	update := bson.M{"$set": updateFields}
 
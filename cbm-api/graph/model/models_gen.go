@@ -10,6 +10,17 @@ import (
 	"time"
 )
 
+type ActivityEntry struct {
+	ID         string         `json:"id"`
+	TargetType ActivityTarget `json:"targetType"`
+	TargetID   string         `json:"targetId"`
+	Kind       ActivityKind   `json:"kind"`
+	ActorID    *string        `json:"actorId,omitempty"`
+	At         string         `json:"at"`
+	Changes    []*FieldChange `json:"changes"`
+	Comment    *Comment       `json:"comment,omitempty"`
+}
+
 type ActivityReport struct {
 	ID             string   `json:"_id"`
 	Timestamp      int      `json:"Timestamp"`
@@ -19,9 +30,173 @@ type ActivityReport struct {
 	TopBGain       *float64 `json:"TopBGain,omitempty"`
 	TopCGain       *float64 `json:"TopCGain,omitempty"`
 	FearGreedIndex int      `json:"FearGreedIndex"`
+	Breadth        *float64 `json:"Breadth,omitempty"`
+	MarketStatus   *string  `json:"MarketStatus,omitempty"`
+}
+
+type AddCommentInput struct {
+	TargetType ActivityTarget `json:"targetType"`
+	TargetID   string         `json:"targetId"`
+	Body       string         `json:"body"`
+	ReplyTo    *string        `json:"replyTo,omitempty"`
+}
+
+type AnalyticsBucket struct {
+	Key   string        `json:"Key"`
+	Stats *OutcomeStats `json:"Stats"`
+}
+
+type AssigneeWorkload struct {
+	AssignedTo       *string `json:"assignedTo,omitempty"`
+	OpenTasks        int     `json:"openTasks"`
+	UnestimatedTasks int     `json:"unestimatedTasks"`
+	RemainingMinutes int     `json:"remainingMinutes"`
+	OverdueMinutes   int     `json:"overdueMinutes"`
+	DueSoonMinutes   int     `json:"dueSoonMinutes"`
+	LaterMinutes     int     `json:"laterMinutes"`
+	NextDueDate      *string `json:"nextDueDate,omitempty"`
+}
+
+type BacktestParameters struct {
+	TradeDuration        int      `json:"TradeDuration"`
+	IncrementsAtr        int      `json:"IncrementsATR"`
+	LongSMADuration      int      `json:"LongSMADuration"`
+	ShortSMADuration     int      `json:"ShortSMADuration"`
+	MovingAveMomentum    float64  `json:"MovingAveMomentum"`
+	TakeProfitPercentage float64  `json:"TakeProfitPercentage"`
+	StopLossPercentage   float64  `json:"StopLossPercentage"`
+	ATRtollerance        *float64 `json:"ATRtollerance,omitempty"`
+	FeePercentage        float64  `json:"FeePercentage"`
+}
+
+type BacktestParametersInput struct {
+	TradeDuration        int      `json:"TradeDuration"`
+	IncrementsAtr        int      `json:"IncrementsATR"`
+	LongSMADuration      int      `json:"LongSMADuration"`
+	ShortSMADuration     int      `json:"ShortSMADuration"`
+	MovingAveMomentum    float64  `json:"MovingAveMomentum"`
+	TakeProfitPercentage float64  `json:"TakeProfitPercentage"`
+	StopLossPercentage   float64  `json:"StopLossPercentage"`
+	ATRtollerance        *float64 `json:"ATRtollerance,omitempty"`
+	FeePercentage        float64  `json:"FeePercentage"`
+}
+
+type BacktestRun struct {
+	RunID           string              `json:"RunID"`
+	BotInstanceName string              `json:"BotInstanceName"`
+	Parameters      *BacktestParameters `json:"Parameters"`
+	DatasetID       string              `json:"DatasetID"`
+	From            int                 `json:"From"`
+	To              int                 `json:"To"`
+	StartingBalance float64             `json:"StartingBalance"`
+	EndingBalance   float64             `json:"EndingBalance"`
+	FeesTotal       float64             `json:"FeesTotal"`
+	WINCounter      int                 `json:"WINCounter"`
+	LOSSCounter     int                 `json:"LOSSCounter"`
+	TIMEOUTCounter  int                 `json:"TIMEOUTCounter"`
+	CreatedOn       int                 `json:"CreatedOn"`
+	Stats           *BacktestStats      `json:"Stats"`
+	EquityCurve     []*EquityPoint      `json:"EquityCurve"`
+	Trades          []*BacktestTrade    `json:"Trades"`
+}
+
+type BacktestRunInput struct {
+	BotInstanceName string                   `json:"BotInstanceName"`
+	Parameters      *BacktestParametersInput `json:"Parameters"`
+	DatasetID       string                   `json:"DatasetID"`
+	From            int                      `json:"From"`
+	To              int                      `json:"To"`
+	StartingBalance float64                  `json:"StartingBalance"`
+	EndingBalance   float64                  `json:"EndingBalance"`
+	FeesTotal       float64                  `json:"FeesTotal"`
+	WINCounter      int                      `json:"WINCounter"`
+	LOSSCounter     int                      `json:"LOSSCounter"`
+	TIMEOUTCounter  int                      `json:"TIMEOUTCounter"`
+	CreatedOn       int                      `json:"CreatedOn"`
+	Stats           *BacktestStatsInput      `json:"Stats"`
+	EquityCurve     []*EquityPointInput      `json:"EquityCurve"`
+	Trades          []*BacktestTradeInput    `json:"Trades"`
+}
+
+type BacktestStats struct {
+	Trades       int     `json:"Trades"`
+	NetPnL       float64 `json:"NetPnL"`
+	WinRate      float64 `json:"WinRate"`
+	Cagr         float64 `json:"CAGR"`
+	MaxDrawdown  float64 `json:"MaxDrawdown"`
+	Sharpe       float64 `json:"Sharpe"`
+	Sortino      float64 `json:"Sortino"`
+	ProfitFactor float64 `json:"ProfitFactor"`
+	Expectancy   float64 `json:"Expectancy"`
+	AvgHoldTime  float64 `json:"AvgHoldTime"`
+	Exposure     float64 `json:"Exposure"`
+}
+
+type BacktestStatsInput struct {
+	Trades       int     `json:"Trades"`
+	NetPnL       float64 `json:"NetPnL"`
+	WinRate      float64 `json:"WinRate"`
+	Cagr         float64 `json:"CAGR"`
+	MaxDrawdown  float64 `json:"MaxDrawdown"`
+	Sharpe       float64 `json:"Sharpe"`
+	Sortino      float64 `json:"Sortino"`
+	ProfitFactor float64 `json:"ProfitFactor"`
+	Expectancy   float64 `json:"Expectancy"`
+	AvgHoldTime  float64 `json:"AvgHoldTime"`
+	Exposure     float64 `json:"Exposure"`
+}
+
+type BacktestTrade struct {
+	Symbol           string  `json:"Symbol"`
+	Outcome          string  `json:"Outcome"`
+	OpenTime         int     `json:"OpenTime"`
+	CloseTime        int     `json:"CloseTime"`
+	OpenPrice        float64 `json:"OpenPrice"`
+	ClosePrice       float64 `json:"ClosePrice"`
+	PercentageChange float64 `json:"PercentageChange"`
+	Fee              float64 `json:"Fee"`
+	Balance          float64 `json:"Balance"`
+}
+
+type BacktestTradeInput struct {
+	Symbol           string  `json:"Symbol"`
+	Outcome          string  `json:"Outcome"`
+	OpenTime         int     `json:"OpenTime"`
+	CloseTime        int     `json:"CloseTime"`
+	OpenPrice        float64 `json:"OpenPrice"`
+	ClosePrice       float64 `json:"ClosePrice"`
+	PercentageChange float64 `json:"PercentageChange"`
+	Fee              float64 `json:"Fee"`
+	Balance          float64 `json:"Balance"`
+}
+
+type BreadthBucket struct {
+	Label string   `json:"Label"`
+	Min   *float64 `json:"Min,omitempty"`
+	Max   *float64 `json:"Max,omitempty"`
+	Count int      `json:"Count"`
+}
+
+type BreadthBucketInput struct {
+	Label string   `json:"Label"`
+	Min   *float64 `json:"Min,omitempty"`
+	Max   *float64 `json:"Max,omitempty"`
+	Count int      `json:"Count"`
+}
+
+type CalendarFeed struct {
+	URL string `json:"url"`
+}
+
+type Comment struct {
+	Body     string   `json:"body"`
+	ReplyTo  *string  `json:"replyTo,omitempty"`
+	ThreadID string   `json:"threadId"`
+	Mentions []string `json:"mentions"`
 }
 
 type CreateProjectInput struct {
+	ID          *string   `json:"id,omitempty"`
 	Title       string    `json:"title"`
 	Sop         *bool     `json:"sop,omitempty"`
 	Description *string   `json:"description,omitempty"`
@@ -32,6 +207,7 @@ type CreateProjectInput struct {
 }
 
 type CreateTaskInput struct {
+	ID          *string   `json:"id,omitempty"`
 	Title       string    `json:"title"`
 	Description *string   `json:"description,omitempty"`
 	Status      *string   `json:"status,omitempty"`
@@ -42,6 +218,11 @@ type CreateTaskInput struct {
 	Department  *string   `json:"department,omitempty"`
 	ProjectID   *string   `json:"projectId,omitempty"`
 	Duration    *int      `json:"duration,omitempty"`
+	Estimate    *int      `json:"estimate,omitempty"`
+	ParentID    *string   `json:"parentId,omitempty"`
+	BlockedBy   []string  `json:"blockedBy,omitempty"`
+	Recurrence  *string   `json:"recurrence,omitempty"`
+	Imported    *bool     `json:"imported,omitempty"`
 }
 
 type CreateUserInput struct {
@@ -55,6 +236,27 @@ type CreateUserInput struct {
 	PreferredContactMethod *string `json:"preferredContactMethod,omitempty"`
 }
 
+type DecayedStats struct {
+	Score     float64 `json:"Score"`
+	AvgGain   float64 `json:"AvgGain"`
+	UpdatedAt int     `json:"UpdatedAt"`
+}
+
+type DepartmentAssigneeInput struct {
+	Department string `json:"department"`
+	AssignedTo string `json:"assignedTo"`
+}
+
+type EquityPoint struct {
+	Timestamp int     `json:"Timestamp"`
+	Balance   float64 `json:"Balance"`
+}
+
+type EquityPointInput struct {
+	Timestamp int     `json:"Timestamp"`
+	Balance   float64 `json:"Balance"`
+}
+
 type FearAndGreedIndex struct {
 	Timestamp           int       `json:"Timestamp"`
 	Value               string    `json:"Value"`
@@ -62,6 +264,12 @@ type FearAndGreedIndex struct {
 	CreatedAt           time.Time `json:"CreatedAt"`
 }
 
+type FieldChange struct {
+	Field string  `json:"Field"`
+	From  *string `json:"From,omitempty"`
+	To    *string `json:"To,omitempty"`
+}
+
 type HistoricKlineData struct {
 	Opentime int     `json:"opentime"`
 	Coins    []*Ohlc `json:"coins"`
@@ -79,6 +287,57 @@ type HistoricTickerStats struct {
 	CreatedAt time.Time      `json:"CreatedAt"`
 }
 
+type JobRun struct {
+	RunID      string     `json:"RunID"`
+	Job        string     `json:"Job"`
+	Slot       *int       `json:"Slot,omitempty"`
+	Trigger    JobTrigger `json:"Trigger"`
+	Status     JobStatus  `json:"Status"`
+	QueuedAt   int        `json:"QueuedAt"`
+	StartedAt  *int       `json:"StartedAt,omitempty"`
+	FinishedAt *int       `json:"FinishedAt,omitempty"`
+	DurationMs *int       `json:"DurationMs,omitempty"`
+	Error      *string    `json:"Error,omitempty"`
+}
+
+type JobRunInput struct {
+	Job     string     `json:"Job"`
+	Slot    *int       `json:"Slot,omitempty"`
+	Trigger JobTrigger `json:"Trigger"`
+	Status  JobStatus  `json:"Status"`
+	Error   *string    `json:"Error,omitempty"`
+}
+
+type JobRunResult struct {
+	Status     JobStatus `json:"Status"`
+	DurationMs int       `json:"DurationMs"`
+	Error      *string   `json:"Error,omitempty"`
+}
+
+type LeaderboardEntry struct {
+	Rank                int               `json:"Rank"`
+	BotInstanceName     string            `json:"BotInstanceName"`
+	Owner               *string           `json:"Owner,omitempty"`
+	Lifecycle           StrategyLifecycle `json:"Lifecycle"`
+	StartingBalance     float64           `json:"StartingBalance"`
+	AccountBalance      float64           `json:"AccountBalance"`
+	ReturnPercent       float64           `json:"ReturnPercent"`
+	RiskAdjustedReturn  float64           `json:"RiskAdjustedReturn"`
+	Trades              int               `json:"Trades"`
+	WinRate             float64           `json:"WinRate"`
+	MaxDrawdown         float64           `json:"MaxDrawdown"`
+	AgeDays             float64           `json:"AgeDays"`
+	RecentReturnPercent float64           `json:"RecentReturnPercent"`
+	RecentTrades        int               `json:"RecentTrades"`
+}
+
+type LifecycleTransition struct {
+	BotInstanceName string             `json:"BotInstanceName"`
+	From            *StrategyLifecycle `json:"From,omitempty"`
+	To              StrategyLifecycle  `json:"To"`
+	Timestamp       int                `json:"Timestamp"`
+}
+
 type LoginInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -94,6 +353,36 @@ type MarkAsTestedInput struct {
 	Tested          bool   `json:"Tested"`
 }
 
+type MarketBreadth struct {
+	Timestamp         int                  `json:"Timestamp"`
+	Pairs             int                  `json:"Pairs"`
+	Advancers         int                  `json:"Advancers"`
+	Decliners         int                  `json:"Decliners"`
+	Unchanged         int                  `json:"Unchanged"`
+	AdvancePercent    float64              `json:"AdvancePercent"`
+	MedianChange      float64              `json:"MedianChange"`
+	MeanChange        float64              `json:"MeanChange"`
+	OnTheMove         int                  `json:"OnTheMove"`
+	VolumeShareMoving *float64             `json:"VolumeShareMoving,omitempty"`
+	Buckets           []*BreadthBucket     `json:"Buckets"`
+	QuoteAssets       []*QuoteAssetBreadth `json:"QuoteAssets"`
+}
+
+type MarketBreadthInput struct {
+	Timestamp         int                       `json:"Timestamp"`
+	Pairs             int                       `json:"Pairs"`
+	Advancers         int                       `json:"Advancers"`
+	Decliners         int                       `json:"Decliners"`
+	Unchanged         int                       `json:"Unchanged"`
+	AdvancePercent    float64                   `json:"AdvancePercent"`
+	MedianChange      float64                   `json:"MedianChange"`
+	MeanChange        float64                   `json:"MeanChange"`
+	OnTheMove         int                       `json:"OnTheMove"`
+	VolumeShareMoving *float64                  `json:"VolumeShareMoving,omitempty"`
+	Buckets           []*BreadthBucketInput     `json:"Buckets"`
+	QuoteAssets       []*QuoteAssetBreadthInput `json:"QuoteAssets"`
+}
+
 type Mean struct {
 	Avg   float64 `json:"Avg"`
 	Count int     `json:"Count"`
@@ -115,6 +404,8 @@ type NewActivityReport struct {
 	TopBGain       *float64 `json:"TopBGain,omitempty"`
 	TopCGain       *float64 `json:"TopCGain,omitempty"`
 	FearGreedIndex int      `json:"FearGreedIndex"`
+	Breadth        *float64 `json:"Breadth,omitempty"`
+	MarketStatus   *string  `json:"MarketStatus,omitempty"`
 }
 
 type NewHistoricKlineDataInput struct {
@@ -132,6 +423,17 @@ type NewHistoricTickerStatsInput struct {
 	Stats     []*TickerStatsInput `json:"Stats"`
 }
 
+type NewTradeOpened struct {
+	Timestamp  int     `json:"Timestamp"`
+	BotName    string  `json:"BotName"`
+	Symbol     string  `json:"Symbol"`
+	EntryPrice float64 `json:"EntryPrice"`
+	TakeProfit float64 `json:"TakeProfit"`
+	StopLoss   float64 `json:"StopLoss"`
+	TimesOutAt int     `json:"TimesOutAt"`
+	VersionID  *string `json:"VersionID,omitempty"`
+}
+
 type NewTradeOutcomeReport struct {
 	Timestamp        int      `json:"Timestamp"`
 	BotName          string   `json:"BotName"`
@@ -144,6 +446,7 @@ type NewTradeOutcomeReport struct {
 	Volume           float64  `json:"Volume"`
 	FearGreedIndex   int      `json:"FearGreedIndex"`
 	MarketStatus     string   `json:"MarketStatus"`
+	VersionID        *string  `json:"VersionID,omitempty"`
 }
 
 type Ohlc struct {
@@ -164,6 +467,28 @@ type OHLCInput struct {
 	Symbol      string `json:"Symbol"`
 }
 
+type OutcomeStats struct {
+	Trades            int     `json:"Trades"`
+	Wins              int     `json:"Wins"`
+	Losses            int     `json:"Losses"`
+	WinRate           float64 `json:"WinRate"`
+	AvgGain           float64 `json:"AvgGain"`
+	AvgLoss           float64 `json:"AvgLoss"`
+	Expectancy        float64 `json:"Expectancy"`
+	ProfitFactor      float64 `json:"ProfitFactor"`
+	MaxDrawdown       float64 `json:"MaxDrawdown"`
+	LongestWinStreak  int     `json:"LongestWinStreak"`
+	LongestLossStreak int     `json:"LongestLossStreak"`
+	CurrentStreak     int     `json:"CurrentStreak"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
 type Pair struct {
 	Symbol           string  `json:"Symbol"`
 	Price            string  `json:"Price"`
@@ -176,95 +501,297 @@ type PairInput struct {
 	PercentageChange *string `json:"PercentageChange,omitempty"`
 }
 
+type PositionAppearance struct {
+	Timestamp int     `json:"Timestamp"`
+	Position  int     `json:"Position"`
+	Gain      float64 `json:"Gain"`
+}
+
 type Project struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Sop         bool      `json:"sop"`
-	Description *string   `json:"description,omitempty"`
-	Labels      []*string `json:"labels,omitempty"`
-	AssignedTo  *string   `json:"assignedTo,omitempty"`
-	DueDate     *string   `json:"dueDate,omitempty"`
-	Status      string    `json:"status"`
-	CreatedAt   string    `json:"createdAt"`
-	UpdatedAt   string    `json:"updatedAt"`
-	Tasks       []*Task   `json:"tasks,omitempty"`
+	ID          string           `json:"id"`
+	Title       string           `json:"title"`
+	Sop         bool             `json:"sop"`
+	Description *string          `json:"description,omitempty"`
+	Labels      []*string        `json:"labels,omitempty"`
+	AssignedTo  *string          `json:"assignedTo,omitempty"`
+	DueDate     *string          `json:"dueDate,omitempty"`
+	Status      string           `json:"status"`
+	CreatedAt   string           `json:"createdAt"`
+	UpdatedAt   string           `json:"updatedAt"`
+	Tasks       []*Task          `json:"tasks,omitempty"`
+	Activity    []*ActivityEntry `json:"activity"`
+}
+
+type ProjectConnection struct {
+	Edges      []*ProjectEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int            `json:"totalCount"`
+}
+
+type ProjectEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Project `json:"node"`
 }
 
 type ProjectFilterInput struct {
-	Sop *bool `json:"sop,omitempty"`
+	Sop        *bool    `json:"sop,omitempty"`
+	Status     []string `json:"status,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
+	AssignedTo *string  `json:"assignedTo,omitempty"`
+	Search     *string  `json:"search,omitempty"`
 }
 
 type Query struct {
 }
 
+type QuoteAssetBreadth struct {
+	QuoteAsset   string  `json:"QuoteAsset"`
+	Pairs        int     `json:"Pairs"`
+	Advancers    int     `json:"Advancers"`
+	Decliners    int     `json:"Decliners"`
+	MedianChange float64 `json:"MedianChange"`
+}
+
+type QuoteAssetBreadthInput struct {
+	QuoteAsset   string  `json:"QuoteAsset"`
+	Pairs        int     `json:"Pairs"`
+	Advancers    int     `json:"Advancers"`
+	Decliners    int     `json:"Decliners"`
+	MedianChange float64 `json:"MedianChange"`
+}
+
+type RecordTopMoversInput struct {
+	Timestamp int              `json:"Timestamp"`
+	Movers    []*TopMoverInput `json:"Movers"`
+}
+
 type Strategy struct {
+	BotInstanceName      string            `json:"BotInstanceName"`
+	TradeDuration        int               `json:"TradeDuration"`
+	IncrementsAtr        int               `json:"IncrementsATR"`
+	LongSMADuration      int               `json:"LongSMADuration"`
+	ShortSMADuration     int               `json:"ShortSMADuration"`
+	WINCounter           *int              `json:"WINCounter,omitempty"`
+	LOSSCounter          *int              `json:"LOSSCounter,omitempty"`
+	TIMEOUTGainCounter   *int              `json:"TIMEOUTGainCounter,omitempty"`
+	TIMEOUTLossCounter   *int              `json:"TIMEOUTLossCounter,omitempty"`
+	NetGainCounter       *int              `json:"NetGainCounter,omitempty"`
+	NetLossCounter       *int              `json:"NetLossCounter,omitempty"`
+	AccountBalance       float64           `json:"AccountBalance"`
+	StartingBalance      *float64          `json:"StartingBalance,omitempty"`
+	MovingAveMomentum    float64           `json:"MovingAveMomentum"`
+	TakeProfitPercentage *float64          `json:"TakeProfitPercentage,omitempty"`
+	StopLossPercentage   *float64          `json:"StopLossPercentage,omitempty"`
+	ATRtollerance        *float64          `json:"ATRtollerance,omitempty"`
+	MinFearGreed         *int              `json:"MinFearGreed,omitempty"`
+	MaxFearGreed         *int              `json:"MaxFearGreed,omitempty"`
+	AllowedSentiments    []string          `json:"AllowedSentiments,omitempty"`
+	FeesTotal            *float64          `json:"FeesTotal,omitempty"`
+	Tested               *bool             `json:"Tested,omitempty"`
+	Lifecycle            StrategyLifecycle `json:"Lifecycle"`
+	VersionID            *string           `json:"VersionID,omitempty"`
+	Version              *int              `json:"Version,omitempty"`
+	Owner                *string           `json:"Owner,omitempty"`
+	CreatedOn            int               `json:"CreatedOn"`
+}
+
+type StrategyAnalytics struct {
+	BotName     string              `json:"BotName"`
+	From        *int                `json:"From,omitempty"`
+	To          *int                `json:"To,omitempty"`
+	Overall     *OutcomeStats       `json:"Overall"`
+	BreakdownBy *AnalyticsBreakdown `json:"BreakdownBy,omitempty"`
+	Buckets     []*AnalyticsBucket  `json:"Buckets"`
+}
+
+type StrategyHistory struct {
+	BotInstanceName string                 `json:"BotInstanceName"`
+	Lifecycle       StrategyLifecycle      `json:"Lifecycle"`
+	Versions        []*StrategyVersion     `json:"Versions"`
+	Transitions     []*LifecycleTransition `json:"Transitions"`
+}
+
+type StrategyInput struct {
+	BotInstanceName      string             `json:"BotInstanceName"`
+	TradeDuration        int                `json:"TradeDuration"`
+	IncrementsAtr        int                `json:"IncrementsATR"`
+	LongSMADuration      int                `json:"LongSMADuration"`
+	ShortSMADuration     int                `json:"ShortSMADuration"`
+	WINCounter           *int               `json:"WINCounter,omitempty"`
+	LOSSCounter          *int               `json:"LOSSCounter,omitempty"`
+	TIMEOUTGainCounter   *int               `json:"TIMEOUTGainCounter,omitempty"`
+	TIMEOUTLossCounter   *int               `json:"TIMEOUTLossCounter,omitempty"`
+	NetGainCounter       *int               `json:"NetGainCounter,omitempty"`
+	NetLossCounter       *int               `json:"NetLossCounter,omitempty"`
+	AccountBalance       float64            `json:"AccountBalance"`
+	MovingAveMomentum    float64            `json:"MovingAveMomentum"`
+	TakeProfitPercentage float64            `json:"TakeProfitPercentage"`
+	StopLossPercentage   float64            `json:"StopLossPercentage"`
+	ATRtollerance        *float64           `json:"ATRtollerance,omitempty"`
+	MinFearGreed         *int               `json:"MinFearGreed,omitempty"`
+	MaxFearGreed         *int               `json:"MaxFearGreed,omitempty"`
+	AllowedSentiments    []string           `json:"AllowedSentiments,omitempty"`
+	FeesTotal            *float64           `json:"FeesTotal,omitempty"`
+	Tested               *bool              `json:"Tested,omitempty"`
+	Lifecycle            *StrategyLifecycle `json:"Lifecycle,omitempty"`
+	VersionID            *string            `json:"VersionID,omitempty"`
+	Owner                string             `json:"Owner"`
+	CreatedOn            int                `json:"CreatedOn"`
+}
+
+type StrategySweep struct {
+	SweepID         string         `json:"SweepID"`
+	Sampling        string         `json:"Sampling"`
+	RankedBy        string         `json:"RankedBy"`
+	From            int            `json:"From"`
+	To              int            `json:"To"`
+	Permutations    int            `json:"Permutations"`
+	StartingBalance float64        `json:"StartingBalance"`
+	CreatedOn       int            `json:"CreatedOn"`
+	Results         []*SweepResult `json:"Results"`
+}
+
+type StrategySweepInput struct {
+	Sampling        string              `json:"Sampling"`
+	RankedBy        string              `json:"RankedBy"`
+	From            int                 `json:"From"`
+	To              int                 `json:"To"`
+	Permutations    int                 `json:"Permutations"`
+	StartingBalance float64             `json:"StartingBalance"`
+	CreatedOn       int                 `json:"CreatedOn"`
+	Results         []*SweepResultInput `json:"Results"`
+}
+
+type StrategyVersion struct {
+	VersionID            string         `json:"VersionID"`
+	BotInstanceName      string         `json:"BotInstanceName"`
+	Version              int            `json:"Version"`
+	TradeDuration        int            `json:"TradeDuration"`
+	IncrementsAtr        int            `json:"IncrementsATR"`
+	LongSMADuration      int            `json:"LongSMADuration"`
+	ShortSMADuration     int            `json:"ShortSMADuration"`
+	MovingAveMomentum    float64        `json:"MovingAveMomentum"`
+	TakeProfitPercentage *float64       `json:"TakeProfitPercentage,omitempty"`
+	StopLossPercentage   *float64       `json:"StopLossPercentage,omitempty"`
+	ATRtollerance        *float64       `json:"ATRtollerance,omitempty"`
+	MinFearGreed         *int           `json:"MinFearGreed,omitempty"`
+	MaxFearGreed         *int           `json:"MaxFearGreed,omitempty"`
+	AllowedSentiments    []string       `json:"AllowedSentiments,omitempty"`
+	CreatedOn            int            `json:"CreatedOn"`
+	Changes              []*FieldChange `json:"Changes"`
+}
+
+type Subscription struct {
+}
+
+type SweepResult struct {
+	Rank                 int      `json:"Rank"`
 	BotInstanceName      string   `json:"BotInstanceName"`
 	TradeDuration        int      `json:"TradeDuration"`
 	IncrementsAtr        int      `json:"IncrementsATR"`
 	LongSMADuration      int      `json:"LongSMADuration"`
 	ShortSMADuration     int      `json:"ShortSMADuration"`
-	WINCounter           *int     `json:"WINCounter,omitempty"`
-	LOSSCounter          *int     `json:"LOSSCounter,omitempty"`
-	TIMEOUTGainCounter   *int     `json:"TIMEOUTGainCounter,omitempty"`
-	TIMEOUTLossCounter   *int     `json:"TIMEOUTLossCounter,omitempty"`
-	NetGainCounter       *int     `json:"NetGainCounter,omitempty"`
-	NetLossCounter       *int     `json:"NetLossCounter,omitempty"`
-	AccountBalance       float64  `json:"AccountBalance"`
 	MovingAveMomentum    float64  `json:"MovingAveMomentum"`
-	TakeProfitPercentage *float64 `json:"TakeProfitPercentage,omitempty"`
-	StopLossPercentage   *float64 `json:"StopLossPercentage,omitempty"`
+	TakeProfitPercentage float64  `json:"TakeProfitPercentage"`
+	StopLossPercentage   float64  `json:"StopLossPercentage"`
 	ATRtollerance        *float64 `json:"ATRtollerance,omitempty"`
-	FeesTotal            *float64 `json:"FeesTotal,omitempty"`
-	Tested               *bool    `json:"Tested,omitempty"`
-	Owner                *string  `json:"Owner,omitempty"`
-	CreatedOn            int      `json:"CreatedOn"`
+	Trades               int      `json:"Trades"`
+	NetPnL               float64  `json:"NetPnL"`
+	WinRate              float64  `json:"WinRate"`
+	MaxDrawdown          float64  `json:"MaxDrawdown"`
+	Sharpe               float64  `json:"Sharpe"`
 }
 
-type StrategyInput struct {
+type SweepResultInput struct {
+	Rank                 int      `json:"Rank"`
 	BotInstanceName      string   `json:"BotInstanceName"`
 	TradeDuration        int      `json:"TradeDuration"`
 	IncrementsAtr        int      `json:"IncrementsATR"`
 	LongSMADuration      int      `json:"LongSMADuration"`
 	ShortSMADuration     int      `json:"ShortSMADuration"`
-	WINCounter           *int     `json:"WINCounter,omitempty"`
-	LOSSCounter          *int     `json:"LOSSCounter,omitempty"`
-	TIMEOUTGainCounter   *int     `json:"TIMEOUTGainCounter,omitempty"`
-	TIMEOUTLossCounter   *int     `json:"TIMEOUTLossCounter,omitempty"`
-	NetGainCounter       *int     `json:"NetGainCounter,omitempty"`
-	NetLossCounter       *int     `json:"NetLossCounter,omitempty"`
-	AccountBalance       float64  `json:"AccountBalance"`
 	MovingAveMomentum    float64  `json:"MovingAveMomentum"`
 	TakeProfitPercentage float64  `json:"TakeProfitPercentage"`
 	StopLossPercentage   float64  `json:"StopLossPercentage"`
 	ATRtollerance        *float64 `json:"ATRtollerance,omitempty"`
-	FeesTotal            *float64 `json:"FeesTotal,omitempty"`
-	Tested               *bool    `json:"Tested,omitempty"`
-	Owner                string   `json:"Owner"`
-	CreatedOn            int      `json:"CreatedOn"`
+	Trades               int      `json:"Trades"`
+	NetPnL               float64  `json:"NetPnL"`
+	WinRate              float64  `json:"WinRate"`
+	MaxDrawdown          float64  `json:"MaxDrawdown"`
+	Sharpe               float64  `json:"Sharpe"`
 }
 
 type SymbolStats struct {
-	Symbol               string   `json:"Symbol"`
-	PositionCounts       []*Mean  `json:"PositionCounts"`
-	LiquidityEstimate    *Mean    `json:"LiquidityEstimate,omitempty"`
-	MaxLiquidityEstimate *float64 `json:"MaxLiquidityEstimate,omitempty"`
-	MinLiquidityEstimate *float64 `json:"MinLiquidityEstimate,omitempty"`
+	Symbol               string                `json:"Symbol"`
+	PositionCounts       []*Mean               `json:"PositionCounts"`
+	Decayed              *DecayedStats         `json:"Decayed,omitempty"`
+	Windows              []*WindowStats        `json:"Windows"`
+	History              []*PositionAppearance `json:"History,omitempty"`
+	LiquidityEstimate    *Mean                 `json:"LiquidityEstimate,omitempty"`
+	MaxLiquidityEstimate *float64              `json:"MaxLiquidityEstimate,omitempty"`
+	MinLiquidityEstimate *float64              `json:"MinLiquidityEstimate,omitempty"`
 }
 
 type Task struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description *string   `json:"description,omitempty"`
-	Status      string    `json:"status"`
-	Labels      []*string `json:"labels,omitempty"`
-	AssignedTo  *string   `json:"assignedTo,omitempty"`
-	DueDate     *string   `json:"dueDate,omitempty"`
-	DeferDate   *string   `json:"deferDate,omitempty"`
-	Department  *string   `json:"department,omitempty"`
-	ProjectID   *string   `json:"projectId,omitempty"`
-	Duration    *int      `json:"duration,omitempty"`
-	CreatedAt   string    `json:"createdAt"`
-	UpdatedAt   string    `json:"updatedAt"`
+	ID               string           `json:"id"`
+	Title            string           `json:"title"`
+	Description      *string          `json:"description,omitempty"`
+	Status           string           `json:"status"`
+	Labels           []*string        `json:"labels,omitempty"`
+	AssignedTo       *string          `json:"assignedTo,omitempty"`
+	DueDate          *string          `json:"dueDate,omitempty"`
+	DeferDate        *string          `json:"deferDate,omitempty"`
+	Department       *string          `json:"department,omitempty"`
+	ProjectID        *string          `json:"projectId,omitempty"`
+	Duration         *int             `json:"duration,omitempty"`
+	Estimate         *int             `json:"estimate,omitempty"`
+	ParentID         *string          `json:"parentId,omitempty"`
+	BlockedBy        []string         `json:"blockedBy,omitempty"`
+	Recurrence       *string          `json:"recurrence,omitempty"`
+	NextOccurrenceID *string          `json:"nextOccurrenceId,omitempty"`
+	CreatedAt        string           `json:"createdAt"`
+	UpdatedAt        string           `json:"updatedAt"`
+	Subtasks         []*Task          `json:"subtasks"`
+	Blockers         []*Task          `json:"blockers"`
+	Activity         []*ActivityEntry `json:"activity"`
+}
+
+type TaskConnection struct {
+	Edges      []*TaskEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount int         `json:"totalCount"`
+}
+
+type TaskEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Task  `json:"node"`
+}
+
+type TaskFilterInput struct {
+	Status     []string `json:"status,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
+	AssignedTo *string  `json:"assignedTo,omitempty"`
+	Department *string  `json:"department,omitempty"`
+	ProjectID  *string  `json:"projectId,omitempty"`
+	DueFrom    *string  `json:"dueFrom,omitempty"`
+	DueTo      *string  `json:"dueTo,omitempty"`
+	Search     *string  `json:"search,omitempty"`
+}
+
+type TaskGraph struct {
+	Root  *Task       `json:"root"`
+	Tasks []*Task     `json:"tasks"`
+	Links []*TaskLink `json:"links"`
+}
+
+type TaskLink struct {
+	From string       `json:"from"`
+	To   string       `json:"to"`
+	Kind TaskLinkKind `json:"kind"`
+}
+
+type TaskStatusTransitions struct {
+	Status  string   `json:"status"`
+	Allowed []string `json:"allowed"`
 }
 
 type TickerStats struct {
@@ -293,6 +820,58 @@ type TickerStatsInput struct {
 	LiquidityEstimate *string `json:"LiquidityEstimate,omitempty"`
 }
 
+type TimeEntry struct {
+	ID              string  `json:"id"`
+	TaskID          string  `json:"taskId"`
+	UserID          string  `json:"userId"`
+	StartedAt       string  `json:"startedAt"`
+	StoppedAt       *string `json:"stoppedAt,omitempty"`
+	DurationSeconds *int    `json:"durationSeconds,omitempty"`
+}
+
+type TimeReport struct {
+	From         string       `json:"from"`
+	To           string       `json:"to"`
+	TotalMinutes int          `json:"totalMinutes"`
+	ByDepartment []*TimeTotal `json:"byDepartment"`
+	ByAssignee   []*TimeTotal `json:"byAssignee"`
+	ByUser       []*TimeTotal `json:"byUser"`
+	ByProject    []*TimeTotal `json:"byProject"`
+}
+
+type TimeTotal struct {
+	Key     *string `json:"key,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	Minutes int     `json:"minutes"`
+}
+
+type TopMover struct {
+	Rank        int     `json:"Rank"`
+	Symbol      string  `json:"Symbol"`
+	Score       float64 `json:"Score"`
+	Appearances int     `json:"Appearances"`
+	AvgPosition float64 `json:"AvgPosition"`
+	AvgGain     float64 `json:"AvgGain"`
+}
+
+type TopMoverInput struct {
+	Symbol   string  `json:"Symbol"`
+	Position int     `json:"Position"`
+	Gain     float64 `json:"Gain"`
+}
+
+type TradeOpened struct {
+	ID         string  `json:"_id"`
+	Timestamp  int     `json:"Timestamp"`
+	BotName    string  `json:"BotName"`
+	Symbol     string  `json:"Symbol"`
+	EntryPrice float64 `json:"EntryPrice"`
+	TakeProfit float64 `json:"TakeProfit"`
+	StopLoss   float64 `json:"StopLoss"`
+	TimesOutAt int     `json:"TimesOutAt"`
+	VersionID  *string `json:"VersionID,omitempty"`
+}
+
 type TradeOutcomeReport struct {
 	ID               string   `json:"_id"`
 	Timestamp        int      `json:"Timestamp"`
@@ -306,6 +885,7 @@ type TradeOutcomeReport struct {
 	Volume           float64  `json:"Volume"`
 	FearGreedIndex   int      `json:"FearGreedIndex"`
 	MarketStatus     string   `json:"MarketStatus"`
+	VersionID        *string  `json:"VersionID,omitempty"`
 }
 
 type UpdateCountersInput struct {
@@ -320,6 +900,11 @@ type UpdateCountersInput struct {
 	FeesTotal          *float64 `json:"FeesTotal,omitempty"`
 }
 
+type UpdateLifecycleInput struct {
+	BotInstanceName string            `json:"BotInstanceName"`
+	Lifecycle       StrategyLifecycle `json:"Lifecycle"`
+}
+
 type UpdateProjectInput struct {
 	ID          string    `json:"id"`
 	Title       *string   `json:"title,omitempty"`
@@ -343,6 +928,11 @@ type UpdateTaskInput struct {
 	Department  *string   `json:"department,omitempty"`
 	ProjectID   *string   `json:"projectId,omitempty"`
 	Duration    *int      `json:"duration,omitempty"`
+	Estimate    *int      `json:"estimate,omitempty"`
+	ParentID    *string   `json:"parentId,omitempty"`
+	BlockedBy   []string  `json:"blockedBy,omitempty"`
+	Recurrence  *string   `json:"recurrence,omitempty"`
+	Imported    *bool     `json:"imported,omitempty"`
 }
 
 type UpdateUserInput struct {
@@ -401,48 +991,68 @@ type User struct {
 	UpdatedAt              time.Time `json:"updatedAt"`
 }
 
-type ContactMethod string
+type WeeklyReview struct {
+	GeneratedAt               string     `json:"generatedAt"`
+	StaleWaitingFor           []*Task    `json:"staleWaitingFor"`
+	Overdue                   []*Task    `json:"overdue"`
+	ProjectsWithoutNextAction []*Project `json:"projectsWithoutNextAction"`
+}
+
+type WindowStats struct {
+	Window         MoverWindow `json:"Window"`
+	Appearances    int         `json:"Appearances"`
+	Score          float64     `json:"Score"`
+	AvgPosition    float64     `json:"AvgPosition"`
+	AvgGain        float64     `json:"AvgGain"`
+	PositionCounts []int       `json:"PositionCounts"`
+}
+
+type ActivityKind string
 
 const (
-	ContactMethodEmail    ContactMethod = "EMAIL"
-	ContactMethodWhatsapp ContactMethod = "WHATSAPP"
+	ActivityKindCreated   ActivityKind = "CREATED"
+	ActivityKindUpdated   ActivityKind = "UPDATED"
+	ActivityKindDeleted   ActivityKind = "DELETED"
+	ActivityKindCommented ActivityKind = "COMMENTED"
 )
 
-var AllContactMethod = []ContactMethod{
-	ContactMethodEmail,
-	ContactMethodWhatsapp,
+var AllActivityKind = []ActivityKind{
+	ActivityKindCreated,
+	ActivityKindUpdated,
+	ActivityKindDeleted,
+	ActivityKindCommented,
 }
 
-func (e ContactMethod) IsValid() bool {
+func (e ActivityKind) IsValid() bool {
 	switch e {
-	case ContactMethodEmail, ContactMethodWhatsapp:
+	case ActivityKindCreated, ActivityKindUpdated, ActivityKindDeleted, ActivityKindCommented:
 		return true
 	}
 	return false
 }
 
-func (e ContactMethod) String() string {
+func (e ActivityKind) String() string {
 	return string(e)
 }
 
-func (e *ContactMethod) UnmarshalGQL(v any) error {
+func (e *ActivityKind) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = ContactMethod(str)
+	*e = ActivityKind(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid ContactMethod", str)
+		return fmt.Errorf("%s is not a valid ActivityKind", str)
 	}
 	return nil
 }
 
-func (e ContactMethod) MarshalGQL(w io.Writer) {
+func (e ActivityKind) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *ContactMethod) UnmarshalJSON(b []byte) error {
+func (e *ActivityKind) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -450,7 +1060,707 @@ func (e *ContactMethod) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e ContactMethod) MarshalJSON() ([]byte, error) {
+func (e ActivityKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ActivityTarget string
+
+const (
+	ActivityTargetTask    ActivityTarget = "TASK"
+	ActivityTargetProject ActivityTarget = "PROJECT"
+)
+
+var AllActivityTarget = []ActivityTarget{
+	ActivityTargetTask,
+	ActivityTargetProject,
+}
+
+func (e ActivityTarget) IsValid() bool {
+	switch e {
+	case ActivityTargetTask, ActivityTargetProject:
+		return true
+	}
+	return false
+}
+
+func (e ActivityTarget) String() string {
+	return string(e)
+}
+
+func (e *ActivityTarget) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ActivityTarget(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ActivityTarget", str)
+	}
+	return nil
+}
+
+func (e ActivityTarget) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ActivityTarget) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ActivityTarget) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type AnalyticsBreakdown string
+
+const (
+	AnalyticsBreakdownSymbol       AnalyticsBreakdown = "SYMBOL"
+	AnalyticsBreakdownHour         AnalyticsBreakdown = "HOUR"
+	AnalyticsBreakdownWeekday      AnalyticsBreakdown = "WEEKDAY"
+	AnalyticsBreakdownMarketStatus AnalyticsBreakdown = "MARKET_STATUS"
+	AnalyticsBreakdownFearGreed    AnalyticsBreakdown = "FEAR_GREED"
+)
+
+var AllAnalyticsBreakdown = []AnalyticsBreakdown{
+	AnalyticsBreakdownSymbol,
+	AnalyticsBreakdownHour,
+	AnalyticsBreakdownWeekday,
+	AnalyticsBreakdownMarketStatus,
+	AnalyticsBreakdownFearGreed,
+}
+
+func (e AnalyticsBreakdown) IsValid() bool {
+	switch e {
+	case AnalyticsBreakdownSymbol, AnalyticsBreakdownHour, AnalyticsBreakdownWeekday, AnalyticsBreakdownMarketStatus, AnalyticsBreakdownFearGreed:
+		return true
+	}
+	return false
+}
+
+func (e AnalyticsBreakdown) String() string {
+	return string(e)
+}
+
+func (e *AnalyticsBreakdown) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AnalyticsBreakdown(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AnalyticsBreakdown", str)
+	}
+	return nil
+}
+
+func (e AnalyticsBreakdown) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *AnalyticsBreakdown) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e AnalyticsBreakdown) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ContactMethod string
+
+const (
+	ContactMethodEmail    ContactMethod = "EMAIL"
+	ContactMethodWhatsapp ContactMethod = "WHATSAPP"
+)
+
+var AllContactMethod = []ContactMethod{
+	ContactMethodEmail,
+	ContactMethodWhatsapp,
+}
+
+func (e ContactMethod) IsValid() bool {
+	switch e {
+	case ContactMethodEmail, ContactMethodWhatsapp:
+		return true
+	}
+	return false
+}
+
+func (e ContactMethod) String() string {
+	return string(e)
+}
+
+func (e *ContactMethod) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ContactMethod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ContactMethod", str)
+	}
+	return nil
+}
+
+func (e ContactMethod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ContactMethod) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ContactMethod) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "QUEUED"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusSucceeded JobStatus = "SUCCEEDED"
+	JobStatusFailed    JobStatus = "FAILED"
+	JobStatusSkipped   JobStatus = "SKIPPED"
+)
+
+var AllJobStatus = []JobStatus{
+	JobStatusQueued,
+	JobStatusRunning,
+	JobStatusSucceeded,
+	JobStatusFailed,
+	JobStatusSkipped,
+}
+
+func (e JobStatus) IsValid() bool {
+	switch e {
+	case JobStatusQueued, JobStatusRunning, JobStatusSucceeded, JobStatusFailed, JobStatusSkipped:
+		return true
+	}
+	return false
+}
+
+func (e JobStatus) String() string {
+	return string(e)
+}
+
+func (e *JobStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = JobStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid JobStatus", str)
+	}
+	return nil
+}
+
+func (e JobStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *JobStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e JobStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "SCHEDULE"
+	JobTriggerCatchUp  JobTrigger = "CATCH_UP"
+	JobTriggerManual   JobTrigger = "MANUAL"
+)
+
+var AllJobTrigger = []JobTrigger{
+	JobTriggerSchedule,
+	JobTriggerCatchUp,
+	JobTriggerManual,
+}
+
+func (e JobTrigger) IsValid() bool {
+	switch e {
+	case JobTriggerSchedule, JobTriggerCatchUp, JobTriggerManual:
+		return true
+	}
+	return false
+}
+
+func (e JobTrigger) String() string {
+	return string(e)
+}
+
+func (e *JobTrigger) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = JobTrigger(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid JobTrigger", str)
+	}
+	return nil
+}
+
+func (e JobTrigger) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *JobTrigger) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e JobTrigger) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type LeaderboardSort string
+
+const (
+	LeaderboardSortReturn       LeaderboardSort = "RETURN"
+	LeaderboardSortRiskAdjusted LeaderboardSort = "RISK_ADJUSTED"
+	LeaderboardSortTrades       LeaderboardSort = "TRADES"
+	LeaderboardSortRecent       LeaderboardSort = "RECENT"
+)
+
+var AllLeaderboardSort = []LeaderboardSort{
+	LeaderboardSortReturn,
+	LeaderboardSortRiskAdjusted,
+	LeaderboardSortTrades,
+	LeaderboardSortRecent,
+}
+
+func (e LeaderboardSort) IsValid() bool {
+	switch e {
+	case LeaderboardSortReturn, LeaderboardSortRiskAdjusted, LeaderboardSortTrades, LeaderboardSortRecent:
+		return true
+	}
+	return false
+}
+
+func (e LeaderboardSort) String() string {
+	return string(e)
+}
+
+func (e *LeaderboardSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LeaderboardSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LeaderboardSort", str)
+	}
+	return nil
+}
+
+func (e LeaderboardSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *LeaderboardSort) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e LeaderboardSort) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type MoverWindow string
+
+const (
+	MoverWindowDay     MoverWindow = "DAY"
+	MoverWindowWeek    MoverWindow = "WEEK"
+	MoverWindowMonth   MoverWindow = "MONTH"
+	MoverWindowDecayed MoverWindow = "DECAYED"
+)
+
+var AllMoverWindow = []MoverWindow{
+	MoverWindowDay,
+	MoverWindowWeek,
+	MoverWindowMonth,
+	MoverWindowDecayed,
+}
+
+func (e MoverWindow) IsValid() bool {
+	switch e {
+	case MoverWindowDay, MoverWindowWeek, MoverWindowMonth, MoverWindowDecayed:
+		return true
+	}
+	return false
+}
+
+func (e MoverWindow) String() string {
+	return string(e)
+}
+
+func (e *MoverWindow) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MoverWindow(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MoverWindow", str)
+	}
+	return nil
+}
+
+func (e MoverWindow) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MoverWindow) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MoverWindow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ProjectSort string
+
+const (
+	ProjectSortCreatedAt ProjectSort = "CREATED_AT"
+	ProjectSortUpdatedAt ProjectSort = "UPDATED_AT"
+	ProjectSortDueDate   ProjectSort = "DUE_DATE"
+	ProjectSortTitle     ProjectSort = "TITLE"
+)
+
+var AllProjectSort = []ProjectSort{
+	ProjectSortCreatedAt,
+	ProjectSortUpdatedAt,
+	ProjectSortDueDate,
+	ProjectSortTitle,
+}
+
+func (e ProjectSort) IsValid() bool {
+	switch e {
+	case ProjectSortCreatedAt, ProjectSortUpdatedAt, ProjectSortDueDate, ProjectSortTitle:
+		return true
+	}
+	return false
+}
+
+func (e ProjectSort) String() string {
+	return string(e)
+}
+
+func (e *ProjectSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProjectSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProjectSort", str)
+	}
+	return nil
+}
+
+func (e ProjectSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProjectSort) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProjectSort) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SortDirection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SortDirection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type StrategyLifecycle string
+
+const (
+	StrategyLifecycleDraft       StrategyLifecycle = "DRAFT"
+	StrategyLifecycleBacktesting StrategyLifecycle = "BACKTESTING"
+	StrategyLifecycleValidated   StrategyLifecycle = "VALIDATED"
+	StrategyLifecyclePaper       StrategyLifecycle = "PAPER"
+	StrategyLifecycleLive        StrategyLifecycle = "LIVE"
+	StrategyLifecyclePaused      StrategyLifecycle = "PAUSED"
+	StrategyLifecycleRetired     StrategyLifecycle = "RETIRED"
+)
+
+var AllStrategyLifecycle = []StrategyLifecycle{
+	StrategyLifecycleDraft,
+	StrategyLifecycleBacktesting,
+	StrategyLifecycleValidated,
+	StrategyLifecyclePaper,
+	StrategyLifecycleLive,
+	StrategyLifecyclePaused,
+	StrategyLifecycleRetired,
+}
+
+func (e StrategyLifecycle) IsValid() bool {
+	switch e {
+	case StrategyLifecycleDraft, StrategyLifecycleBacktesting, StrategyLifecycleValidated, StrategyLifecyclePaper, StrategyLifecycleLive, StrategyLifecyclePaused, StrategyLifecycleRetired:
+		return true
+	}
+	return false
+}
+
+func (e StrategyLifecycle) String() string {
+	return string(e)
+}
+
+func (e *StrategyLifecycle) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StrategyLifecycle(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StrategyLifecycle", str)
+	}
+	return nil
+}
+
+func (e StrategyLifecycle) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *StrategyLifecycle) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e StrategyLifecycle) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type TaskLinkKind string
+
+const (
+	TaskLinkKindSubtask TaskLinkKind = "SUBTASK"
+	TaskLinkKindBlocks  TaskLinkKind = "BLOCKS"
+)
+
+var AllTaskLinkKind = []TaskLinkKind{
+	TaskLinkKindSubtask,
+	TaskLinkKindBlocks,
+}
+
+func (e TaskLinkKind) IsValid() bool {
+	switch e {
+	case TaskLinkKindSubtask, TaskLinkKindBlocks:
+		return true
+	}
+	return false
+}
+
+func (e TaskLinkKind) String() string {
+	return string(e)
+}
+
+func (e *TaskLinkKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskLinkKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskLinkKind", str)
+	}
+	return nil
+}
+
+func (e TaskLinkKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TaskLinkKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TaskLinkKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type TaskSort string
+
+const (
+	TaskSortCreatedAt TaskSort = "CREATED_AT"
+	TaskSortUpdatedAt TaskSort = "UPDATED_AT"
+	TaskSortDueDate   TaskSort = "DUE_DATE"
+	TaskSortTitle     TaskSort = "TITLE"
+)
+
+var AllTaskSort = []TaskSort{
+	TaskSortCreatedAt,
+	TaskSortUpdatedAt,
+	TaskSortDueDate,
+	TaskSortTitle,
+}
+
+func (e TaskSort) IsValid() bool {
+	switch e {
+	case TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortDueDate, TaskSortTitle:
+		return true
+	}
+	return false
+}
+
+func (e TaskSort) String() string {
+	return string(e)
+}
+
+func (e *TaskSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskSort", str)
+	}
+	return nil
+}
+
+func (e TaskSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TaskSort) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TaskSort) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
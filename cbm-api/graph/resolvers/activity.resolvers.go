@@ -0,0 +1,40 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/auth"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+)
+
+// AddComment is the resolver for the addComment field.
+func (r *mutationResolver) AddComment(ctx context.Context, input model.AddCommentInput) (*model.ActivityEntry, error) {
+	user, err := auth.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := db.AddComment(ctx, input, user.ID, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("Error adding comment:")
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// ReadMentions is the resolver for the readMentions field.
+func (r *queryResolver) ReadMentions(ctx context.Context) ([]*model.ActivityEntry, error) {
+	user, err := auth.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ReadMentions(ctx, user.ID)
+}
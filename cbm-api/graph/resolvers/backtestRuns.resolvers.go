@@ -0,0 +1,36 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+// CreateBacktestRun is the resolver for the createBacktestRun field.
+func (r *mutationResolver) CreateBacktestRun(ctx context.Context, input model.BacktestRunInput) (*model.BacktestRun, error) {
+	return db.CreateBacktestRun(ctx, input)
+}
+
+// DeleteBacktestRun is the resolver for the deleteBacktestRun field.
+func (r *mutationResolver) DeleteBacktestRun(ctx context.Context, runID string) (bool, error) {
+	return db.DeleteBacktestRun(ctx, runID)
+}
+
+// ReadBacktestRun is the resolver for the readBacktestRun field.
+func (r *queryResolver) ReadBacktestRun(ctx context.Context, runID string) (*model.BacktestRun, error) {
+	return db.ReadBacktestRun(ctx, runID)
+}
+
+// ReadBacktestRuns is the resolver for the readBacktestRuns field.
+func (r *queryResolver) ReadBacktestRuns(ctx context.Context, botInstanceName *string, datasetID *string, limit *int) ([]*model.BacktestRun, error) {
+	return db.ReadBacktestRuns(ctx, botInstanceName, datasetID, limit)
+}
+
+// CompareBacktestRuns is the resolver for the compareBacktestRuns field.
+func (r *queryResolver) CompareBacktestRuns(ctx context.Context, runIDs []string) ([]*model.BacktestRun, error) {
+	return db.CompareBacktestRuns(ctx, runIDs)
+}
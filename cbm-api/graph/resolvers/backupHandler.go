@@ -0,0 +1,113 @@
+package resolvers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/auth"
+	"cryptobotmanager.com/cbm-backend/cbm-api/backup"
+	"github.com/rs/zerolog/log"
+)
+
+// BackupHandler serves GET /backup, an archive of the database for admins.
+// The include and exclude parameters pick collections by name, as comma
+// separated lists.
+func BackupHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !requireAdmin(w, r) {
+			return
+		}
+
+		filter := backupFilter(r)
+		filename := fmt.Sprintf("backup-%s.zip", time.Now().UTC().Format("20060102T150405Z"))
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		// The archive streams out as it is written, so a failure part way can
+		// only cut it short; its missing manifest makes a restore refuse it.
+		if _, err := db.Backup(r.Context(), w, filter); err != nil {
+			log.Error().Err(err).Msg("Error streaming backup:")
+		}
+	})
+}
+
+// RestoreHandler serves POST /restore for admins, replacing the collections in
+// the archive posted, or those of them the include and exclude parameters
+// pick, and answering with the collections restored.
+func RestoreHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !requireAdmin(w, r) {
+			return
+		}
+
+		// An archive is read out of order, so keep the upload in a file first.
+		spool, err := os.CreateTemp("", "restore-*.zip")
+		if err != nil {
+			log.Error().Err(err).Msg("Error creating restore spool file:")
+			http.Error(w, "could not receive the archive", http.StatusInternalServerError)
+			return
+		}
+		defer os.Remove(spool.Name())
+		defer spool.Close()
+
+		size, err := io.Copy(spool, r.Body)
+		if err != nil {
+			log.Error().Err(err).Msg("Error receiving restore archive:")
+			http.Error(w, "could not receive the archive", http.StatusBadRequest)
+			return
+		}
+
+		archive, err := backup.Open(spool, size)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		restored, err := db.Restore(r.Context(), archive, backupFilter(r))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(restored); err != nil {
+			log.Error().Err(err).Msg("Error writing restore response:")
+		}
+	})
+}
+
+// requireAdmin answers requests not from an admin, reporting whether the
+// request may go on.
+func requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	_, err := auth.RequireRole(r.Context(), auth.RoleAdmin)
+	var authErr *auth.Error
+	if errors.As(err, &authErr) && authErr.Code == auth.CodeUnauthenticated {
+		http.Error(w, authErr.Message, http.StatusUnauthorized)
+		return false
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func backupFilter(r *http.Request) backup.Filter {
+	query := r.URL.Query()
+	return backup.Filter{Include: backup.ParseList(query.Get("include")), Exclude: backup.ParseList(query.Get("exclude"))}
+}
@@ -73,6 +73,17 @@ func (r *mutationResolver) UpdateMarkAsTested(ctx context.Context, input model.M
 	return &success, nil
 }
 
+// UpdateStrategyLifecycle is the resolver for the updateStrategyLifecycle field.
+func (r *mutationResolver) UpdateStrategyLifecycle(ctx context.Context, input model.UpdateLifecycleInput) (*model.Strategy, error) {
+	strategy, err := db.UpdateStrategyLifecycle(ctx, input.BotInstanceName, input.Lifecycle)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to update strategy lifecycle.")
+		return nil, err
+	}
+
+	return strategy, nil
+}
+
 // ReadStrategyByName is the resolver for the readStrategyByName field.
 func (r *queryResolver) ReadStrategyByName(ctx context.Context, botInstanceName string) (*model.Strategy, error) {
 	// Assuming db is an instance of your DB type
@@ -96,3 +107,13 @@ func (r *queryResolver) ReadAllStrategies(ctx context.Context) ([]*model.Strateg
 
 	return strategies, nil
 }
+
+// ReadStrategyVersion is the resolver for the readStrategyVersion field.
+func (r *queryResolver) ReadStrategyVersion(ctx context.Context, versionID string) (*model.StrategyVersion, error) {
+	return db.ReadStrategyVersion(ctx, versionID)
+}
+
+// ReadStrategyHistory is the resolver for the readStrategyHistory field.
+func (r *queryResolver) ReadStrategyHistory(ctx context.Context, botInstanceName string) (*model.StrategyHistory, error) {
+	return db.ReadStrategyHistory(ctx, botInstanceName)
+}
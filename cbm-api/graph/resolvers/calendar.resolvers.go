@@ -0,0 +1,46 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/auth"
+	"cryptobotmanager.com/cbm-backend/cbm-api/calendar"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+)
+
+// CalendarFeed is the resolver for the calendarFeed field.
+func (r *mutationResolver) CalendarFeed(ctx context.Context, labels []string, departments []string, tasksAsEvents *bool) (*model.CalendarFeed, error) {
+	user, err := auth.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := db.CalendarToken(ctx, user.ID, false)
+	if err != nil {
+		log.Error().Err(err).Str("userID", user.ID).Msg("Error issuing calendar token:")
+		return nil, err
+	}
+
+	filter := calendar.Filter{Labels: labels, Departments: departments, TasksAsEvents: valueOr(tasksAsEvents, false)}
+	return &model.CalendarFeed{URL: calendar.URL(token, filter)}, nil
+}
+
+// ResetCalendarFeed is the resolver for the resetCalendarFeed field.
+func (r *mutationResolver) ResetCalendarFeed(ctx context.Context) (bool, error) {
+	user, err := auth.RequireUser(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := db.CalendarToken(ctx, user.ID, true); err != nil {
+		log.Error().Err(err).Str("userID", user.ID).Msg("Error resetting calendar token:")
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,48 @@
+package resolvers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/calendar"
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"github.com/rs/zerolog/log"
+)
+
+// CalendarHandler serves the iCalendar feeds at /calendar/{token}.ics. The
+// token stands in for a login, since calendar clients cannot send one, and
+// the filters come from the query.
+func CalendarHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/calendar/"), ".ics")
+		if !ok || token == "" || strings.Contains(token, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		feed, err := db.ReadCalendarFeed(r.Context(), token, calendar.ParseFilter(r.URL.Query()))
+		if errors.Is(err, database.ErrUnknownCalendarToken) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Error reading calendar feed:")
+			http.Error(w, "could not read the calendar", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, max-age=300")
+		if err := calendar.Render(w, *feed, time.Now()); err != nil {
+			log.Error().Err(err).Msg("Error writing calendar feed:")
+		}
+	})
+}
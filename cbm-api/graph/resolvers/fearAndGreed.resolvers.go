@@ -30,6 +30,11 @@ func (r *queryResolver) ReadFearAndGreedIndexAtTimestamp(ctx context.Context, ti
 	return db.ReadFearAndGreedIndexAtTimestamp(ctx, timestamp)
 }
 
+// ReadFearAndGreedIndexForTime is the resolver for the readFearAndGreedIndexForTime field.
+func (r *queryResolver) ReadFearAndGreedIndexForTime(ctx context.Context, timestamp int) (*model.FearAndGreedIndex, error) {
+	return db.ReadFearAndGreedIndexForTime(ctx, timestamp)
+}
+
 // ReadFearAndGreedIndexCount is the resolver for the readFearAndGreedIndexCount field.
 func (r *queryResolver) ReadFearAndGreedIndexCount(ctx context.Context) (int, error) {
 	return db.ReadFearAndGreedIndexCount(ctx)
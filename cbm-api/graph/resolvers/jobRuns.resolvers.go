@@ -0,0 +1,46 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+// CreateJobRun is the resolver for the createJobRun field.
+func (r *mutationResolver) CreateJobRun(ctx context.Context, input model.JobRunInput) (*model.JobRun, error) {
+	return db.CreateJobRun(ctx, input)
+}
+
+// FinishJobRun is the resolver for the finishJobRun field.
+func (r *mutationResolver) FinishJobRun(ctx context.Context, runID string, input model.JobRunResult) (*model.JobRun, error) {
+	return db.FinishJobRun(ctx, runID, input)
+}
+
+// ClaimJobRun is the resolver for the claimJobRun field.
+func (r *mutationResolver) ClaimJobRun(ctx context.Context, job string) (*model.JobRun, error) {
+	return db.ClaimJobRun(ctx, job)
+}
+
+// TriggerJob is the resolver for the triggerJob field.
+func (r *mutationResolver) TriggerJob(ctx context.Context, name string) (*model.JobRun, error) {
+	return db.TriggerJob(ctx, name)
+}
+
+// FailStaleJobRuns is the resolver for the failStaleJobRuns field.
+func (r *mutationResolver) FailStaleJobRuns(ctx context.Context, olderThan int) (int, error) {
+	return db.FailStaleJobRuns(ctx, olderThan)
+}
+
+// ReadJobRuns is the resolver for the readJobRuns field.
+func (r *queryResolver) ReadJobRuns(ctx context.Context, job *string, status *model.JobStatus, limit *int) ([]*model.JobRun, error) {
+	return db.ReadJobRuns(ctx, job, status, limit)
+}
+
+// ReadLastJobSlot is the resolver for the readLastJobSlot field.
+func (r *queryResolver) ReadLastJobSlot(ctx context.Context, job string) (*int, error) {
+	return db.ReadLastJobSlot(ctx, job)
+}
@@ -0,0 +1,87 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vikstrous/dataloadgen"
+)
+
+type loadersKey struct{}
+
+// loaders batch the lookups made while resolving one operation, so a list of
+// projects loads all their tasks in a single query.
+type loaders struct {
+	tasksByProject   *dataloadgen.Loader[string, []*model.Task]
+	subtasksByParent *dataloadgen.Loader[string, []*model.Task]
+	taskByID         *dataloadgen.Loader[string, *model.Task]
+	activityByTarget *dataloadgen.Loader[database.ActivityKey, []*model.ActivityEntry]
+}
+
+func newLoaders() *loaders {
+	return &loaders{
+		tasksByProject:   dataloadgen.NewLoader(fetchTasksByProject, dataloadgen.WithWait(time.Millisecond)),
+		subtasksByParent: dataloadgen.NewLoader(fetchSubtasksByParent, dataloadgen.WithWait(time.Millisecond)),
+		taskByID:         dataloadgen.NewLoader(fetchTasksByID, dataloadgen.WithWait(time.Millisecond)),
+		activityByTarget: dataloadgen.NewLoader(fetchActivityByTarget, dataloadgen.WithWait(time.Millisecond)),
+	}
+}
+
+func fetchTasksByProject(ctx context.Context, projectIDs []string) ([][]*model.Task, []error) {
+	tasks, err := db.GetTasksByProjectIDs(ctx, projectIDs)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return tasks, nil
+}
+
+func fetchSubtasksByParent(ctx context.Context, parentIDs []string) ([][]*model.Task, []error) {
+	subtasks, err := db.GetSubtasksByParentIDs(ctx, parentIDs)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return subtasks, nil
+}
+
+// fetchTasksByID leaves tasks not found nil, for callers to skip.
+func fetchTasksByID(ctx context.Context, ids []string) ([]*model.Task, []error) {
+	tasks, err := db.GetTasksByIDs(ctx, ids)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return tasks, nil
+}
+
+func fetchActivityByTarget(ctx context.Context, keys []database.ActivityKey) ([][]*model.ActivityEntry, []error) {
+	activity, err := db.GetActivityByTargets(ctx, keys)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return activity, nil
+}
+
+// AttachLoaders gives each operation its own loaders. Use it with the
+// server's AroundOperations, so it covers subscriptions over websockets too.
+func AttachLoaders(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	return next(context.WithValue(ctx, loadersKey{}, newLoaders()))
+}
+
+// loadersFor returns the operation's loaders, or new ones outside an
+// operation so that resolvers still work when called directly.
+func loadersFor(ctx context.Context) *loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*loaders); ok {
+		return l
+	}
+	return newLoaders()
+}
+
+// valueOr returns the argument's value, or the fallback when it is not given.
+func valueOr[T any](arg *T, fallback T) T {
+	if arg == nil {
+		return fallback
+	}
+	return *arg
+}
@@ -0,0 +1,31 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+// CreateMarketBreadth is the resolver for the createMarketBreadth field.
+func (r *mutationResolver) CreateMarketBreadth(ctx context.Context, input model.MarketBreadthInput) (*model.MarketBreadth, error) {
+	return db.CreateMarketBreadth(ctx, input)
+}
+
+// DeleteMarketBreadth is the resolver for the deleteMarketBreadth field.
+func (r *mutationResolver) DeleteMarketBreadth(ctx context.Context, timestamp int) (bool, error) {
+	return db.DeleteMarketBreadth(ctx, timestamp)
+}
+
+// ReadMarketBreadthAt is the resolver for the readMarketBreadthAt field.
+func (r *queryResolver) ReadMarketBreadthAt(ctx context.Context, timestamp int) (*model.MarketBreadth, error) {
+	return db.ReadMarketBreadthAt(ctx, timestamp)
+}
+
+// ReadMarketBreadth is the resolver for the readMarketBreadth field.
+func (r *queryResolver) ReadMarketBreadth(ctx context.Context, from *int, to *int, limit *int) ([]*model.MarketBreadth, error) {
+	return db.ReadMarketBreadth(ctx, from, to, limit)
+}
@@ -20,6 +20,9 @@ func (r *mutationResolver) CreateHistoricPrices(ctx context.Context, input *mode
 	if err != nil {
 		return nil, err
 	}
+	for _, prices := range insertedHistoricPrices {
+		r.PriceTicks.Publish(prices)
+	}
 
 	// Assuming you want to return the insertedHistoricPrices and the timestamp
 	return insertedHistoricPrices, nil
@@ -62,6 +65,16 @@ func (r *queryResolver) ReadHistoricPrice(ctx context.Context, symbol string, li
 	return result, nil
 }
 
+// ReadHistoricPriceBefore is the resolver for the readHistoricPriceBefore field.
+func (r *queryResolver) ReadHistoricPriceBefore(ctx context.Context, symbol string, timestamp int, limit *int) ([]*model.HistoricPrices, error) {
+	l := 0
+	if limit != nil {
+		l = *limit
+	}
+
+	return db.ReadHistoricPricesBySymbolBefore(ctx, symbol, timestamp, l)
+}
+
 // ReadHistoricPricesAtTimestamp is the resolver for the readHistoricPricesAtTimestamp field.
 func (r *queryResolver) ReadHistoricPricesAtTimestamp(ctx context.Context, timestamp int) ([]*model.HistoricPrices, error) {
 	log.Info().Msgf("Fetching prices at Timestamp: %d", timestamp)
@@ -13,7 +13,9 @@ import (
 
 // MutationResolver implementation
 func (r *mutationResolver) CreateActivityReport(ctx context.Context, input *model.NewActivityReport) (*model.ActivityReport, error) {
-	return db.CreateActivityReport(input), nil
+	report := db.CreateActivityReport(input)
+	r.ActivityReports.Publish(report)
+	return report, nil
 }
 
 // ReadActivityReport is the resolver for the readActivityReport field.
@@ -26,6 +28,11 @@ func (r *queryResolver) ReadAllActivityReports(ctx context.Context) ([]*model.Ac
 	return db.ReadAllActivityReports(), nil
 }
 
+// ReadActivityReportAt is the resolver for the readActivityReportAt field.
+func (r *queryResolver) ReadActivityReportAt(ctx context.Context, timestamp int) (*model.ActivityReport, error) {
+	return db.ReadActivityReportAt(ctx, timestamp)
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
@@ -22,6 +22,11 @@ func (r *mutationResolver) DeleteSymbolStats(ctx context.Context, symbol string)
 	return success, err
 }
 
+// RecordTopMovers is the resolver for the recordTopMovers field.
+func (r *mutationResolver) RecordTopMovers(ctx context.Context, input model.RecordTopMoversInput) (int, error) {
+	return db.RecordTopMovers(ctx, input)
+}
+
 // CreateHistoricTickerStats is the resolver for the createHistoricTickerStats field.
 func (r *mutationResolver) CreateHistoricTickerStats(ctx context.Context, input model.NewHistoricTickerStatsInput) ([]*model.HistoricTickerStats, error) {
 	// Assuming you want to save multiple HistoricKlineData in the input
@@ -56,6 +61,11 @@ func (r *queryResolver) ReadSingleSymbolStatsBySymbol(ctx context.Context, symbo
 	return db.ReadSingleSymbolStatsBySymbol(symbol), nil
 }
 
+// ReadTopMovers is the resolver for the readTopMovers field.
+func (r *queryResolver) ReadTopMovers(ctx context.Context, window *model.MoverWindow, limit *int) ([]*model.TopMover, error) {
+	return db.ReadTopMovers(ctx, window, limit)
+}
+
 // ReadHistoricTickerStatsAtTimestamp is the resolver for the readHistoricTickerStatsAtTimestamp field.
 func (r *queryResolver) ReadHistoricTickerStatsAtTimestamp(ctx context.Context, timestamp int) ([]*model.HistoricTickerStats, error) {
 	log.Info().Msgf("Fetching historic ticker stats at Timestamp: %d", timestamp)
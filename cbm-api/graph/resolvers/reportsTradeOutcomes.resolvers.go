@@ -13,7 +13,9 @@ import (
 
 // CreateTradeOutcomeReport is the resolver for the createTradeOutcomeReport field.
 func (r *mutationResolver) CreateTradeOutcomeReport(ctx context.Context, input *model.NewTradeOutcomeReport) (*model.TradeOutcomeReport, error) {
-	return db.CreateTradeOutcomeReport(input), nil
+	report := db.CreateTradeOutcomeReport(input)
+	r.TradesClosed.Publish(report)
+	return report, nil
 }
 
 // DeleteOutcomeReports is the resolver for the deleteOutcomeReports field.
@@ -1,7 +1,36 @@
 package resolvers
 
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/pubsub"
+)
+
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app.
 
-type Resolver struct{}
+type Resolver struct {
+	// Brokers fan out the records mutations write to subscriptions.
+	PriceTicks      *pubsub.Broker[*model.HistoricPrices]
+	TradesOpened    *pubsub.Broker[*model.TradeOpened]
+	TradesClosed    *pubsub.Broker[*model.TradeOutcomeReport]
+	ActivityReports *pubsub.Broker[*model.ActivityReport]
+}
+
+// NewResolver returns a resolver with empty subscription brokers.
+func NewResolver() *Resolver {
+	return &Resolver{
+		PriceTicks:      pubsub.NewBroker[*model.HistoricPrices]("priceTick"),
+		TradesOpened:    pubsub.NewBroker[*model.TradeOpened]("tradeOpened"),
+		TradesClosed:    pubsub.NewBroker[*model.TradeOutcomeReport]("tradeClosed"),
+		ActivityReports: pubsub.NewBroker[*model.ActivityReport]("activityReportCreated"),
+	}
+}
+
+// VerifySchema checks the database has been migrated to the version this
+// build expects.
+func VerifySchema(ctx context.Context) error {
+	return db.VerifySchema(ctx)
+}
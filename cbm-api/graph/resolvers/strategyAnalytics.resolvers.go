@@ -0,0 +1,21 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+// ReadStrategyAnalytics is the resolver for the readStrategyAnalytics field.
+func (r *queryResolver) ReadStrategyAnalytics(ctx context.Context, botName string, from *int, to *int, breakdownBy *model.AnalyticsBreakdown) (*model.StrategyAnalytics, error) {
+	return db.ReadStrategyAnalytics(ctx, botName, from, to, breakdownBy)
+}
+
+// ReadAllStrategyAnalytics is the resolver for the readAllStrategyAnalytics field.
+func (r *queryResolver) ReadAllStrategyAnalytics(ctx context.Context, from int, to *int) ([]*model.StrategyAnalytics, error) {
+	return db.ReadAllStrategyAnalytics(ctx, from, to)
+}
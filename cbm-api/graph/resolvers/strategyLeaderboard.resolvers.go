@@ -0,0 +1,16 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+// ReadStrategyLeaderboard is the resolver for the readStrategyLeaderboard field.
+func (r *queryResolver) ReadStrategyLeaderboard(ctx context.Context, sortBy *model.LeaderboardSort, window *int, owner *string, lifecycle *model.StrategyLifecycle, limit *int) ([]*model.LeaderboardEntry, error) {
+	return db.ReadStrategyLeaderboard(ctx, sortBy, window, owner, lifecycle, limit)
+}
@@ -0,0 +1,31 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+// CreateStrategySweep is the resolver for the createStrategySweep field.
+func (r *mutationResolver) CreateStrategySweep(ctx context.Context, input model.StrategySweepInput) (*model.StrategySweep, error) {
+	return db.CreateStrategySweep(ctx, input)
+}
+
+// DeleteStrategySweep is the resolver for the deleteStrategySweep field.
+func (r *mutationResolver) DeleteStrategySweep(ctx context.Context, sweepID string) (bool, error) {
+	return db.DeleteStrategySweep(ctx, sweepID)
+}
+
+// ReadStrategySweep is the resolver for the readStrategySweep field.
+func (r *queryResolver) ReadStrategySweep(ctx context.Context, sweepID string, limit *int) (*model.StrategySweep, error) {
+	return db.ReadStrategySweep(ctx, sweepID, limit)
+}
+
+// ReadAllStrategySweeps is the resolver for the readAllStrategySweeps field.
+func (r *queryResolver) ReadAllStrategySweeps(ctx context.Context, limit *int) ([]*model.StrategySweep, error) {
+	return db.ReadAllStrategySweeps(ctx, limit)
+}
@@ -0,0 +1,86 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/generated"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+// CreateTradeOpened is the resolver for the createTradeOpened field.
+func (r *mutationResolver) CreateTradeOpened(ctx context.Context, input model.NewTradeOpened) (*model.TradeOpened, error) {
+	trade, err := db.CreateTradeOpened(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	r.TradesOpened.Publish(trade)
+	return trade, nil
+}
+
+// ReadTradesOpened is the resolver for the readTradesOpened field.
+func (r *queryResolver) ReadTradesOpened(ctx context.Context, botName string, limit *int) ([]*model.TradeOpened, error) {
+	return db.ReadTradesOpened(ctx, botName, limit)
+}
+
+// PriceTick is the resolver for the priceTick field.
+func (r *subscriptionResolver) PriceTick(ctx context.Context, symbols []string) (<-chan *model.HistoricPrices, error) {
+	ticks := r.PriceTicks.Subscribe(ctx, nil)
+	if len(symbols) == 0 {
+		return ticks, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, symbol := range symbols {
+		wanted[symbol] = true
+	}
+
+	narrowed := make(chan *model.HistoricPrices, cap(ticks))
+	go func() {
+		defer close(narrowed)
+		for tick := range ticks {
+			var pairs []*model.Pair
+			for _, pair := range tick.Pair {
+				if wanted[pair.Symbol] {
+					pairs = append(pairs, pair)
+				}
+			}
+			if len(pairs) == 0 {
+				continue
+			}
+			select {
+			case narrowed <- &model.HistoricPrices{Pair: pairs, Timestamp: tick.Timestamp, CreatedAt: tick.CreatedAt}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return narrowed, nil
+}
+
+// TradeOpened is the resolver for the tradeOpened field.
+func (r *subscriptionResolver) TradeOpened(ctx context.Context, botName *string) (<-chan *model.TradeOpened, error) {
+	return r.TradesOpened.Subscribe(ctx, func(trade *model.TradeOpened) bool {
+		return botName == nil || trade.BotName == *botName
+	}), nil
+}
+
+// TradeClosed is the resolver for the tradeClosed field.
+func (r *subscriptionResolver) TradeClosed(ctx context.Context, botName *string) (<-chan *model.TradeOutcomeReport, error) {
+	return r.TradesClosed.Subscribe(ctx, func(report *model.TradeOutcomeReport) bool {
+		return botName == nil || report.BotName == *botName
+	}), nil
+}
+
+// ActivityReportCreated is the resolver for the activityReportCreated field.
+func (r *subscriptionResolver) ActivityReportCreated(ctx context.Context) (<-chan *model.ActivityReport, error) {
+	return r.ActivityReports.Subscribe(ctx, nil), nil
+}
+
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
@@ -6,13 +6,25 @@ package resolvers
 
 import (
 	"context"
+	"time"
 
+	"cryptobotmanager.com/cbm-backend/cbm-api/auth"
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/generated"
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
 	"github.com/rs/zerolog/log"
 )
 
 // CreateTask is the resolver for the createTask field.
 func (r *mutationResolver) CreateTask(ctx context.Context, input model.CreateTaskInput) (*model.Task, error) {
+	// Imports skip the workflow's rules, so only admins may make them
+	if input.Imported != nil && *input.Imported {
+		if _, err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+
 	task, err := db.CreateTask(ctx, input)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating task:")
@@ -24,6 +36,13 @@ func (r *mutationResolver) CreateTask(ctx context.Context, input model.CreateTas
 
 // UpdateTask is the resolver for the updateTask field.
 func (r *mutationResolver) UpdateTask(ctx context.Context, input model.UpdateTaskInput) (*model.Task, error) {
+	// Imports skip the workflow's rules, so only admins may make them
+	if input.Imported != nil && *input.Imported {
+		if _, err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+
 	task, err := db.UpdateTask(ctx, input)
 	if err != nil {
 		log.Error().Err(err).Msg("Error updating task:")
@@ -33,6 +52,17 @@ func (r *mutationResolver) UpdateTask(ctx context.Context, input model.UpdateTas
 	return task, nil
 }
 
+// PromoteDeferredTasks is the resolver for the promoteDeferredTasks field.
+func (r *mutationResolver) PromoteDeferredTasks(ctx context.Context) ([]*model.Task, error) {
+	tasks, err := db.PromoteDeferredTasks(ctx, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("Error promoting deferred tasks:")
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
 // DeleteTask is the resolver for the deleteTask field.
 func (r *mutationResolver) DeleteTask(ctx context.Context, id string) (*bool, error) {
 	success, err := db.DeleteTaskByID(ctx, id)
@@ -74,6 +104,44 @@ func (r *mutationResolver) DeleteProject(ctx context.Context, id string) (*bool,
 	return &success, nil
 }
 
+// InstantiateSop is the resolver for the instantiateSop field.
+func (r *mutationResolver) InstantiateSop(ctx context.Context, sopID string, title string, assignedTo *string, startDate string, departmentAssignees []*model.DepartmentAssigneeInput) (*model.Project, error) {
+	project, err := db.InstantiateSop(ctx, sopID, title, assignedTo, startDate, departmentAssignees)
+	if err != nil {
+		log.Error().Err(err).Msg("Error instantiating SOP:")
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// Tasks is the resolver for the tasks field.
+func (r *projectResolver) Tasks(ctx context.Context, obj *model.Project) ([]*model.Task, error) {
+	// Keep tasks already chosen for the project, such as the weekly review's.
+	if obj.Tasks != nil {
+		return obj.Tasks, nil
+	}
+
+	tasks, err := loadersFor(ctx).tasksByProject.Load(ctx, obj.ID)
+	if err != nil {
+		log.Error().Err(err).Str("projectID", obj.ID).Msg("Error fetching tasks for project:")
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Activity is the resolver for the activity field.
+func (r *projectResolver) Activity(ctx context.Context, obj *model.Project) ([]*model.ActivityEntry, error) {
+	activity, err := loadersFor(ctx).activityByTarget.Load(ctx, database.ActivityKey{TargetType: model.ActivityTargetProject, TargetID: obj.ID})
+	if err != nil {
+		log.Error().Err(err).Str("projectID", obj.ID).Msg("Error fetching project activity:")
+		return nil, err
+	}
+
+	return activity, nil
+}
+
 // ReadTaskByID is the resolver for the readTaskById field.
 func (r *queryResolver) ReadTaskByID(ctx context.Context, id string) (*model.Task, error) {
 	task, err := db.ReadTaskByID(ctx, id)
@@ -96,6 +164,55 @@ func (r *queryResolver) ReadAllTasks(ctx context.Context) ([]*model.Task, error)
 	return tasks, nil
 }
 
+// Tasks is the resolver for the tasks field.
+func (r *queryResolver) Tasks(ctx context.Context, filter *model.TaskFilterInput, sort *model.TaskSort, direction *model.SortDirection, first *int, after *string) (*model.TaskConnection, error) {
+	tasks, err := db.ReadTasksPage(ctx, filter, valueOr(sort, model.TaskSortCreatedAt), valueOr(direction, model.SortDirectionAsc), valueOr(first, 50), after)
+	if err != nil {
+		log.Error().Err(err).Msg("Error fetching page of tasks:")
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// TaskGraph is the resolver for the taskGraph field.
+func (r *queryResolver) TaskGraph(ctx context.Context, id string, depth *int) (*model.TaskGraph, error) {
+	graph, err := db.TaskGraph(ctx, id, valueOr(depth, 5))
+	if err != nil {
+		log.Error().Err(err).Str("taskID", id).Msg("Error fetching task graph:")
+		return nil, err
+	}
+
+	return graph, nil
+}
+
+// TaskStatuses is the resolver for the taskStatuses field.
+func (r *queryResolver) TaskStatuses(ctx context.Context) ([]*model.TaskStatusTransitions, error) {
+	statuses := gtd.Statuses()
+	transitions := make([]*model.TaskStatusTransitions, 0, len(statuses))
+	for _, status := range statuses {
+		transitions = append(transitions, &model.TaskStatusTransitions{Status: status, Allowed: gtd.Allowed(status)})
+	}
+
+	return transitions, nil
+}
+
+// WeeklyReview is the resolver for the weeklyReview field.
+func (r *queryResolver) WeeklyReview(ctx context.Context, staleAfterDays *int) (*model.WeeklyReview, error) {
+	staleAfter := 7
+	if staleAfterDays != nil {
+		staleAfter = *staleAfterDays
+	}
+
+	review, err := db.WeeklyReview(ctx, time.Now(), staleAfter)
+	if err != nil {
+		log.Error().Err(err).Msg("Error building weekly review:")
+		return nil, err
+	}
+
+	return review, nil
+}
+
 // ReadSingleProjectByID is the resolver for the readSingleProjectById field.
 func (r *queryResolver) ReadSingleProjectByID(ctx context.Context, id string) (*model.Project, error) {
 	project, err := db.ReadSingleProjectByID(ctx, id)
@@ -104,39 +221,81 @@ func (r *queryResolver) ReadSingleProjectByID(ctx context.Context, id string) (*
 		return nil, err
 	}
 
-	// Fetch tasks manually
-	tasks, err := db.GetTasksByProjectID(ctx, project.ID)
+	return project, nil
+}
+
+// ReadProjectsFilter is the resolver for the readProjectsFilter field.
+func (r *queryResolver) ReadProjectsFilter(ctx context.Context, filter *model.ProjectFilterInput) ([]*model.Project, error) {
+	projects, err := db.ReadProjects(ctx, filter)
 	if err != nil {
-		log.Error().Err(err).Msg("Error fetching tasks for project:")
+		log.Error().Err(err).Msg("Error fetching projects:")
 		return nil, err
 	}
-	project.Tasks = tasks
 
-	return project, nil
+	return projects, nil
 }
 
-// ReadProjectsFilter is the resolver for the readProjectsFilter field.
-func (r *queryResolver) ReadProjectsFilter(ctx context.Context, filter *model.ProjectFilterInput) ([]*model.Project, error) {
-	var projects []*model.Project
-	var err error
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context, filter *model.ProjectFilterInput, sort *model.ProjectSort, direction *model.SortDirection, first *int, after *string) (*model.ProjectConnection, error) {
+	projects, err := db.ReadProjectsPage(ctx, filter, valueOr(sort, model.ProjectSortCreatedAt), valueOr(direction, model.SortDirectionAsc), valueOr(first, 50), after)
+	if err != nil {
+		log.Error().Err(err).Msg("Error fetching page of projects:")
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Subtasks is the resolver for the subtasks field.
+func (r *taskResolver) Subtasks(ctx context.Context, obj *model.Task) ([]*model.Task, error) {
+	subtasks, err := loadersFor(ctx).subtasksByParent.Load(ctx, obj.ID)
+	if err != nil {
+		log.Error().Err(err).Str("taskID", obj.ID).Msg("Error fetching subtasks:")
+		return nil, err
+	}
+
+	return subtasks, nil
+}
 
-	if filter != nil && filter.Sop != nil {
-		projects, err = db.ReadProjectsBySOP(ctx, *filter.Sop)
+// Blockers is the resolver for the blockers field.
+func (r *taskResolver) Blockers(ctx context.Context, obj *model.Task) ([]*model.Task, error) {
+	if len(obj.BlockedBy) == 0 {
+		return []*model.Task{}, nil
 	}
 
+	tasks, err := loadersFor(ctx).taskByID.LoadAll(ctx, obj.BlockedBy)
 	if err != nil {
-		log.Error().Err(err).Msg("Error fetching projects:")
+		log.Error().Err(err).Str("taskID", obj.ID).Msg("Error fetching blockers:")
 		return nil, err
 	}
 
-	for _, project := range projects {
-		tasks, err := db.GetTasksByProjectID(ctx, project.ID)
-		if err != nil {
-			log.Error().Err(err).Str("projectID", project.ID).Msg("Error fetching tasks")
-			continue
+	// Skip blockers deleted since.
+	blockers := make([]*model.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task != nil {
+			blockers = append(blockers, task)
 		}
-		project.Tasks = tasks
 	}
 
-	return projects, nil
+	return blockers, nil
 }
+
+// Activity is the resolver for the activity field.
+func (r *taskResolver) Activity(ctx context.Context, obj *model.Task) ([]*model.ActivityEntry, error) {
+	activity, err := loadersFor(ctx).activityByTarget.Load(ctx, database.ActivityKey{TargetType: model.ActivityTargetTask, TargetID: obj.ID})
+	if err != nil {
+		log.Error().Err(err).Str("taskID", obj.ID).Msg("Error fetching task activity:")
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// Project returns generated.ProjectResolver implementation.
+func (r *Resolver) Project() generated.ProjectResolver { return &projectResolver{r} }
+
+// Task returns generated.TaskResolver implementation.
+func (r *Resolver) Task() generated.TaskResolver { return &taskResolver{r} }
+
+type projectResolver struct{ *Resolver }
+type taskResolver struct{ *Resolver }
@@ -0,0 +1,83 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.72
+
+import (
+	"context"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/auth"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/rs/zerolog/log"
+)
+
+// StartTimer is the resolver for the startTimer field.
+func (r *mutationResolver) StartTimer(ctx context.Context, taskID string) (*model.TimeEntry, error) {
+	user, err := auth.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := db.StartTimer(ctx, taskID, user.ID, time.Now())
+	if err != nil {
+		log.Error().Err(err).Str("taskID", taskID).Msg("Error starting timer:")
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// StopTimer is the resolver for the stopTimer field.
+func (r *mutationResolver) StopTimer(ctx context.Context, taskID string) (*model.TimeEntry, error) {
+	user, err := auth.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := db.StopTimer(ctx, taskID, user.ID, time.Now())
+	if err != nil {
+		log.Error().Err(err).Str("taskID", taskID).Msg("Error stopping timer:")
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// RunningTimer is the resolver for the runningTimer field.
+func (r *queryResolver) RunningTimer(ctx context.Context) (*model.TimeEntry, error) {
+	user, err := auth.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ReadRunningTimer(ctx, user.ID)
+}
+
+// ReadTimeEntries is the resolver for the readTimeEntries field.
+func (r *queryResolver) ReadTimeEntries(ctx context.Context, taskID *string, userID *string) ([]*model.TimeEntry, error) {
+	return db.ReadTimeEntries(ctx, taskID, userID)
+}
+
+// TimeReport is the resolver for the timeReport field.
+func (r *queryResolver) TimeReport(ctx context.Context, from string, to string) (*model.TimeReport, error) {
+	report, err := db.TimeReport(ctx, from, to, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("Error building time report:")
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Workload is the resolver for the workload field.
+func (r *queryResolver) Workload(ctx context.Context, days *int) ([]*model.AssigneeWorkload, error) {
+	workload, err := db.Workload(ctx, time.Now(), valueOr(days, 7))
+	if err != nil {
+		log.Error().Err(err).Msg("Error building workload:")
+		return nil, err
+	}
+
+	return workload, nil
+}
@@ -0,0 +1,131 @@
+package gtd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Recurrence rules besides cron expressions.
+const (
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+)
+
+// ValidateRecurrence checks that the rule is daily, weekly, monthly or a cron
+// expression such as "0 9 * * 1-5".
+func ValidateRecurrence(rule string) error {
+	_, err := NextOccurrence(rule, time.Now(), false)
+	return err
+}
+
+// NextOccurrence returns when a task recurring on the rule next falls after
+// the occurrence at t. Daily, weekly and monthly rules keep the time of day,
+// and a monthly rule from a day the next month lacks falls on its last day.
+// When t is a date alone, a cron rule moves on to the next day it matches and
+// the result is a date alone too.
+func NextOccurrence(rule string, t time.Time, dateOnly bool) (time.Time, error) {
+	switch rule {
+	case Daily:
+		return t.AddDate(0, 0, 1), nil
+	case Weekly:
+		return t.AddDate(0, 0, 7), nil
+	case Monthly:
+		return addMonth(t), nil
+	}
+
+	schedule, err := cron.ParseStandard(rule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid recurrence %q, expected daily, weekly, monthly or a cron expression: %w", rule, err)
+	}
+	if dateOnly {
+		// Skip the rest of the day, so the next occurrence is on a later date.
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	next := schedule.Next(t)
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("recurrence %q never falls after %s", rule, t.Format(time.RFC3339))
+	}
+	if dateOnly {
+		next = time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return next, nil
+}
+
+// addMonth returns the same day and time the next month, or the month's last
+// day if it is shorter.
+func addMonth(t time.Time) time.Time {
+	year, month, day := t.Date()
+	lastDay := time.Date(year, month+2, 0, 0, 0, 0, 0, t.Location()).Day()
+	return time.Date(year, month+1, min(day, lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// FormatDate formats t in the layout ParseDate found, a date alone or RFC 3339.
+func FormatDate(t time.Time, dateOnly bool) string {
+	if dateOnly {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// NextDates returns the due and defer dates of the occurrence after a task
+// recurring on the rule, completed at now. The due date, or the defer date
+// without one, moves on by the rule and the other date keeps its distance to
+// it. Occurrences already overdue by now are skipped. A task with neither
+// date is next due on the first day the rule reaches after now.
+func NextDates(rule string, dueDate, deferDate *string, now time.Time) (*string, *string, error) {
+	anchor, other := dueDate, deferDate
+	if !parsable(anchor) {
+		anchor, other = deferDate, nil
+	}
+
+	if !parsable(anchor) {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		next, err := NextOccurrence(rule, today, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		due := FormatDate(next, true)
+		return &due, nil, nil
+	}
+
+	from, dateOnly, _ := ParseDate(*anchor)
+	next := from
+	// Bounded so a rule that rarely matches cannot spin; a daily rule covers
+	// years of missed occurrences.
+	for range 10000 {
+		var err error
+		if next, err = NextOccurrence(rule, next, dateOnly); err != nil {
+			return nil, nil, err
+		}
+		if nextDate := FormatDate(next, dateOnly); !Overdue(&nextDate, now) {
+			break
+		}
+	}
+
+	nextAnchor := FormatDate(next, dateOnly)
+	var nextOther *string
+	if t, otherDateOnly, ok := parseOptional(other); ok {
+		shifted := FormatDate(t.Add(next.Sub(from)), otherDateOnly)
+		nextOther = &shifted
+	}
+
+	if anchor == deferDate {
+		return nil, &nextAnchor, nil
+	}
+	return &nextAnchor, nextOther, nil
+}
+
+func parsable(date *string) bool {
+	_, _, ok := parseOptional(date)
+	return ok
+}
+
+func parseOptional(date *string) (time.Time, bool, bool) {
+	if date == nil {
+		return time.Time{}, false, false
+	}
+	return ParseDate(*date)
+}
@@ -0,0 +1,167 @@
+// Package gtd defines the Getting Things Done workflow a task's status moves
+// through, and the rules for moving it.
+package gtd
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Task statuses.
+const (
+	Inbox        = "inbox"        // captured, not yet clarified
+	NextAction   = "nextAction"   // ready to be done
+	WaitingFor   = "waitingFor"   // delegated or blocked on someone else
+	Scheduled    = "scheduled"    // deferred until its deferDate
+	SomedayMaybe = "somedayMaybe" // parked, not committed to
+	Complete     = "complete"     // done
+)
+
+// transitions lists the statuses each status may move to. A status may always
+// be set to itself.
+var transitions = map[string][]string{
+	Inbox:        {NextAction, WaitingFor, Scheduled, SomedayMaybe, Complete},
+	NextAction:   {WaitingFor, Scheduled, SomedayMaybe, Complete},
+	WaitingFor:   {NextAction, Scheduled, SomedayMaybe, Complete},
+	Scheduled:    {NextAction, WaitingFor, SomedayMaybe, Complete},
+	SomedayMaybe: {Inbox, NextAction, Scheduled},
+	Complete:     {NextAction},
+}
+
+// Error codes, given in the extensions of the GraphQL error.
+const (
+	CodeUnknownStatus     = "UNKNOWN_TASK_STATUS"
+	CodeInvalidTransition = "INVALID_TASK_TRANSITION"
+	CodeBlocked           = "TASK_BLOCKED"
+)
+
+// Error is a status change the workflow does not allow.
+type Error struct {
+	Code     string
+	Message  string
+	From     string   // status being moved from, empty for a new task
+	To       string   // status being moved to
+	Allowed  []string // statuses that could be moved to instead
+	Blockers []string // IDs of the open tasks blocking the move
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions returns the details of the error for the GraphQL response.
+func (e *Error) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.Code, "to": e.To}
+	if e.From != "" {
+		ext["from"] = e.From
+	}
+	if e.Allowed != nil {
+		ext["allowed"] = e.Allowed
+	}
+	if e.Blockers != nil {
+		ext["blockedBy"] = e.Blockers
+	}
+	return ext
+}
+
+// Statuses returns every task status.
+func Statuses() []string {
+	return []string{Inbox, NextAction, WaitingFor, Scheduled, SomedayMaybe, Complete}
+}
+
+// Allowed returns the statuses the status may move to, besides itself.
+func Allowed(from string) []string {
+	return slices.Clone(transitions[from])
+}
+
+// ValidateStatus checks that the status is one of the workflow's.
+func ValidateStatus(status string) error {
+	if _, ok := transitions[status]; !ok {
+		return &Error{
+			Code:    CodeUnknownStatus,
+			Message: fmt.Sprintf("unknown task status %q", status),
+			To:      status,
+			Allowed: Statuses(),
+		}
+	}
+	return nil
+}
+
+// ValidateTransition checks that a task may move from one status to another.
+// A task with a status from before the workflow may move to any status.
+func ValidateTransition(from, to string) error {
+	if err := ValidateStatus(to); err != nil {
+		if e, ok := err.(*Error); ok {
+			e.From = from
+		}
+		return err
+	}
+
+	allowed, known := transitions[from]
+	if from == to || !known || slices.Contains(allowed, to) {
+		return nil
+	}
+	return &Error{
+		Code:    CodeInvalidTransition,
+		Message: fmt.Sprintf("a task cannot move from %s to %s", from, to),
+		From:    from,
+		To:      to,
+		Allowed: Allowed(from),
+	}
+}
+
+// ValidateUnblocked checks that a task moving from one status to another is
+// not made a next action while the tasks blocking it are still open.
+func ValidateUnblocked(from, to string, openBlockers []string) error {
+	if to != NextAction || from == NextAction || len(openBlockers) == 0 {
+		return nil
+	}
+	return &Error{
+		Code:     CodeBlocked,
+		Message:  "a task cannot become a next action until the tasks blocking it are complete",
+		From:     from,
+		To:       to,
+		Blockers: openBlockers,
+	}
+}
+
+// ParseDate parses a task's due or defer date, stored in RFC 3339 or as a
+// date alone. A date alone is the start of that day, UTC, and dateOnly is set.
+func ParseDate(date string) (t time.Time, dateOnly bool, ok bool) {
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t, false, true
+	}
+	if t, err := time.Parse(time.DateOnly, date); err == nil {
+		return t, true, true
+	}
+	return time.Time{}, false, false
+}
+
+// Due reports whether a defer date has been reached by now; a date alone is
+// reached at the start of the day. Empty or unparsable dates never are.
+func Due(date *string, now time.Time) bool {
+	if date == nil {
+		return false
+	}
+	t, _, ok := ParseDate(*date)
+	return ok && !t.After(now)
+}
+
+// Overdue reports whether a due date has passed by now; a date alone passes
+// at the end of the day. Empty or unparsable dates never do.
+func Overdue(date *string, now time.Time) bool {
+	if date == nil {
+		return false
+	}
+	t, dateOnly, ok := ParseDate(*date)
+	if dateOnly {
+		t = t.AddDate(0, 0, 1)
+	}
+	return ok && now.After(t)
+}
+
+// Open reports whether a task with the status still needs doing.
+func Open(status string) bool {
+	return status != Complete && status != SomedayMaybe
+}
@@ -0,0 +1,347 @@
+// Package migrate applies versioned, ordered schema migrations to MongoDB and
+// records the ones applied in the _migrations collection, so that the API
+// can check on startup that the database is at the version it expects.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection is where applied migrations are recorded.
+const Collection = "_migrations"
+
+// Migration is one step of the schema. Up moves the data forward and Down
+// back; both must be safe to run again after failing part way. Down is nil
+// for a migration that cannot be undone.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// VersionIndex is the unique index on version that claims rely on. The first
+// migration should create it; Up creates it too, before the first claim.
+var VersionIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "version", Value: 1}},
+	Options: options.Index().SetName("version_unique").SetUnique(true),
+}
+
+// States of a record. A run claims a migration by inserting its record as
+// running before applying it, so that a unique index on version makes a
+// second run fail rather than apply it again, and marks it applied after.
+const (
+	Running = "running"
+	Applied = "applied"
+)
+
+// Record is a migration applied, or being applied or undone, as stored in the
+// _migrations collection.
+type Record struct {
+	Version     int       `bson:"version"`
+	Description string    `bson:"description"`
+	State       string    `bson:"state"`
+	ClaimedAt   time.Time `bson:"claimedat"`
+	AppliedAt   time.Time `bson:"appliedat,omitempty"`
+}
+
+// Status is a migration and whether it has been applied.
+type Status struct {
+	Version     int
+	Description string
+	Running     bool       // a run has claimed it, or died holding it
+	AppliedAt   *time.Time // nil until applied
+}
+
+// Validate checks migrations are numbered 1, 2, 3 and so on in order, each
+// with an Up.
+func Validate(migrations []Migration) error {
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return fmt.Errorf("migration %d is numbered %d, migrations must be numbered from 1 in order", i+1, migration.Version)
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("migration %d has no up step", migration.Version)
+		}
+	}
+	return nil
+}
+
+// Latest returns the version the migrations bring a database to.
+func Latest(migrations []Migration) int {
+	return len(migrations)
+}
+
+// Pending returns the migrations not applied yet, up to and including target,
+// in the order to apply them.
+func Pending(migrations []Migration, applied map[int]bool, target int) []Migration {
+	var pending []Migration
+	for _, migration := range migrations {
+		if migration.Version <= target && !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// Rollback returns the applied migrations above target, in the order to undo
+// them. It refuses when one of them cannot be undone.
+func Rollback(migrations []Migration, applied map[int]bool, target int) ([]Migration, error) {
+	if target < 0 {
+		return nil, fmt.Errorf("cannot roll back below version 0, got %d", target)
+	}
+
+	var steps []Migration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if migration.Version <= target || !applied[migration.Version] {
+			continue
+		}
+		if migration.Down == nil {
+			return nil, fmt.Errorf("migration %d (%s) cannot be undone", migration.Version, migration.Description)
+		}
+		steps = append(steps, migration)
+	}
+	return steps, nil
+}
+
+// Check reports whether the applied migrations are exactly the known ones.
+// A database missing some needs migrating up; one with migrations this build
+// does not know was migrated by a newer build.
+func Check(migrations []Migration, applied map[int]bool) error {
+	var missing, unknown []string
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			missing = append(missing, fmt.Sprint(migration.Version))
+		}
+	}
+	var versions []int
+	for version := range applied {
+		if version < 1 || version > Latest(migrations) {
+			versions = append(versions, version)
+		}
+	}
+	sort.Ints(versions)
+	for _, version := range versions {
+		unknown = append(unknown, fmt.Sprint(version))
+	}
+
+	switch {
+	case len(unknown) > 0:
+		return fmt.Errorf("database has migrations %s that this build does not know, it expects version %d", strings.Join(unknown, ", "), Latest(migrations))
+	case len(missing) > 0:
+		return fmt.Errorf("database is missing migrations %s, it must be migrated up to version %d", strings.Join(missing, ", "), Latest(migrations))
+	}
+	return nil
+}
+
+// Migrator applies migrations to a database.
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+// New returns a migrator for the database, refusing misnumbered migrations.
+func New(db *mongo.Database, migrations []Migration) (*Migrator, error) {
+	if err := Validate(migrations); err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// applied reads the records of the applied migrations.
+func (m *Migrator) applied(ctx context.Context) (map[int]Record, error) {
+	cursor, err := m.db.Collection(Collection).Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("reading applied migrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var records []Record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("decoding applied migrations: %w", err)
+	}
+
+	applied := make(map[int]Record, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// versions returns the versions applied, leaving out those a run holds.
+func versions(records map[int]Record) map[int]bool {
+	applied := make(map[int]bool, len(records))
+	for version, record := range records {
+		if record.State == Applied {
+			applied[version] = true
+		}
+	}
+	return applied
+}
+
+// Status lists the migrations with when each was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Description: migration.Description}
+		if record, ok := records[migration.Version]; ok {
+			status.Running = record.State == Running
+			if record.State == Applied {
+				appliedAt := record.AppliedAt
+				status.AppliedAt = &appliedAt
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Verify checks the database is at the version the migrations bring it to.
+func (m *Migrator) Verify(ctx context.Context) error {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	return Check(m.migrations, versions(records))
+}
+
+// Up applies the migrations not yet applied, up to target or all of them when
+// target is 0, claiming each before applying it and marking it applied after.
+// It returns those applied.
+func (m *Migrator) Up(ctx context.Context, target int) ([]Migration, error) {
+	if target == 0 {
+		target = Latest(m.migrations)
+	}
+	if target < 0 || target > Latest(m.migrations) {
+		return nil, fmt.Errorf("no migration %d, the latest is %d", target, Latest(m.migrations))
+	}
+
+	if _, err := m.db.Collection(Collection).Indexes().CreateOne(ctx, VersionIndex); err != nil {
+		return nil, fmt.Errorf("creating the index claims rely on: %w", err)
+	}
+
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range Pending(m.migrations, versions(records), target) {
+		if err := m.claim(ctx, migration); err != nil {
+			return done, err
+		}
+		if err := migration.Up(ctx, m.db); err != nil {
+			// Free the claim so the migration can be run again once fixed.
+			m.db.Collection(Collection).DeleteOne(ctx, bson.M{"version": migration.Version, "state": Running})
+			return done, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		if err := m.setState(ctx, migration, Running, bson.M{"state": Applied, "appliedat": time.Now().UTC()}); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// claim records a migration as running, failing when another run has
+// recorded it first.
+func (m *Migrator) claim(ctx context.Context, migration Migration) error {
+	record := Record{Version: migration.Version, Description: migration.Description, State: Running, ClaimedAt: time.Now().UTC()}
+	_, err := m.db.Collection(Collection).InsertOne(ctx, record)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("migration %d is claimed by another run; if none is running, unlock it and retry", migration.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("claiming migration %d: %w", migration.Version, err)
+	}
+	return nil
+}
+
+// setState moves a migration's record from one state, setting fields,
+// failing when another run has moved it first.
+func (m *Migrator) setState(ctx context.Context, migration Migration, from string, set bson.M) error {
+	result, err := m.db.Collection(Collection).UpdateOne(ctx, bson.M{"version": migration.Version, "state": from}, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("recording migration %d: %w", migration.Version, err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("migration %d is no longer %s, another run has claimed it", migration.Version, from)
+	}
+	return nil
+}
+
+// Down undoes the applied migrations above target, newest first, claiming
+// each as it starts and removing its record as it succeeds. It returns those
+// undone.
+func (m *Migrator) Down(ctx context.Context, target int) ([]Migration, error) {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := Rollback(m.migrations, versions(records), target)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range steps {
+		if err := m.setState(ctx, migration, Applied, bson.M{"state": Running, "claimedat": time.Now().UTC()}); err != nil {
+			return done, err
+		}
+		if err := migration.Down(ctx, m.db); err != nil {
+			m.setState(ctx, migration, Running, bson.M{"state": Applied})
+			return done, fmt.Errorf("undoing migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		if _, err := m.db.Collection(Collection).DeleteOne(ctx, bson.M{"version": migration.Version}); err != nil {
+			return done, fmt.Errorf("removing the record of migration %d: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Unlock releases the claims of runs that died holding them, of every
+// migration or only of version when it is not 0. A claim to apply a migration
+// is removed, leaving it pending; one to undo an applied migration is put back
+// to applied, though its Down may have been part done. Only unlock when no run
+// is going. It returns the versions unlocked.
+func (m *Migrator) Unlock(ctx context.Context, version int) ([]int, error) {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var unlocked []int
+	for _, record := range records {
+		if record.State != Running || (version != 0 && record.Version != version) {
+			continue
+		}
+		filter := bson.M{"version": record.Version, "state": Running}
+		if record.AppliedAt.IsZero() {
+			_, err = m.db.Collection(Collection).DeleteOne(ctx, filter)
+		} else {
+			_, err = m.db.Collection(Collection).UpdateOne(ctx, filter, bson.M{"$set": bson.M{"state": Applied}})
+		}
+		if err != nil {
+			return unlocked, fmt.Errorf("unlocking migration %d: %w", record.Version, err)
+		}
+		unlocked = append(unlocked, record.Version)
+	}
+	sort.Ints(unlocked)
+	return unlocked, nil
+}
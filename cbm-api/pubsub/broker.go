@@ -0,0 +1,78 @@
+// Package pubsub fans records written by mutations out to GraphQL subscribers.
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind by before
+// further events are dropped for it.
+const subscriberBuffer = 32
+
+type subscriber[T any] struct {
+	events chan T
+	keep   func(T) bool
+}
+
+// Broker publishes events of one type to every current subscriber. A slow
+// subscriber misses events rather than holding up the mutation publishing them.
+type Broker[T any] struct {
+	name        string
+	mu          sync.RWMutex
+	next        int
+	subscribers map[int]*subscriber[T]
+}
+
+// NewBroker returns a broker with no subscribers. The name is used in logs.
+func NewBroker[T any](name string) *Broker[T] {
+	return &Broker[T]{name: name, subscribers: map[int]*subscriber[T]{}}
+}
+
+// Subscribe returns a channel of the events keep accepts, or of all events if
+// keep is nil. The channel is closed once ctx is done.
+func (b *Broker[T]) Subscribe(ctx context.Context, keep func(T) bool) <-chan T {
+	sub := &subscriber[T]{events: make(chan T, subscriberBuffer), keep: keep}
+
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, id)
+		close(sub.events)
+		b.mu.Unlock()
+	}()
+
+	return sub.events
+}
+
+// Publish sends the event to every subscriber that keeps it, without blocking.
+func (b *Broker[T]) Publish(event T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for id, sub := range b.subscribers {
+		if sub.keep != nil && !sub.keep(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			log.Warn().Str("Broker", b.name).Int("Subscriber", id).Msg("Subscriber is behind, dropping event")
+		}
+	}
+}
+
+// Subscribers returns the number of current subscribers.
+func (b *Broker[T]) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
@@ -1,18 +1,29 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
+	"cryptobotmanager.com/cbm-backend/cbm-api/auth"
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/generated"
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/resolvers"
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
 	log "github.com/rs/zerolog/log"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 const defaultPort = "8080"
@@ -26,19 +37,40 @@ func main() {
 		fmt.Println(envVar)
 	}
 
+	// Tokens are signed with JWT_SECRET, so the API cannot log anyone in without it
+	if err := auth.SetSecret(os.Getenv("JWT_SECRET")); err != nil {
+		log.Fatal().Err(err).Msg("Set JWT_SECRET to the key login tokens are signed with")
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = defaultPort
 	}
 
+	// Refuse to serve a database this build's migrations have not been applied to
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	err := resolvers.VerifySchema(ctx)
+	cancel()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Database schema is not at the expected version, run dataManager -mode migrate -action up")
+	}
+
 	// Create a GraphQL server
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: &resolvers.Resolver{}}))
+	srv := newServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolvers.NewResolver()}))
 
 	// Use a middleware to set CORS headers for the root path (GraphQL Playground)
 	http.Handle("/", corsMiddleware(playground.Handler("GraphQL playground", "/query")))
 
-	// Use a middleware to set CORS headers for the /query path (GraphQL endpoint)
-	http.Handle("/query", corsMiddleware(srv))
+	// Use a middleware to set CORS headers for the /query path (GraphQL endpoint),
+	// and identify the user from their token
+	http.Handle("/query", corsMiddleware(auth.Middleware(srv)))
+
+	// Serve each user's iCalendar feed, which its token in the path authenticates
+	http.Handle("/calendar/", resolvers.CalendarHandler())
+
+	// Back up and restore the database, for admins
+	http.Handle("/backup", auth.Middleware(resolvers.BackupHandler()))
+	http.Handle("/restore", auth.Middleware(resolvers.RestoreHandler()))
 
 	log.Info().Str("Port", port).Msg("connect to http://localhost: for GraphQL playground on:")
 
@@ -57,12 +89,60 @@ func main() {
 	<-stop
 }
 
+// newServer builds the GraphQL server with the same transports and extensions
+// as handler.NewDefaultServer, except that the websocket transport used by
+// subscriptions accepts any origin, matching the CORS headers on /query.
+func newServer(es graphql.ExecutableSchema) *handler.Server {
+	srv := handler.New(es)
+
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              auth.WebsocketInit,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	srv.SetErrorPresenter(presentError)
+	srv.AroundOperations(resolvers.AttachLoaders)
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New[string](100),
+	})
+
+	return srv
+}
+
+// presentError adds the details of errors that carry them, such as the task
+// workflow's, to the extensions of the GraphQL error.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var extended interface{ Extensions() map[string]interface{} }
+	if errors.As(err, &extended) {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]interface{}{}
+		}
+		for key, value := range extended.Extensions() {
+			gqlErr.Extensions[key] = value
+		}
+	}
+	return gqlErr
+}
+
 // corsMiddleware is a middleware function to set CORS headers
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, Cache-Control, Pragma")
 
 		// Handle preflight requests
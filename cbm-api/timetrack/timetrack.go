@@ -0,0 +1,205 @@
+// Package timetrack totals the time recorded against tasks, and weighs the
+// effort still estimated for open tasks against their due dates.
+package timetrack
+
+import (
+	"sort"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+)
+
+// Seconds returns how much of the entry falls between from and to. A running
+// entry counts until now, and an entry with unparsable times counts for
+// nothing.
+func Seconds(entry *model.TimeEntry, from, to, now time.Time) int64 {
+	start, err := time.Parse(time.RFC3339, entry.StartedAt)
+	if err != nil {
+		return 0
+	}
+	stop := now
+	if entry.StoppedAt != nil {
+		if stop, err = time.Parse(time.RFC3339, *entry.StoppedAt); err != nil {
+			return 0
+		}
+	}
+
+	if start.Before(from) {
+		start = from
+	}
+	if stop.After(to) {
+		stop = to
+	}
+	if !stop.After(start) {
+		return 0
+	}
+	return int64(stop.Sub(start) / time.Second)
+}
+
+// Minutes rounds seconds to the nearest minute.
+func Minutes(seconds int64) int {
+	return int((seconds + 30) / 60)
+}
+
+// totals adds up seconds by key, "" for entries without one.
+type totals struct {
+	seconds map[string]int64
+	names   map[string]string
+}
+
+func newTotals() *totals {
+	return &totals{seconds: map[string]int64{}, names: map[string]string{}}
+}
+
+func (t *totals) add(key *string, seconds int64) {
+	k := ""
+	if key != nil {
+		k = *key
+	}
+	t.seconds[k] += seconds
+}
+
+// list returns the totals with the most time first.
+func (t *totals) list() []*model.TimeTotal {
+	list := make([]*model.TimeTotal, 0, len(t.seconds))
+	for key, seconds := range t.seconds {
+		total := &model.TimeTotal{Minutes: Minutes(seconds)}
+		if key != "" {
+			k, name := key, key
+			if n, ok := t.names[key]; ok {
+				name = n
+			}
+			total.Key, total.Name = &k, &name
+		}
+		list = append(list, total)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Minutes != list[j].Minutes {
+			return list[i].Minutes > list[j].Minutes
+		}
+		return keyOf(list[i].Key) < keyOf(list[j].Key)
+	})
+	return list
+}
+
+func keyOf(key *string) string {
+	if key == nil {
+		return ""
+	}
+	return *key
+}
+
+// Report totals the time of the entries between from and to by the
+// department, assignee and project of their tasks, and by the user who
+// tracked it. tasks holds the entries' tasks by ID, and projectTitles the
+// titles of their projects; entries of tasks since deleted count towards the
+// total only.
+func Report(entries []*model.TimeEntry, tasks map[string]*model.Task, projectTitles map[string]string, from, to, now time.Time) *model.TimeReport {
+	byDepartment, byAssignee, byUser, byProject := newTotals(), newTotals(), newTotals(), newTotals()
+	byProject.names = projectTitles
+
+	var total int64
+	for _, entry := range entries {
+		seconds := Seconds(entry, from, to, now)
+		if seconds == 0 {
+			continue
+		}
+		total += seconds
+		byUser.add(&entry.UserID, seconds)
+
+		task, ok := tasks[entry.TaskID]
+		if !ok {
+			continue
+		}
+		byDepartment.add(task.Department, seconds)
+		byAssignee.add(task.AssignedTo, seconds)
+		byProject.add(task.ProjectID, seconds)
+	}
+
+	return &model.TimeReport{
+		From:         from.Format(time.RFC3339),
+		To:           to.Format(time.RFC3339),
+		TotalMinutes: Minutes(total),
+		ByDepartment: byDepartment.list(),
+		ByAssignee:   byAssignee.list(),
+		ByUser:       byUser.list(),
+		ByProject:    byProject.list(),
+	}
+}
+
+// Remaining returns the minutes of a task's estimate not yet spent, and
+// whether it has an estimate.
+func Remaining(task *model.Task) (int, bool) {
+	if task.Estimate == nil {
+		return 0, false
+	}
+	remaining := *task.Estimate
+	if task.Duration != nil {
+		remaining -= *task.Duration
+	}
+	return max(remaining, 0), true
+}
+
+// Workload weighs the remaining effort of the open tasks of each assignee
+// against their due dates: overdue by now, due within days, or later. The
+// assignees with the most effort remaining come first.
+func Workload(tasks []*model.Task, now time.Time, days int) []*model.AssigneeWorkload {
+	soon := now.AddDate(0, 0, days)
+
+	byAssignee := map[string]*model.AssigneeWorkload{}
+	for _, task := range tasks {
+		if !gtd.Open(task.Status) {
+			continue
+		}
+
+		key := keyOf(task.AssignedTo)
+		workload, ok := byAssignee[key]
+		if !ok {
+			workload = &model.AssigneeWorkload{AssignedTo: task.AssignedTo}
+			byAssignee[key] = workload
+		}
+		workload.OpenTasks++
+
+		if task.DueDate != nil {
+			if _, _, ok := gtd.ParseDate(*task.DueDate); ok && (workload.NextDueDate == nil || earlier(*task.DueDate, *workload.NextDueDate)) {
+				workload.NextDueDate = task.DueDate
+			}
+		}
+
+		remaining, estimated := Remaining(task)
+		if !estimated {
+			workload.UnestimatedTasks++
+			continue
+		}
+		workload.RemainingMinutes += remaining
+
+		switch {
+		case gtd.Overdue(task.DueDate, now):
+			workload.OverdueMinutes += remaining
+		case gtd.Due(task.DueDate, soon):
+			workload.DueSoonMinutes += remaining
+		default:
+			workload.LaterMinutes += remaining
+		}
+	}
+
+	workloads := make([]*model.AssigneeWorkload, 0, len(byAssignee))
+	for _, workload := range byAssignee {
+		workloads = append(workloads, workload)
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].RemainingMinutes != workloads[j].RemainingMinutes {
+			return workloads[i].RemainingMinutes > workloads[j].RemainingMinutes
+		}
+		return keyOf(workloads[i].AssignedTo) < keyOf(workloads[j].AssignedTo)
+	})
+	return workloads
+}
+
+// earlier reports whether due date a falls before b.
+func earlier(a, b string) bool {
+	ta, _, _ := gtd.ParseDate(a)
+	tb, _, _ := gtd.ParseDate(b)
+	return ta.Before(tb)
+}
@@ -0,0 +1,409 @@
+package functions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	trade "cryptobotmanager.com/cbm-backend/microservices/externalDataAPIs/streamPrices"
+	reports "cryptobotmanager.com/cbm-backend/microservices/reports/functions"
+	tradingBots "cryptobotmanager.com/cbm-backend/microservices/tradingBots/functions"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/rs/zerolog/log"
+)
+
+// snapshotInterval is the number of seconds between price snapshots.
+const snapshotInterval = 300
+
+// PriceSeries holds a run of price snapshots in memory, indexed by timestamp,
+// so that many strategies can be replayed over it without touching the database.
+type PriceSeries struct {
+	Timestamps []int
+	DatasetID  string
+	prices     map[int]map[string]float64
+	onTheMove  map[int][]shared.Gainers
+	fearGreed  map[int]FearGreedReading // by the start of the UTC day
+}
+
+// FearGreedReading is the Fear & Greed index in force for a day.
+type FearGreedReading struct {
+	Index          int
+	Classification string
+}
+
+// BacktestTrade is a single simulated position from entry to exit.
+type BacktestTrade struct {
+	Symbol           string
+	Outcome          string
+	OpenTime         int
+	CloseTime        int
+	OpenPrice        float64
+	ClosePrice       float64
+	PercentageChange float64
+	Fees             float64
+	Balance          float64
+}
+
+// BacktestResult is the outcome of replaying one strategy over a PriceSeries.
+type BacktestResult struct {
+	Strategy        model.StrategyInput
+	FeePercentage   float64
+	From, To        int
+	StartingBalance float64
+	EndingBalance   float64
+	Trades          []BacktestTrade
+}
+
+// NewPriceSeries indexes the snapshots by timestamp and works out, once for
+// all strategies, which pairs were on the move at each snapshot. A pair is on
+// the move when its price rose by at least marketMomentum percent since the
+// previous snapshot, the same rule FirstFilter applies to live prices.
+func NewPriceSeries(snapshots []model.NewHistoricPriceInput, marketMomentum float64) *PriceSeries {
+	series := &PriceSeries{
+		prices:    make(map[int]map[string]float64),
+		onTheMove: make(map[int][]shared.Gainers),
+		fearGreed: make(map[int]FearGreedReading),
+	}
+
+	for _, snapshot := range snapshots {
+		if len(snapshot.Pairs) == 0 {
+			continue
+		}
+		prices, exists := series.prices[snapshot.Timestamp]
+		if !exists {
+			prices = make(map[string]float64, len(snapshot.Pairs))
+			series.prices[snapshot.Timestamp] = prices
+			series.Timestamps = append(series.Timestamps, snapshot.Timestamp)
+		}
+		for _, pair := range snapshot.Pairs {
+			price, err := strconv.ParseFloat(pair.Price, 64)
+			if err != nil || price <= 0 {
+				continue
+			}
+			prices[pair.Symbol] = price
+		}
+	}
+	sort.Ints(series.Timestamps)
+
+	for _, ts := range series.Timestamps {
+		previous, found := series.prices[ts-snapshotInterval]
+		if !found {
+			continue
+		}
+		var gainers []shared.Gainers
+		for symbol, price := range series.prices[ts] {
+			previousPrice, found := previous[symbol]
+			if !found {
+				continue
+			}
+			change := shared.PercentageChange(previousPrice, price)
+			if change >= marketMomentum {
+				gainers = append(gainers, shared.Gainers{Symbol: symbol, IncrementPriceGain: change})
+			}
+		}
+		sort.Slice(gainers, func(i, j int) bool {
+			if gainers[i].IncrementPriceGain == gainers[j].IncrementPriceGain {
+				return gainers[i].Symbol < gainers[j].Symbol
+			}
+			return gainers[i].IncrementPriceGain > gainers[j].IncrementPriceGain
+		})
+		series.onTheMove[ts] = gainers
+	}
+	series.DatasetID = series.fingerprint()
+
+	return series
+}
+
+// fingerprint hashes every timestamp, symbol and price in order, so two series
+// built from the same snapshots share an ID however the files were laid out.
+func (s *PriceSeries) fingerprint() string {
+	hash := sha256.New()
+	for _, ts := range s.Timestamps {
+		symbols := make([]string, 0, len(s.prices[ts]))
+		for symbol := range s.prices[ts] {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+
+		fmt.Fprintf(hash, "%d\n", ts)
+		for _, symbol := range symbols {
+			fmt.Fprintf(hash, "%s=%g\n", symbol, s.prices[ts][symbol])
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// Window returns the part of the series with timestamps from start up to but
+// not including end. Earlier prices stay available for SMA look-back, as they
+// would have been known at the time.
+func (s *PriceSeries) Window(start, end int) *PriceSeries {
+	window := &PriceSeries{prices: s.prices, onTheMove: s.onTheMove, fearGreed: s.fearGreed}
+	for _, ts := range s.Timestamps {
+		if ts >= start && ts < end {
+			window.Timestamps = append(window.Timestamps, ts)
+		}
+	}
+	window.DatasetID = window.fingerprint()
+	return window
+}
+
+// SetFearGreed records the Fear & Greed reading in force on the day of the
+// timestamp.
+func (s *PriceSeries) SetFearGreed(ts int, reading FearGreedReading) {
+	s.fearGreed[ts-ts%secondsPerDay] = reading
+}
+
+// FearGreed returns the Fear & Greed reading in force at the timestamp, with
+// an unknown index if none was recorded for its day.
+func (s *PriceSeries) FearGreed(ts int) FearGreedReading {
+	if reading, found := s.fearGreed[ts-ts%secondsPerDay]; found {
+		return reading
+	}
+	return FearGreedReading{Index: reports.UnknownFearGreedIndex}
+}
+
+// LoadFearGreed looks up the Fear & Greed reading in force on each day of the
+// series, so strategies gated on sentiment trade as they would have live.
+// Days whose lookup fails are left unknown, as the live filters treat them.
+func LoadFearGreed(ctx context.Context, client graphql.Client, series *PriceSeries) {
+	for _, ts := range series.Timestamps {
+		day := ts - ts%secondsPerDay
+		if _, found := series.fearGreed[day]; found {
+			continue
+		}
+		index, classification, err := reports.ResolveFearGreedIndex(ctx, client, day)
+		if err != nil {
+			log.Error().Err(err).Int("Day", day).Msg("Failed to resolve fear and greed index")
+		}
+		series.fearGreed[day] = FearGreedReading{Index: index, Classification: classification}
+	}
+}
+
+// Price returns the price of the symbol at the given timestamp.
+func (s *PriceSeries) Price(ts int, symbol string) (float64, bool) {
+	price, found := s.prices[ts][symbol]
+	return price, found
+}
+
+// sma returns the mean price of the symbol over the given number of snapshots
+// ending at ts, or false if any of those snapshots is missing the symbol.
+func (s *PriceSeries) sma(ts int, symbol string, periods int) (float64, bool) {
+	if periods <= 0 {
+		return 0, false
+	}
+	var total float64
+	for i := 0; i < periods; i++ {
+		price, found := s.Price(ts-i*snapshotInterval, symbol)
+		if !found {
+			return 0, false
+		}
+		total += price
+	}
+	return total / float64(periods), true
+}
+
+// selectTicker mirrors the live filters: of the pairs on the move, the one with
+// the biggest increment whose short SMA is above its long SMA by more than the
+// strategy's MovingAveMomentum is chosen. Liquidity and ATR are not applied as
+// that data is not part of the price snapshots.
+func (s *PriceSeries) selectTicker(ts int, details model.StrategyInput) string {
+	for _, gainer := range s.onTheMove[ts] {
+		shortAvg, ok := s.sma(ts, gainer.Symbol, details.ShortSMADuration)
+		if !ok {
+			continue
+		}
+		longAvg, ok := s.sma(ts, gainer.Symbol, details.LongSMADuration)
+		if !ok {
+			continue
+		}
+		if shortAvg > longAvg && shared.PercentageChange(longAvg, shortAvg) > details.MovingAveMomentum {
+			return gainer.Symbol
+		}
+	}
+	return ""
+}
+
+// RunBacktest replays the strategy over the series, holding at most one position
+// at a time. Exits are checked against each later snapshot, so take profit and
+// stop loss fill at the snapshot price rather than the exact level. A position
+// still open when the data runs out is closed at its last known price. Entries
+// are only made while the day's Fear & Greed reading is within the strategy's
+// sentiment bounds.
+func RunBacktest(series *PriceSeries, details model.StrategyInput, feePercentage float64) BacktestResult {
+	result := BacktestResult{
+		Strategy:        details,
+		FeePercentage:   feePercentage,
+		StartingBalance: details.AccountBalance,
+		EndingBalance:   details.AccountBalance,
+	}
+
+	var open *BacktestTrade
+	var exits shared.TradeValues
+	var lastPrice float64
+	var lastTime int
+
+	closePosition := func(price float64, ts int, outcome string) {
+		change := shared.PercentageChange(open.OpenPrice, price)
+		updatedBalance, fees, _ := trade.CalculateUpdatedBalance(result.EndingBalance, change, feePercentage)
+		open.CloseTime = ts
+		open.ClosePrice = price
+		open.PercentageChange = change
+		open.Fees = fees
+		open.Balance = updatedBalance
+		open.Outcome = outcome
+		result.EndingBalance = updatedBalance
+		result.Trades = append(result.Trades, *open)
+		open = nil
+	}
+
+	for _, ts := range series.Timestamps {
+		if open != nil {
+			price, found := series.Price(ts, open.Symbol)
+			if !found {
+				continue
+			}
+			lastPrice, lastTime = price, ts
+
+			switch {
+			case price >= exits.TakeProfit:
+				closePosition(price, ts, "WIN")
+			case price <= exits.StopLoss:
+				closePosition(price, ts, "LOSS")
+			case ts >= exits.TimedOut:
+				closePosition(price, ts, "TIMED OUT")
+			}
+			continue
+		}
+
+		if sentiment := series.FearGreed(ts); !tradingBots.SentimentAllows(details, sentiment.Index, sentiment.Classification) {
+			continue
+		}
+		symbol := series.selectTicker(ts, details)
+		if symbol == "" {
+			continue
+		}
+		price, _ := series.Price(ts, symbol)
+		open = &BacktestTrade{Symbol: symbol, OpenTime: ts, OpenPrice: price}
+		lastPrice, lastTime = price, ts
+		exits = shared.TradeValues{
+			TimedOut:   ts + details.TradeDuration*60,
+			TakeProfit: price * (1 + details.TakeProfitPercentage/100),
+			StopLoss:   price * (1 - details.StopLossPercentage/100),
+		}
+	}
+
+	if open != nil {
+		closePosition(lastPrice, lastTime, "TIMED OUT")
+	}
+	if len(series.Timestamps) > 0 {
+		result.From = series.Timestamps[0]
+		result.To = series.Timestamps[len(series.Timestamps)-1]
+	}
+
+	return result
+}
+
+// ListPriceFiles returns the binance_prices_YYYY-MM-DD.json files in dataDir,
+// in date order, whose date falls between from and to inclusive.
+func ListPriceFiles(dataDir string, from, to time.Time) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			log.Error().Err(err).Msg("Error walking through files")
+			return err
+		}
+		if d.IsDir() || !strings.HasPrefix(d.Name(), "binance_prices_") || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+
+		day, err := time.Parse("2006-01-02", strings.TrimSuffix(strings.TrimPrefix(d.Name(), "binance_prices_"), ".json"))
+		if err != nil {
+			log.Warn().Str("file", path).Msg("Skipping price file without a date in its name")
+			return nil
+		}
+		if (!from.IsZero() && day.Before(from)) || (!to.IsZero() && day.After(to)) {
+			return nil
+		}
+
+		log.Debug().Str("discovered_file", path).Msg("Found matching price file")
+		files = append(files, path)
+		return nil
+	})
+	sort.Strings(files) // Ensure they’re processed in date order
+
+	return files, err
+}
+
+// LoadPriceSeries loads every snapshot in the files into a single PriceSeries.
+func LoadPriceSeries(files []string, marketMomentum float64) (*PriceSeries, error) {
+	var snapshots []model.NewHistoricPriceInput
+	for _, file := range files {
+		log.Info().Str("File", file).Msg("Loading file")
+		marketData, err := LoadPriceSnapshotsFromFile(file)
+		if err != nil {
+			log.Error().Err(err).Str("file", file).Msg("Failed to load JSON data")
+			return nil, err
+		}
+		snapshots = append(snapshots, marketData...)
+	}
+	return NewPriceSeries(snapshots, marketMomentum), nil
+}
+
+// loadSeriesBetween loads the price files for the chosen days into one
+// PriceSeries, along with the Fear & Greed readings for those days.
+func loadSeriesBetween(ctx context.Context, client graphql.Client, dataDir string, from, to time.Time, marketMomentum float64) (*PriceSeries, error) {
+	files, err := ListPriceFiles(dataDir, from, to)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list price files")
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no price files found in %s for the chosen dates", dataDir)
+	}
+
+	series, err := LoadPriceSeries(files, marketMomentum)
+	if err != nil {
+		return nil, err
+	}
+	if len(series.Timestamps) == 0 {
+		return nil, fmt.Errorf("no price snapshots found in %d files", len(files))
+	}
+	LoadFearGreed(ctx, client, series)
+
+	return series, nil
+}
+
+// BacktestAll runs every strategy over the series, spreading the work across the available CPUs.
+func BacktestAll(series *PriceSeries, strategies []model.StrategyInput, feePercentage float64) []BacktestResult {
+	results := make([]BacktestResult, len(strategies))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = RunBacktest(series, strategies[i], feePercentage)
+			}
+		}()
+	}
+	for i := range strategies {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
@@ -11,24 +11,48 @@ import (
 	reports "cryptobotmanager.com/cbm-backend/microservices/reports/functions"
 	tradingBots "cryptobotmanager.com/cbm-backend/microservices/tradingBots/functions"
 	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/messaging"
 	"github.com/Khan/genqlient/graphql"
 	"github.com/rs/zerolog/log"
 )
 
+// LetsTrade runs a whole tick in process: it reports on the market, selects
+// the trades each strategy wants and paper trades them, returning once every
+// trade has closed. Services on the event bus run these steps separately.
 func LetsTrade(ctx context.Context, client graphql.Client, market []model.Pair, currentDatetime int) error {
+	reports.ReportOnMarket(client, market, currentDatetime)
+
+	signals, err := SelectTrades(ctx, client, market, currentDatetime)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, signal := range signals {
+		wg.Add(1)
+		go func(signal messaging.TradeSignal) {
+			defer wg.Done()
+			trade.ListenAndPaperTrade(ctx, client, nil, signal.Symbol, signal.Strategy, nil)
+		}(signal)
+	}
+	wg.Wait()
+	return nil
+}
+
+// SelectTrades runs the market through the filters and returns a signal for
+// each strategy that has found a symbol to trade.
+func SelectTrades(ctx context.Context, client graphql.Client, market []model.Pair, currentDatetime int) ([]messaging.TradeSignal, error) {
 
 	cfg := shared.GetDefaultCfg()
 
-	// Report on the market activity
 	PairsOnTheMove, err := filter.FirstFilter(market, cfg.ActiveMarketThreshold)
 	if err != nil {
 		log.Error().Msgf("Pairs on the move!")
 	}
 
 	if len(PairsOnTheMove) == 0 {
-		return nil
+		return nil, nil
 	}
-	reports.MarketActivityReport(client, cfg.TopAverages, PairsOnTheMove, currentDatetime)
 	fmt.Println("")
 	fmt.Println("")
 	fmt.Println("")
@@ -44,7 +68,7 @@ func LetsTrade(ctx context.Context, client graphql.Client, market []model.Pair,
 	}
 	if len(LiquidPairsOnTheMove) == 0 {
 		log.Warn().Msg("No liquid pairs found")
-		return nil
+		return nil, nil
 	}
 	log.Info().Int("Qty", len(LiquidPairsOnTheMove)).Msg("Pairs passing liquidity threshold")
 
@@ -55,9 +79,24 @@ func LetsTrade(ctx context.Context, client graphql.Client, market []model.Pair,
 		log.Error().Msgf("Failed to get strategy details!")
 	}
 
+	// Resolve the Fear & Greed index once, for the strategies that gate on it
+	fearGreedIndex, sentiment, err := reports.ResolveFearGreedIndex(ctx, client, currentDatetime)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to resolve fear and greed index")
+	}
+
 	// Start a goroutine for each bot
-	var wg sync.WaitGroup
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		signals []messaging.TradeSignal
+	)
 	for _, details := range strategyDetails {
+		if !tradingBots.SentimentAllows(details, fearGreedIndex, sentiment) {
+			log.Info().Str("Name", details.BotInstanceName).Int("FearGreedIndex", fearGreedIndex).Str("Sentiment", sentiment).Msg("Sentiment outside strategy bounds, not trading")
+			continue
+		}
+
 		wg.Add(1)
 		go func(details model.StrategyInput) {
 			defer wg.Done()
@@ -73,63 +112,53 @@ func LetsTrade(ctx context.Context, client graphql.Client, market []model.Pair,
 				log.Error().Msgf("coins With Momentum")
 			}
 
-			length := 0
-			// Check if coinsWithMomentum is nil before using it
-			if coinsWithMomentum != nil {
-				length = len(*coinsWithMomentum)
-				log.Info().Int("Qty", length).Msg("Coins with Market Momentum")
-
-				if length == 0 {
-					return
-				}
-
-				if length > 0 {
-					fmt.Println("")
-					log.Info().Msg("THIRD FILTER (Volatility)")
-					log.Debug().Msg("Get Current Price And Calculate Average True Range")
-
-					if len(*coinsWithMomentum) > 0 {
-						chosenTicker := (*coinsWithMomentum)[0].Symbol
-
-						log.Info().
-							Str("Chosen Ticker (bypassed ATR)", chosenTicker).
-							Msg("Paper Trading - Temporary Selection Without ATR")
-
-						// Optionally call the MakeTrade or just log it for now
-						trade.ListenAndPaperTrade(ctx, client, chosenTicker, details)
-					}
-
-					// for _, coin := range *coinsWithMomentum {
-					// 	percentageGain, err := goBot.GetATR(tradeKlines, coin.Symbol, details.TradeDuration, details.IncrementsATR)
-					// 	if err != nil {
-					// 		log.Error().Msgf("Third filter percentage gain")
-					// 	}
-
-					// 	log.Debug().Str("Symbol", coin.Symbol).Float64("ATR Percentage Gain", percentageGain).Msg("Change")
-
-					// 	for i := range *coinsWithMomentum {
-					// 		if (*coinsWithMomentum)[i].Symbol == coin.Symbol {
-					// 			(*coinsWithMomentum)[i].ATR = percentageGain
-					// 			break
-					// 		}
-					// 	}
-					// }
-
-					// _, chosenTicker, _, err = goBot.FilterByAverageTrueRange(*coinsWithMomentum, details.MovingAveMomentum, cfg.WeightSMA, cfg.WeightATR, details.BotInstanceName)
-					// if err != nil {
-					// 	log.Error().Msgf("Filter By Average True Range!")
-					// }
-
-					// log.Info().Str("Chosen Ticker", chosenTicker).Msg("Paper Tading")
-					// goBot.MakeTrade(client, chosenTicker, scenarioType, details)
-
-				} else {
-					log.Warn().Msg("coinsWithMomentum is nil")
-					return
-				}
+			if coinsWithMomentum == nil {
+				log.Warn().Msg("coinsWithMomentum is nil")
+				return
+			}
+			log.Info().Int("Qty", len(*coinsWithMomentum)).Msg("Coins with Market Momentum")
+			if len(*coinsWithMomentum) == 0 {
+				return
 			}
+
+			fmt.Println("")
+			log.Info().Msg("THIRD FILTER (Volatility)")
+			log.Debug().Msg("Get Current Price And Calculate Average True Range")
+
+			chosenTicker := (*coinsWithMomentum)[0].Symbol
+			log.Info().
+				Str("Chosen Ticker (bypassed ATR)", chosenTicker).
+				Msg("Paper Trading - Temporary Selection Without ATR")
+
+			mu.Lock()
+			signals = append(signals, messaging.TradeSignal{Timestamp: currentDatetime, Symbol: chosenTicker, Strategy: details})
+			mu.Unlock()
+
+			// for _, coin := range *coinsWithMomentum {
+			// 	percentageGain, err := goBot.GetATR(tradeKlines, coin.Symbol, details.TradeDuration, details.IncrementsATR)
+			// 	if err != nil {
+			// 		log.Error().Msgf("Third filter percentage gain")
+			// 	}
+
+			// 	log.Debug().Str("Symbol", coin.Symbol).Float64("ATR Percentage Gain", percentageGain).Msg("Change")
+
+			// 	for i := range *coinsWithMomentum {
+			// 		if (*coinsWithMomentum)[i].Symbol == coin.Symbol {
+			// 			(*coinsWithMomentum)[i].ATR = percentageGain
+			// 			break
+			// 		}
+			// 	}
+			// }
+
+			// _, chosenTicker, _, err = goBot.FilterByAverageTrueRange(*coinsWithMomentum, details.MovingAveMomentum, cfg.WeightSMA, cfg.WeightATR, details.BotInstanceName)
+			// if err != nil {
+			// 	log.Error().Msgf("Filter By Average True Range!")
+			// }
+
+			// log.Info().Str("Chosen Ticker", chosenTicker).Msg("Paper Tading")
+			// goBot.MakeTrade(client, chosenTicker, scenarioType, details)
 		}(details)
 	}
 	wg.Wait()
-	return nil
+	return signals, nil
 }
@@ -2,93 +2,122 @@ package functions
 
 import (
 	"context"
-	"io/fs"
 	"net/http"
-	"path/filepath"
-	"sort"
-	"strings"
+	"time"
 
-	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
-	filter "cryptobotmanager.com/cbm-backend/microservices/filters/functions"
+	tradingBots "cryptobotmanager.com/cbm-backend/microservices/tradingBots/functions"
 	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/graph"
 	"github.com/Khan/genqlient/graphql"
 	"github.com/rs/zerolog/log"
 )
 
-func CSVPrices(backend string) error {
+// ReplayPrices replays the price files between from and to (zero for no bound)
+// through the in-memory backtest engine for every strategy under test, and
+// stores each result as a BacktestRun. Prices are never written to the live
+// HistoricPrices collection and no trades are opened against Binance, so the
+// same files always give the same runs.
+func ReplayPrices(backend, dataDir string, from, to time.Time) error {
 	client := graphql.NewClient(backend, &http.Client{})
 	ctx := context.Background()
+	cfg := shared.GetDefaultCfg()
 
-	// Directory with your JSON price files
-	dataDir := "binancePrices"
+	series, err := loadSeriesBetween(ctx, client, dataDir, from, to, cfg.ActiveMarketThreshold)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msg("Loading strategy Details ...")
+	strategyDetails, err := tradingBots.GetParameters(ctx, client)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get strategy details!")
+		return err
+	}
 
-	// Read and sort files chronologically
-	var files []string
-	filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, err error) error {
+	createdOn := int(time.Now().Unix())
+	for _, result := range BacktestAll(series, strategyDetails, cfg.FeePercentage) {
+		runID, err := SaveBacktestRun(ctx, client, series, result, createdOn)
 		if err != nil {
-			log.Error().Err(err).Msg("Error walking through files")
+			log.Error().Err(err).Str("Bot", result.Strategy.BotInstanceName).Msg("Failed to save backtest run")
 			return err
 		}
-		if !d.IsDir() && strings.HasPrefix(d.Name(), "binance_prices_") && strings.HasSuffix(d.Name(), ".json") {
-			log.Debug().Str("discovered_file", path).Msg("Found matching price file")
-			files = append(files, path)
-		}
-		return nil
-	})
-	sort.Strings(files) // Ensure they’re processed in date order
+		log.Info().Str("Bot", result.Strategy.BotInstanceName).Str("RunID", runID).Int("Trades", len(result.Trades)).Float64("Balance", result.EndingBalance).Msg("Backtest complete")
+	}
+	return nil
+}
 
-	// Loop through each file and "replay" the data
-	for _, file := range files {
-		log.Info().Str("File", file).Msg("Processing file")
+// SaveBacktestRun stores the result in the BacktestRuns collection and returns its ID.
+func SaveBacktestRun(ctx context.Context, client graphql.Client, series *PriceSeries, result BacktestResult, createdOn int) (string, error) {
+	metrics := result.Metrics()
+	input := graph.BacktestRunInput{
+		BotInstanceName: result.Strategy.BotInstanceName,
+		Parameters: graph.BacktestParametersInput{
+			TradeDuration:        result.Strategy.TradeDuration,
+			IncrementsATR:        result.Strategy.IncrementsAtr,
+			LongSMADuration:      result.Strategy.LongSMADuration,
+			ShortSMADuration:     result.Strategy.ShortSMADuration,
+			MovingAveMomentum:    result.Strategy.MovingAveMomentum,
+			TakeProfitPercentage: result.Strategy.TakeProfitPercentage,
+			StopLossPercentage:   result.Strategy.StopLossPercentage,
+			FeePercentage:        result.FeePercentage,
+		},
+		DatasetID:       series.DatasetID,
+		From:            result.From,
+		To:              result.To,
+		StartingBalance: result.StartingBalance,
+		EndingBalance:   result.EndingBalance,
+		CreatedOn:       createdOn,
+		Stats: graph.BacktestStatsInput{
+			Trades:       metrics.Trades,
+			NetPnL:       metrics.NetPnL,
+			WinRate:      metrics.WinRate,
+			CAGR:         metrics.CAGR,
+			MaxDrawdown:  metrics.MaxDrawdown,
+			Sharpe:       metrics.Sharpe,
+			Sortino:      metrics.Sortino,
+			ProfitFactor: metrics.ProfitFactor,
+			Expectancy:   metrics.Expectancy,
+			AvgHoldTime:  metrics.AvgHoldTime,
+			Exposure:     metrics.Exposure,
+		},
+		EquityCurve: []graph.EquityPointInput{},
+		Trades:      []graph.BacktestTradeInput{},
+	}
+	if result.Strategy.ATRtollerance != nil {
+		input.Parameters.ATRtollerance = *result.Strategy.ATRtollerance
+	}
+	for _, p := range result.EquityCurve() {
+		input.EquityCurve = append(input.EquityCurve, graph.EquityPointInput{Timestamp: p.Timestamp, Balance: p.Balance})
+	}
 
-		marketData, err := LoadPriceSnapshotsFromFile(file)
-		if err != nil {
-			log.Error().Err(err).Str("file", file).Msg("Failed to load JSON data")
-			continue
+	for _, t := range result.Trades {
+		switch t.Outcome {
+		case "WIN":
+			input.WINCounter++
+		case "LOSS":
+			input.LOSSCounter++
+		default:
+			input.TIMEOUTCounter++
 		}
+		input.FeesTotal += t.Fees
+		input.Trades = append(input.Trades, graph.BacktestTradeInput{
+			Symbol:           t.Symbol,
+			Outcome:          t.Outcome,
+			OpenTime:         t.OpenTime,
+			CloseTime:        t.CloseTime,
+			OpenPrice:        t.OpenPrice,
+			ClosePrice:       t.ClosePrice,
+			PercentageChange: t.PercentageChange,
+			Fee:              t.Fees,
+			Balance:          t.Balance,
+		})
+	}
 
-		for _, snapshot := range marketData {
-			var currentPrices []model.Pair
-			for _, p := range snapshot.Pairs {
-				currentPrices = append(currentPrices, model.Pair{
-					Symbol: p.Symbol,
-					Price:  p.Price,
-				})
-			}
-
-			if len(currentPrices) == 0 {
-				log.Warn().
-					Int("timestamp", snapshot.Timestamp).
-					Msg("Skipping snapshot due to empty market data")
-				continue
-			}
-
-			previousTime := int(snapshot.Timestamp) - 300
-
-			previousPrices, err := filter.GetPriceData(ctx, client, previousTime, "Gopher")
-			if err != nil {
-				log.Error().Err(err).Msgf("Failed to get previous price data!")
-				return err
-			}
-
-			currentPrices, err = filter.EnrichWithPercentageChange(currentPrices, previousPrices)
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to enrich prices with % change")
-				return err
-			}
-
-			if err := shared.SavePriceData(ctx, client, currentPrices, int(snapshot.Timestamp)); err != nil {
-				log.Error().Err(err).Int("timestamp", snapshot.Timestamp).Msg("Save PriceData")
-			}
-
-			// Start trading
-			err = LetsTrade(ctx, client, currentPrices, int(snapshot.Timestamp))
-			if err != nil {
-				log.Error().Err(err).Int("timestamp", snapshot.Timestamp).Msg("lets trade")
-			}
-		}
+	resp, err := graph.CreateBacktestRun(ctx, client, input)
+	if err != nil {
+		return "", err
 	}
-	return nil
+	return resp.CreateBacktestRun.RunID, nil
 }
 
 // func BinancePrices(backend string) error {
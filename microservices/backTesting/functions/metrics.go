@@ -0,0 +1,177 @@
+package functions
+
+import (
+	"math"
+)
+
+// secondsPerYear is used to annualise growth over the replayed period.
+const secondsPerYear = 365.25 * 24 * 60 * 60
+
+// minCAGRSeconds is the shortest period growth is annualised over. Compounding
+// the growth of a shorter one to a year overflows for all but tiny gains.
+const minCAGRSeconds = 30 * 24 * 60 * 60
+
+// BacktestMetrics summarises how a strategy performed in a backtest.
+type BacktestMetrics struct {
+	Trades       int
+	NetPnL       float64
+	WinRate      float64
+	CAGR         float64
+	MaxDrawdown  float64
+	Sharpe       float64
+	Sortino      float64
+	ProfitFactor float64
+	Expectancy   float64
+	AvgHoldTime  float64
+	Exposure     float64
+}
+
+// EquityPoint is the account balance at a moment in the backtest.
+type EquityPoint struct {
+	Timestamp int
+	Balance   float64
+}
+
+// EquityCurve returns the balance at the start of the period and after each
+// trade closes. Open positions are not marked to market between snapshots.
+func (r BacktestResult) EquityCurve() []EquityPoint {
+	curve := []EquityPoint{{Timestamp: r.From, Balance: r.StartingBalance}}
+	for _, t := range r.Trades {
+		curve = append(curve, EquityPoint{Timestamp: t.CloseTime, Balance: t.Balance})
+	}
+	return curve
+}
+
+// Metrics calculates the summary metrics for the result. Win rate counts
+// trades that closed with a net gain after fees, whatever their outcome.
+func (r BacktestResult) Metrics() BacktestMetrics {
+	metrics := BacktestMetrics{
+		Trades: len(r.Trades),
+		NetPnL: r.EndingBalance - r.StartingBalance,
+	}
+	if len(r.Trades) == 0 {
+		return metrics
+	}
+
+	balances := []float64{r.StartingBalance}
+	returns := make([]float64, 0, len(r.Trades))
+	var grossProfit, grossLoss float64
+	var held int
+	wins := 0
+	previous := r.StartingBalance
+	for _, t := range r.Trades {
+		gain := t.Balance - previous
+		if gain > 0 {
+			wins++
+			grossProfit += gain
+		} else {
+			grossLoss -= gain
+		}
+		if previous != 0 {
+			returns = append(returns, gain/previous*100)
+		}
+		held += t.CloseTime - t.OpenTime
+		balances = append(balances, t.Balance)
+		previous = t.Balance
+	}
+
+	metrics.WinRate = float64(wins) / float64(len(r.Trades)) * 100
+	metrics.CAGR = CAGR(r.StartingBalance, r.EndingBalance, r.To-r.From)
+	metrics.MaxDrawdown = MaxDrawdown(balances)
+	metrics.Sharpe = SharpeRatio(returns)
+	metrics.Sortino = SortinoRatio(returns)
+	if grossLoss > 0 {
+		metrics.ProfitFactor = grossProfit / grossLoss
+	}
+	metrics.Expectancy = metrics.NetPnL / float64(len(r.Trades))
+	metrics.AvgHoldTime = float64(held) / float64(len(r.Trades))
+	if r.To > r.From {
+		metrics.Exposure = math.Min(float64(held)/float64(r.To-r.From)*100, 100)
+	}
+
+	return metrics
+}
+
+// CAGR returns the compound annual growth rate, as a percentage, of moving
+// from the starting to the ending balance over the given number of seconds.
+// Periods shorter than 30 days, and growth too large to represent, give zero.
+func CAGR(startingBalance, endingBalance float64, seconds int) float64 {
+	if startingBalance <= 0 || endingBalance <= 0 || seconds < minCAGRSeconds {
+		return 0
+	}
+	cagr := (math.Pow(endingBalance/startingBalance, secondsPerYear/float64(seconds)) - 1) * 100
+	if math.IsInf(cagr, 0) || math.IsNaN(cagr) {
+		return 0
+	}
+	return cagr
+}
+
+// MaxDrawdown returns the largest fall from a running peak to a later trough,
+// as a percentage of the peak.
+func MaxDrawdown(balances []float64) float64 {
+	var peak, maxDrawdown float64
+	for i, balance := range balances {
+		if i == 0 || balance > peak {
+			peak = balance
+			continue
+		}
+		if peak > 0 {
+			drawdown := (peak - balance) / peak * 100
+			if drawdown > maxDrawdown {
+				maxDrawdown = drawdown
+			}
+		}
+	}
+	return maxDrawdown
+}
+
+// SharpeRatio returns the mean of the returns divided by their standard
+// deviation. It is not annualised, so it compares strategies per trade. Fewer
+// than two returns, or returns with no spread, give zero.
+func SharpeRatio(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+
+	mean := meanOf(returns)
+
+	var squares float64
+	for _, r := range returns {
+		squares += (r - mean) * (r - mean)
+	}
+	stdDev := math.Sqrt(squares / float64(len(returns)-1))
+	if stdDev == 0 {
+		return 0
+	}
+
+	return mean / stdDev
+}
+
+// SortinoRatio is SharpeRatio with only the losing returns counted as risk,
+// using the downside deviation below zero. With no losing returns it gives zero.
+func SortinoRatio(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var squares float64
+	for _, r := range returns {
+		if r < 0 {
+			squares += r * r
+		}
+	}
+	downside := math.Sqrt(squares / float64(len(returns)))
+	if downside == 0 {
+		return 0
+	}
+
+	return meanOf(returns) / downside
+}
+
+func meanOf(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,84 @@
+package functions
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/shared"
+)
+
+// GeneratePermutations builds the cartesian product of the parameter ranges as
+// strategies named sweep_<createdOn>_<n>. Combinations where the short SMA is
+// not shorter than the long SMA are skipped as they can never show momentum.
+func GeneratePermutations(ranges shared.ParameterRanges, startingBalance float64, createdOn int) []model.StrategyInput {
+	var strategies []model.StrategyInput
+
+	for _, duration := range ranges.TradeDuration.Values() {
+		for _, short := range ranges.ShortSMADuration.Values() {
+			for _, long := range ranges.LongSMADuration.Values() {
+				if short >= long {
+					continue
+				}
+				for _, momentum := range ranges.MovingAveMomentum.Values() {
+					for _, takeProfit := range ranges.TakeProfitPercentage.Values() {
+						for _, stopLoss := range ranges.StopLossPercentage.Values() {
+							for _, incrementsATR := range ranges.IncrementsATR.Values() {
+								for _, atrTolerance := range ranges.ATRtollerance.Values() {
+									atrTolerance := atrTolerance
+									strategies = append(strategies, model.StrategyInput{
+										BotInstanceName:      fmt.Sprintf("sweep_%d_%04d", createdOn, len(strategies)+1),
+										TradeDuration:        duration,
+										IncrementsAtr:        incrementsATR,
+										LongSMADuration:      long,
+										ShortSMADuration:     short,
+										AccountBalance:       startingBalance,
+										MovingAveMomentum:    momentum,
+										TakeProfitPercentage: takeProfit,
+										StopLossPercentage:   stopLoss,
+										ATRtollerance:        &atrTolerance,
+										Owner:                "sweep",
+										CreatedOn:            createdOn,
+									})
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return strategies
+}
+
+// SamplePermutations reduces the permutations to at most size strategies.
+// "all" (or a size of zero) keeps every permutation, "grid" takes evenly
+// spaced permutations across the product and "random" draws them using the
+// seed so a sweep can be repeated. The original order is preserved.
+func SamplePermutations(strategies []model.StrategyInput, sampling string, size int, seed int64) ([]model.StrategyInput, error) {
+	if sampling != "all" && sampling != "grid" && sampling != "random" {
+		return nil, fmt.Errorf("unknown sampling %q, expected all, grid or random", sampling)
+	}
+	if sampling == "all" || size <= 0 || size >= len(strategies) {
+		return strategies, nil
+	}
+
+	indexes := make([]int, 0, size)
+	switch sampling {
+	case "grid":
+		for i := 0; i < size; i++ {
+			indexes = append(indexes, i*len(strategies)/size)
+		}
+	case "random":
+		indexes = append(indexes, rand.New(rand.NewSource(seed)).Perm(len(strategies))[:size]...)
+		sort.Ints(indexes)
+	}
+
+	sample := make([]model.StrategyInput, 0, size)
+	for _, i := range indexes {
+		sample = append(sample, strategies[i])
+	}
+	return sample, nil
+}
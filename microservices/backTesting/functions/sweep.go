@@ -0,0 +1,165 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/rs/zerolog/log"
+)
+
+// SweepOptions controls which strategies a parameter sweep tries and over what data.
+type SweepOptions struct {
+	DataDir    string
+	From, To   time.Time // inclusive days to replay, zero for no bound
+	Ranges     shared.ParameterRanges
+	Sampling   string // all, grid or random
+	SampleSize int
+	Seed       int64
+	RankBy     string // sharpe, pnl or winrate
+	Promote    int    // how many of the top ranked strategies to create in BotDetails for backtesting
+}
+
+// RankedResult pairs a backtested strategy with its metrics and position in the sweep.
+type RankedResult struct {
+	Rank     int
+	Strategy model.StrategyInput
+	Metrics  BacktestMetrics
+}
+
+// RankResults orders the results best first by the chosen metric, breaking ties on net PnL.
+func RankResults(results []BacktestResult, rankBy string) ([]RankedResult, error) {
+	var score func(m BacktestMetrics) float64
+	switch rankBy {
+	case "sharpe":
+		score = func(m BacktestMetrics) float64 { return m.Sharpe }
+	case "pnl":
+		score = func(m BacktestMetrics) float64 { return m.NetPnL }
+	case "winrate":
+		score = func(m BacktestMetrics) float64 { return m.WinRate }
+	default:
+		return nil, fmt.Errorf("unknown ranking %q, expected sharpe, pnl or winrate", rankBy)
+	}
+
+	ranked := make([]RankedResult, 0, len(results))
+	for _, r := range results {
+		ranked = append(ranked, RankedResult{Strategy: r.Strategy, Metrics: r.Metrics()})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := score(ranked[i].Metrics), score(ranked[j].Metrics)
+		if a == b {
+			return ranked[i].Metrics.NetPnL > ranked[j].Metrics.NetPnL
+		}
+		return a > b
+	})
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+
+	return ranked, nil
+}
+
+// RunSweep generates strategies from the parameter ranges, backtests them over
+// the chosen days of price files, stores the ranked results and optionally
+// creates the best of them as strategies. It returns the stored sweep's ID.
+func RunSweep(ctx context.Context, client graphql.Client, opts SweepOptions) (string, error) {
+	cfg := shared.GetDefaultCfg()
+	createdOn := int(time.Now().Unix())
+
+	series, err := loadSeriesBetween(ctx, client, opts.DataDir, opts.From, opts.To, cfg.ActiveMarketThreshold)
+	if err != nil {
+		return "", err
+	}
+
+	permutations := GeneratePermutations(opts.Ranges, cfg.StartingBalance, createdOn)
+	strategies, err := SamplePermutations(permutations, opts.Sampling, opts.SampleSize, opts.Seed)
+	if err != nil {
+		return "", err
+	}
+	log.Info().Int("Permutations", len(permutations)).Int("Sampled", len(strategies)).Int("Snapshots", len(series.Timestamps)).Msg("Running parameter sweep")
+
+	ranked, err := RankResults(BacktestAll(series, strategies, cfg.FeePercentage), opts.RankBy)
+	if err != nil {
+		return "", err
+	}
+
+	input := graph.StrategySweepInput{
+		Sampling:        opts.Sampling,
+		RankedBy:        opts.RankBy,
+		From:            series.Timestamps[0],
+		To:              series.Timestamps[len(series.Timestamps)-1],
+		Permutations:    len(permutations),
+		StartingBalance: cfg.StartingBalance,
+		CreatedOn:       createdOn,
+	}
+	for _, r := range ranked {
+		input.Results = append(input.Results, graph.SweepResultInput{
+			Rank:                 r.Rank,
+			BotInstanceName:      r.Strategy.BotInstanceName,
+			TradeDuration:        r.Strategy.TradeDuration,
+			IncrementsATR:        r.Strategy.IncrementsAtr,
+			LongSMADuration:      r.Strategy.LongSMADuration,
+			ShortSMADuration:     r.Strategy.ShortSMADuration,
+			MovingAveMomentum:    r.Strategy.MovingAveMomentum,
+			TakeProfitPercentage: r.Strategy.TakeProfitPercentage,
+			StopLossPercentage:   r.Strategy.StopLossPercentage,
+			ATRtollerance:        *r.Strategy.ATRtollerance,
+			Trades:               r.Metrics.Trades,
+			NetPnL:               r.Metrics.NetPnL,
+			WinRate:              r.Metrics.WinRate,
+			MaxDrawdown:          r.Metrics.MaxDrawdown,
+			Sharpe:               r.Metrics.Sharpe,
+		})
+	}
+
+	resp, err := graph.CreateStrategySweep(ctx, client, input)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to store strategy sweep")
+		return "", err
+	}
+	sweepID := resp.CreateStrategySweep.SweepID
+	log.Info().Str("SweepID", sweepID).Msg("Strategy sweep stored")
+
+	if err := createCandidates(ctx, client, ranked[:min(opts.Promote, len(ranked))]); err != nil {
+		return sweepID, err
+	}
+
+	return sweepID, nil
+}
+
+// createCandidates creates the ranked strategies in BotDetails in the backtesting state,
+// ready to be validated out-of-sample before they paper trade.
+func createCandidates(ctx context.Context, client graphql.Client, ranked []RankedResult) error {
+	for _, r := range ranked {
+		input := graph.StrategyInput{
+			BotInstanceName:      r.Strategy.BotInstanceName,
+			TradeDuration:        r.Strategy.TradeDuration,
+			IncrementsATR:        r.Strategy.IncrementsAtr,
+			LongSMADuration:      r.Strategy.LongSMADuration,
+			ShortSMADuration:     r.Strategy.ShortSMADuration,
+			AccountBalance:       r.Strategy.AccountBalance,
+			MovingAveMomentum:    r.Strategy.MovingAveMomentum,
+			TakeProfitPercentage: r.Strategy.TakeProfitPercentage,
+			StopLossPercentage:   r.Strategy.StopLossPercentage,
+			Lifecycle:            graph.StrategyLifecycleBacktesting,
+			Owner:                r.Strategy.Owner,
+			CreatedOn:            r.Strategy.CreatedOn,
+		}
+		if r.Strategy.ATRtollerance != nil {
+			input.ATRtollerance = *r.Strategy.ATRtollerance
+		}
+
+		if _, err := graph.CreateStrategy(ctx, client, input); err != nil {
+			log.Error().Err(err).Str("Bot", r.Strategy.BotInstanceName).Msg("Failed to create candidate strategy")
+			return err
+		}
+		log.Info().Str("Bot", r.Strategy.BotInstanceName).Int("Rank", r.Rank).Msg("Created candidate strategy")
+	}
+
+	return nil
+}
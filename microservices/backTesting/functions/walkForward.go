@@ -0,0 +1,258 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	tradingBots "cryptobotmanager.com/cbm-backend/microservices/tradingBots/functions"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/rs/zerolog/log"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// WalkForwardOptions controls a walk-forward validation run. The embedded sweep
+// options choose the strategies optimised on each in-sample period; Promote
+// creates the top N of the most recent in-sample period for backtesting.
+type WalkForwardOptions struct {
+	SweepOptions
+	InSampleDays    int
+	OutOfSampleDays int
+}
+
+// WalkForwardWindow is one in-sample period and the out-of-sample period that follows it.
+type WalkForwardWindow struct {
+	InSample    *PriceSeries
+	OutOfSample *PriceSeries
+	Ranked      []RankedResult // strategies ranked on the in-sample period
+	Result      BacktestResult // the best of them replayed on the out-of-sample period
+}
+
+// SplitWalkForward cuts the series into rolling windows: an in-sample period
+// followed by an out-of-sample period, rolling forward by the out-of-sample
+// length so that no two out-of-sample periods overlap. The last out-of-sample
+// period may be short if the data runs out.
+func SplitWalkForward(series *PriceSeries, inSampleDays, outOfSampleDays int) []WalkForwardWindow {
+	if len(series.Timestamps) == 0 || inSampleDays <= 0 || outOfSampleDays <= 0 {
+		return nil
+	}
+
+	first := series.Timestamps[0]
+	last := series.Timestamps[len(series.Timestamps)-1]
+	inSample := inSampleDays * secondsPerDay
+	outOfSample := outOfSampleDays * secondsPerDay
+
+	var windows []WalkForwardWindow
+	for start := first; start+inSample <= last; start += outOfSample {
+		split := start + inSample
+		window := WalkForwardWindow{
+			InSample:    series.Window(start, split),
+			OutOfSample: series.Window(split, split+outOfSample),
+		}
+		if len(window.OutOfSample.Timestamps) == 0 {
+			break
+		}
+		windows = append(windows, window)
+	}
+
+	return windows
+}
+
+// OptimiseWalkForward ranks the strategies on each in-sample period and replays
+// the best on the out-of-sample period that follows. Each out-of-sample run
+// starts from the balance the previous one finished on, so together they form
+// one continuous out-of-sample record.
+func OptimiseWalkForward(windows []WalkForwardWindow, strategies []model.StrategyInput, feePercentage float64, rankBy string) error {
+	if len(strategies) == 0 {
+		return fmt.Errorf("no strategies to optimise")
+	}
+
+	for i := range windows {
+		ranked, err := RankResults(BacktestAll(windows[i].InSample, strategies, feePercentage), rankBy)
+		if err != nil {
+			return err
+		}
+
+		best := ranked[0].Strategy
+		if i > 0 {
+			best.AccountBalance = windows[i-1].Result.EndingBalance
+		}
+		windows[i].Ranked = ranked
+		windows[i].Result = RunBacktest(windows[i].OutOfSample, best, feePercentage)
+	}
+
+	return nil
+}
+
+// EvaluateOutOfSample replays a fixed strategy over every out-of-sample period,
+// chaining the balance from one to the next. It returns the combined result and
+// the percentage of periods that ended in profit.
+func EvaluateOutOfSample(windows []WalkForwardWindow, details model.StrategyInput, feePercentage float64) (BacktestResult, float64) {
+	var results []BacktestResult
+	profitable := 0
+	for _, window := range windows {
+		result := RunBacktest(window.OutOfSample, details, feePercentage)
+		if result.EndingBalance > result.StartingBalance {
+			profitable++
+		}
+		results = append(results, result)
+		details.AccountBalance = result.EndingBalance
+	}
+
+	if len(results) == 0 {
+		return BacktestResult{Strategy: details, StartingBalance: details.AccountBalance, EndingBalance: details.AccountBalance}, 0
+	}
+	return CombineResults(results), float64(profitable) / float64(len(results)) * 100
+}
+
+// CombineResults joins results whose balances were chained one after another
+// into a single result covering the whole period.
+func CombineResults(results []BacktestResult) BacktestResult {
+	if len(results) == 0 {
+		return BacktestResult{}
+	}
+
+	first, last := results[0], results[len(results)-1]
+	combined := BacktestResult{
+		Strategy:        first.Strategy,
+		FeePercentage:   first.FeePercentage,
+		From:            first.From,
+		To:              last.To,
+		StartingBalance: first.StartingBalance,
+		EndingBalance:   last.EndingBalance,
+	}
+	for _, r := range results {
+		combined.Trades = append(combined.Trades, r.Trades...)
+	}
+
+	return combined
+}
+
+// MeetsThresholds reports whether out-of-sample results are good enough for promotion.
+func MeetsThresholds(metrics BacktestMetrics, profitableWindows float64, thresholds shared.PromotionThresholds) bool {
+	return metrics.Trades >= thresholds.MinTrades &&
+		metrics.WinRate >= thresholds.MinWinRate &&
+		metrics.Sharpe >= thresholds.MinSharpe &&
+		metrics.MaxDrawdown <= thresholds.MaxDrawdown &&
+		profitableWindows >= thresholds.MinProfitableWindows
+}
+
+// RunWalkForward optimises the parameter ranges over rolling in-sample windows,
+// stores each out-of-sample result as a BacktestRun and logs the combined
+// out-of-sample performance. It then replays the backtesting and validated
+// strategies over the same out-of-sample periods and promotes those that meet
+// the configured thresholds one lifecycle state.
+func RunWalkForward(ctx context.Context, client graphql.Client, opts WalkForwardOptions) error {
+	cfg := shared.GetDefaultCfg()
+	createdOn := int(time.Now().Unix())
+
+	series, err := loadSeriesBetween(ctx, client, opts.DataDir, opts.From, opts.To, cfg.ActiveMarketThreshold)
+	if err != nil {
+		return err
+	}
+
+	windows := SplitWalkForward(series, opts.InSampleDays, opts.OutOfSampleDays)
+	if len(windows) == 0 {
+		return fmt.Errorf("not enough data for a %d day in-sample and %d day out-of-sample window", opts.InSampleDays, opts.OutOfSampleDays)
+	}
+
+	permutations := GeneratePermutations(opts.Ranges, cfg.StartingBalance, createdOn)
+	strategies, err := SamplePermutations(permutations, opts.Sampling, opts.SampleSize, opts.Seed)
+	if err != nil {
+		return err
+	}
+	log.Info().Int("Windows", len(windows)).Int("Strategies", len(strategies)).Msg("Running walk-forward optimisation")
+
+	if err := OptimiseWalkForward(windows, strategies, cfg.FeePercentage, opts.RankBy); err != nil {
+		return err
+	}
+
+	var results []BacktestResult
+	profitable := 0
+	for i, window := range windows {
+		result := window.Result
+		result.Strategy.BotInstanceName = fmt.Sprintf("walkforward_%d_w%02d", createdOn, i+1)
+		if _, err := SaveBacktestRun(ctx, client, window.OutOfSample, result, createdOn); err != nil {
+			log.Error().Err(err).Str("Bot", result.Strategy.BotInstanceName).Msg("Failed to save backtest run")
+			return err
+		}
+		if result.EndingBalance > result.StartingBalance {
+			profitable++
+		}
+		results = append(results, result)
+
+		inSample := window.Ranked[0].Metrics
+		outOfSample := result.Metrics()
+		log.Info().
+			Int("Window", i+1).
+			Str("Chosen", window.Ranked[0].Strategy.BotInstanceName).
+			Float64("InSampleNetPnL", inSample.NetPnL).
+			Float64("OutOfSampleNetPnL", outOfSample.NetPnL).
+			Int("OutOfSampleTrades", outOfSample.Trades).
+			Msg("Walk-forward window")
+	}
+
+	combined := CombineResults(results).Metrics()
+	log.Info().
+		Float64("NetPnL", combined.NetPnL).
+		Float64("WinRate", combined.WinRate).
+		Float64("Sharpe", combined.Sharpe).
+		Float64("MaxDrawdown", combined.MaxDrawdown).
+		Float64("ProfitableWindows", float64(profitable)/float64(len(windows))*100).
+		Msg("Walk-forward out-of-sample results")
+
+	latest := windows[len(windows)-1].Ranked
+	if err := createCandidates(ctx, client, latest[:min(opts.Promote, len(latest))]); err != nil {
+		return err
+	}
+
+	return advanceLifecycles(ctx, client, windows, cfg)
+}
+
+// advanceLifecycles replays the validated and then the backtesting strategies
+// over the out-of-sample periods and promotes those meeting the thresholds.
+// Validated strategies are handled first so that each strategy moves at most
+// one state per run.
+func advanceLifecycles(ctx context.Context, client graphql.Client, windows []WalkForwardWindow, cfg shared.AppConfig) error {
+	steps := []struct {
+		from, to   graph.StrategyLifecycle
+		thresholds shared.PromotionThresholds
+	}{
+		{graph.StrategyLifecycleValidated, graph.StrategyLifecyclePaper, cfg.WalkForward.ToPaper},
+		{graph.StrategyLifecycleBacktesting, graph.StrategyLifecycleValidated, cfg.WalkForward.ToValidated},
+	}
+
+	for _, step := range steps {
+		strategies, err := tradingBots.GetStrategiesByLifecycle(ctx, client, step.from)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get strategy details!")
+			return err
+		}
+
+		for _, details := range strategies {
+			details.AccountBalance = cfg.StartingBalance
+			result, profitableWindows := EvaluateOutOfSample(windows, details, cfg.FeePercentage)
+			metrics := result.Metrics()
+			if !MeetsThresholds(metrics, profitableWindows, step.thresholds) {
+				log.Info().Str("Bot", details.BotInstanceName).Str("Lifecycle", string(step.from)).Int("Trades", metrics.Trades).Float64("Sharpe", metrics.Sharpe).Msg("Not promoted")
+				continue
+			}
+
+			_, err := graph.UpdateStrategyLifecycle(ctx, client, graph.UpdateLifecycleInput{
+				BotInstanceName: details.BotInstanceName,
+				Lifecycle:       step.to,
+			})
+			if err != nil {
+				log.Error().Err(err).Str("Bot", details.BotInstanceName).Msg("Failed to promote strategy")
+				return err
+			}
+			log.Info().Str("Bot", details.BotInstanceName).Str("From", string(step.from)).Str("To", string(step.to)).Msg("Promoted strategy")
+		}
+	}
+
+	return nil
+}
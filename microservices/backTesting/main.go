@@ -1,17 +1,37 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 
 	"cryptobotmanager.com/cbm-backend/microservices/backTesting/functions"
 	"cryptobotmanager.com/cbm-backend/shared"
+	"github.com/Khan/genqlient/graphql"
 	sharedlog "github.com/rs/zerolog/log"
 )
 
+var (
+	mode       = flag.String("mode", "replay", "replay the strategies under test over the price files, sweep strategy parameters, or walkforward to validate them")
+	dataDir    = flag.String("data", "binancePrices", "directory holding the binance_prices_YYYY-MM-DD.json files")
+	fromDay    = flag.String("from", "", "first day to backtest (YYYY-MM-DD), defaults to the earliest file")
+	toDay      = flag.String("to", "", "last day to backtest (YYYY-MM-DD), defaults to the latest file")
+	rangesFile = flag.String("ranges", "", "JSON file of parameter ranges, defaults to the ranges in config")
+	sampling   = flag.String("sample", "all", "how to pick permutations: all, grid or random")
+	sampleSize = flag.Int("n", 0, "number of permutations to sample for grid or random")
+	seed       = flag.Int64("seed", 1, "seed for random sampling")
+	rankBy     = flag.String("rank", "sharpe", "metric to rank results by: sharpe, pnl or winrate")
+	promote    = flag.Int("promote", 0, "create the top N ranked strategies in BotDetails for backtesting")
+	inSample   = flag.Int("in", 0, "walk-forward in-sample days, defaults to config")
+	outSample  = flag.Int("out", 0, "walk-forward out-of-sample days, defaults to config")
+)
+
 func main() {
 	err := godotenv.Load(".env")
 	if err != nil {
@@ -40,20 +60,115 @@ func main() {
 
 	fmt.Println("SYSTEM_MODE is:", os.Getenv("SYSTEM_MODE"))
 
-	// Setup GraphQL backend client
-	// if os.Getenv("SYSTEM_MODE") == "local" {
-	err = functions.CSVPrices(backend)
+	switch *mode {
+	case "sweep":
+		if err := runSweep(backend); err != nil {
+			sharedlog.Error().Err(err).Msg("Strategy sweep failed")
+			os.Exit(1)
+		}
+		return
+	case "walkforward":
+		if err := runWalkForward(backend); err != nil {
+			sharedlog.Error().Err(err).Msg("Walk-forward validation failed")
+			os.Exit(1)
+		}
+		return
+	}
+
+	from, to, err := parseDays()
+	if err != nil {
+		sharedlog.Error().Err(err).Msg("Invalid date range")
+		os.Exit(1)
+	}
+
+	err = functions.ReplayPrices(backend, *dataDir, from, to)
+	if err != nil {
+		sharedlog.Error().Err(err).Msg("Failed to replay price data")
+		os.Exit(1)
+	}
+}
+
+// sweepOptions builds the sweep options from the command line flags.
+func sweepOptions() (functions.SweepOptions, error) {
+	opts := functions.SweepOptions{
+		DataDir:    *dataDir,
+		Ranges:     shared.GetDefaultCfg().ParameterRanges,
+		Sampling:   *sampling,
+		SampleSize: *sampleSize,
+		Seed:       *seed,
+		RankBy:     *rankBy,
+		Promote:    *promote,
+	}
+
+	var err error
+	if opts.From, opts.To, err = parseDays(); err != nil {
+		return opts, err
+	}
+	if *rangesFile != "" {
+		data, err := os.ReadFile(*rangesFile)
+		if err != nil {
+			return opts, err
+		}
+		if err := json.Unmarshal(data, &opts.Ranges); err != nil {
+			return opts, fmt.Errorf("invalid ranges file: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+// runSweep runs a parameter sweep configured by the command line flags.
+func runSweep(backend string) error {
+	opts, err := sweepOptions()
+	if err != nil {
+		return err
+	}
+
+	client := graphql.NewClient(backend, &http.Client{})
+	sweepID, err := functions.RunSweep(context.Background(), client, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Sweep stored with ID:", sweepID)
+	return nil
+}
+
+// runWalkForward runs walk-forward validation configured by the command line flags.
+func runWalkForward(backend string) error {
+	opts, err := sweepOptions()
 	if err != nil {
-		sharedlog.Error().Err(err).Msg("Failed to load JSON data")
+		return err
+	}
+
+	cfg := shared.GetDefaultCfg()
+	walkForward := functions.WalkForwardOptions{
+		SweepOptions:    opts,
+		InSampleDays:    cfg.WalkForward.InSampleDays,
+		OutOfSampleDays: cfg.WalkForward.OutOfSampleDays,
+	}
+	if *inSample > 0 {
+		walkForward.InSampleDays = *inSample
+	}
+	if *outSample > 0 {
+		walkForward.OutOfSampleDays = *outSample
 	}
 
-	// } else {
-	// 	err := functions.BinancePrices(backend)
-	// 	if err != nil {
-	// 		sharedlog.Error().Err(err).Msgf("Failed to get price data from Binance!")
-	// 	}
-	// }
+	client := graphql.NewClient(backend, &http.Client{})
+	return functions.RunWalkForward(context.Background(), client, walkForward)
+}
 
+// parseDays reads the -from and -to flags, leaving either zero when unset.
+func parseDays() (from, to time.Time, err error) {
+	if *fromDay != "" {
+		if from, err = time.Parse("2006-01-02", *fromDay); err != nil {
+			return from, to, fmt.Errorf("invalid -from: %w", err)
+		}
+	}
+	if *toDay != "" {
+		if to, err = time.Parse("2006-01-02", *toDay); err != nil {
+			return from, to, fmt.Errorf("invalid -to: %w", err)
+		}
+	}
+	return from, to, nil
 }
 
 // func BinancePrices(backend string) error {
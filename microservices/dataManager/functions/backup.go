@@ -0,0 +1,87 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/backup"
+)
+
+// BackupURL returns the address of the API's backup or restore endpoint for
+// the filter.
+func BackupURL(api, endpoint string, filter backup.Filter) string {
+	query := url.Values{}
+	if len(filter.Include) > 0 {
+		query.Set("include", strings.Join(filter.Include, ","))
+	}
+	if len(filter.Exclude) > 0 {
+		query.Set("exclude", strings.Join(filter.Exclude, ","))
+	}
+	address := strings.TrimRight(api, "/") + "/" + endpoint
+	if encoded := query.Encode(); encoded != "" {
+		address += "?" + encoded
+	}
+	return address
+}
+
+// DownloadBackup writes the archive the API backs up to w, authorised by an
+// admin's token.
+func DownloadBackup(ctx context.Context, api, token string, filter backup.Filter, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, BackupURL(api, "backup", filter), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := responseError(resp); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// UploadRestore posts an archive of size bytes to the API to restore,
+// authorised by an admin's token, and returns the collections restored.
+func UploadRestore(ctx context.Context, api, token string, filter backup.Filter, archive io.Reader, size int64) ([]backup.Collection, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, BackupURL(api, "restore", filter), archive)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/zip")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := responseError(resp); err != nil {
+		return nil, err
+	}
+
+	var restored []backup.Collection
+	if err := json.NewDecoder(resp.Body).Decode(&restored); err != nil {
+		return nil, fmt.Errorf("decoding restore response: %w", err)
+	}
+	return restored, nil
+}
+
+func responseError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
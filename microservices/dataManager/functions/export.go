@@ -0,0 +1,155 @@
+package functions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+)
+
+// Version is the schema version of the export format. Bump it when a change
+// to Export would be read wrongly by an older import.
+const Version = 1
+
+// Export is a snapshot of the projects, SOPs included, and tasks, keeping
+// their IDs so that importing it again updates rather than duplicates them.
+type Export struct {
+	Version    int       `json:"version"`
+	ExportedAt string    `json:"exportedAt"`
+	Projects   []Project `json:"projects"`
+	Tasks      []Task    `json:"tasks"`
+}
+
+// Project is an exported project or SOP. Empty fields are unset.
+type Project struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Sop         bool     `json:"sop"`
+	Description string   `json:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	AssignedTo  string   `json:"assignedTo,omitempty"`
+	DueDate     string   `json:"dueDate,omitempty"`
+	Status      string   `json:"status"`
+	CreatedAt   string   `json:"createdAt,omitempty"`
+	UpdatedAt   string   `json:"updatedAt,omitempty"`
+}
+
+// Task is an exported task. Empty fields are unset.
+type Task struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Status      string   `json:"status"`
+	Labels      []string `json:"labels,omitempty"`
+	AssignedTo  string   `json:"assignedTo,omitempty"`
+	DueDate     string   `json:"dueDate,omitempty"`
+	DeferDate   string   `json:"deferDate,omitempty"`
+	Department  string   `json:"department,omitempty"`
+	ProjectID   string   `json:"projectId,omitempty"`
+	Duration    *int     `json:"duration,omitempty"`
+	Estimate    *int     `json:"estimate,omitempty"`
+	ParentID    string   `json:"parentId,omitempty"`
+	BlockedBy   []string `json:"blockedBy,omitempty"`
+	Recurrence  string   `json:"recurrence,omitempty"`
+	CreatedAt   string   `json:"createdAt,omitempty"`
+	UpdatedAt   string   `json:"updatedAt,omitempty"`
+}
+
+// ProjectFromGraph converts a project read from the API.
+func ProjectFromGraph(p graph.ReadProjectsFilterReadProjectsFilterProject) Project {
+	return Project{
+		ID:          p.Id,
+		Title:       p.Title,
+		Sop:         p.Sop,
+		Description: p.Description,
+		Labels:      p.Labels,
+		AssignedTo:  p.AssignedTo,
+		DueDate:     p.DueDate,
+		Status:      p.Status,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+// TaskFromGraph converts a task read from the API.
+func TaskFromGraph(t graph.ReadAllTasksReadAllTasksTask) Task {
+	return Task{
+		ID:          t.Id,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Labels:      t.Labels,
+		AssignedTo:  t.AssignedTo,
+		DueDate:     t.DueDate,
+		DeferDate:   t.DeferDate,
+		Department:  t.Department,
+		ProjectID:   t.ProjectId,
+		Duration:    t.Duration,
+		Estimate:    t.Estimate,
+		ParentID:    t.ParentId,
+		BlockedBy:   t.BlockedBy,
+		Recurrence:  t.Recurrence,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// NewExport returns an export of the projects and tasks taken at now.
+func NewExport(projects []Project, tasks []Task, now time.Time) *Export {
+	if projects == nil {
+		projects = []Project{}
+	}
+	if tasks == nil {
+		tasks = []Task{}
+	}
+	return &Export{Version: Version, ExportedAt: now.UTC().Format(time.RFC3339), Projects: projects, Tasks: tasks}
+}
+
+// WriteExport writes an export as indented JSON.
+func WriteExport(w io.Writer, export *Export) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// ReadExport reads an export, refusing one of another schema version or with
+// projects or tasks lacking an ID or title, or sharing an ID.
+func ReadExport(r io.Reader) (*Export, error) {
+	var export Export
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("decoding export: %w", err)
+	}
+
+	switch {
+	case export.Version == 0:
+		return nil, errors.New("export has no schema version; export it again with this version of dataManager")
+	case export.Version != Version:
+		return nil, fmt.Errorf("export has schema version %d, this dataManager reads version %d", export.Version, Version)
+	}
+
+	projectIDs := map[string]bool{}
+	for i, project := range export.Projects {
+		if project.ID == "" || project.Title == "" {
+			return nil, fmt.Errorf("project %d needs an id and a title", i)
+		}
+		if projectIDs[project.ID] {
+			return nil, fmt.Errorf("project %s appears twice", project.ID)
+		}
+		projectIDs[project.ID] = true
+	}
+	taskIDs := map[string]bool{}
+	for i, task := range export.Tasks {
+		if task.ID == "" || task.Title == "" {
+			return nil, fmt.Errorf("task %d needs an id and a title", i)
+		}
+		if taskIDs[task.ID] {
+			return nil, fmt.Errorf("task %s appears twice", task.ID)
+		}
+		taskIDs[task.ID] = true
+	}
+
+	return &export, nil
+}
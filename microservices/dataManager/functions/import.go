@@ -0,0 +1,330 @@
+package functions
+
+import (
+	"net/http"
+	"slices"
+
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"github.com/Khan/genqlient/graphql"
+)
+
+// Action is what importing does with a project or task.
+type Action string
+
+const (
+	Create Action = "create" // not in the API yet
+	Update Action = "update" // in the API with different fields
+	Skip   Action = "skip"   // in the API already as it is
+)
+
+// ProjectStep is the import of one project.
+type ProjectStep struct {
+	Action  Action
+	Project Project
+	Changes []string // the fields an update changes
+}
+
+// TaskStep is the import of one task.
+type TaskStep struct {
+	Action  Action
+	Task    Task
+	Changes []string // the fields an update changes
+}
+
+// Plan is what an import does, in the order to do it: projects before the
+// tasks in them, and tasks after their parent and blockers.
+type Plan struct {
+	Projects []ProjectStep
+	Tasks    []TaskStep
+}
+
+// Counts are the projects or tasks an import created, updated, skipped as
+// already up to date, or failed on.
+type Counts struct {
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+}
+
+// Add counts one more project or task imported by the action.
+func (c *Counts) Add(action Action) {
+	switch action {
+	case Create:
+		c.Created++
+	case Update:
+		c.Updated++
+	case Skip:
+		c.Skipped++
+	}
+}
+
+// Counts returns what the plan would do, for a dry run.
+func (p *Plan) Counts() (projects, tasks Counts) {
+	for _, step := range p.Projects {
+		projects.Add(step.Action)
+	}
+	for _, step := range p.Tasks {
+		tasks.Add(step.Action)
+	}
+	return projects, tasks
+}
+
+// PlanImport matches the projects and tasks of an export by ID against those
+// already in the API, to create the missing ones and update those that differ.
+func PlanImport(export *Export, existingProjects []Project, existingTasks []Task) *Plan {
+	plan := &Plan{}
+
+	projects := make(map[string]Project, len(existingProjects))
+	for _, project := range existingProjects {
+		projects[project.ID] = project
+	}
+	for _, project := range export.Projects {
+		step := ProjectStep{Action: Create, Project: project}
+		if current, ok := projects[project.ID]; ok {
+			step.Changes = ProjectChanges(current, project)
+			step.Action = Update
+			if len(step.Changes) == 0 {
+				step.Action = Skip
+			}
+		}
+		plan.Projects = append(plan.Projects, step)
+	}
+
+	tasks := make(map[string]Task, len(existingTasks))
+	for _, task := range existingTasks {
+		tasks[task.ID] = task
+	}
+	for _, task := range linkOrder(export.Tasks) {
+		step := TaskStep{Action: Create, Task: task}
+		if current, ok := tasks[task.ID]; ok {
+			step.Changes = TaskChanges(current, task)
+			step.Action = Update
+			if len(step.Changes) == 0 {
+				step.Action = Skip
+			}
+		}
+		plan.Tasks = append(plan.Tasks, step)
+	}
+
+	return plan
+}
+
+// linkOrder orders tasks so that each comes after its parent and blockers, as
+// the API refuses links to tasks it does not have yet. Otherwise, and where
+// links loop, the export's order is kept.
+func linkOrder(tasks []Task) []Task {
+	byID := make(map[string]Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	ordered := make([]Task, 0, len(tasks))
+	visited := map[string]bool{}
+	var visit func(task Task)
+	visit = func(task Task) {
+		if visited[task.ID] {
+			return
+		}
+		visited[task.ID] = true
+		links := append([]string{task.ParentID}, task.BlockedBy...)
+		for _, id := range links {
+			if linked, ok := byID[id]; ok {
+				visit(linked)
+			}
+		}
+		ordered = append(ordered, task)
+	}
+	for _, task := range tasks {
+		visit(task)
+	}
+	return ordered
+}
+
+// ProjectChanges lists the fields of a project that differ between the API
+// and an export. Unset and empty are the same.
+func ProjectChanges(current, imported Project) []string {
+	var changes []string
+	add := func(field string, differs bool) {
+		if differs {
+			changes = append(changes, field)
+		}
+	}
+	add("title", current.Title != imported.Title)
+	add("sop", current.Sop != imported.Sop)
+	add("description", current.Description != imported.Description)
+	add("labels", !sameList(current.Labels, imported.Labels))
+	add("assignedTo", current.AssignedTo != imported.AssignedTo)
+	add("dueDate", current.DueDate != imported.DueDate)
+	add("status", current.Status != imported.Status)
+	return changes
+}
+
+// TaskChanges lists the fields of a task that differ between the API and an
+// export. Unset and empty are the same, and as the API cannot clear a
+// duration or estimate, an unset one in the export leaves the API's.
+func TaskChanges(current, imported Task) []string {
+	var changes []string
+	add := func(field string, differs bool) {
+		if differs {
+			changes = append(changes, field)
+		}
+	}
+	add("title", current.Title != imported.Title)
+	add("description", current.Description != imported.Description)
+	add("status", current.Status != imported.Status)
+	add("labels", !sameList(current.Labels, imported.Labels))
+	add("assignedTo", current.AssignedTo != imported.AssignedTo)
+	add("dueDate", current.DueDate != imported.DueDate)
+	add("deferDate", current.DeferDate != imported.DeferDate)
+	add("department", current.Department != imported.Department)
+	add("projectId", current.ProjectID != imported.ProjectID)
+	add("duration", imported.Duration != nil && !sameInt(current.Duration, imported.Duration))
+	add("estimate", imported.Estimate != nil && !sameInt(current.Estimate, imported.Estimate))
+	add("parentId", current.ParentID != imported.ParentID)
+	add("blockedBy", !sameList(current.BlockedBy, imported.BlockedBy))
+	add("recurrence", current.Recurrence != imported.Recurrence)
+	return changes
+}
+
+func sameList(a, b []string) bool {
+	return len(a) == 0 && len(b) == 0 || slices.Equal(a, b)
+}
+
+func sameInt(a, b *int) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+// CreateProjectInput returns the input creating a project with its ID.
+func CreateProjectInput(p Project) graph.CreateProjectInput {
+	return graph.CreateProjectInput{
+		Id:          &p.ID,
+		Title:       p.Title,
+		Sop:         p.Sop,
+		Description: optional(p.Description),
+		Labels:      p.Labels,
+		AssignedTo:  optional(p.AssignedTo),
+		DueDate:     optional(p.DueDate),
+		Status:      p.Status,
+	}
+}
+
+// UpdateProjectInput returns the input setting the changed fields of a project.
+func UpdateProjectInput(p Project, changes []string) graph.UpdateProjectInput {
+	input := graph.UpdateProjectInput{Id: p.ID}
+	for _, field := range changes {
+		switch field {
+		case "title":
+			input.Title = &p.Title
+		case "sop":
+			input.Sop = &p.Sop
+		case "description":
+			input.Description = &p.Description
+		case "labels":
+			input.Labels = nonNil(p.Labels)
+		case "assignedTo":
+			input.AssignedTo = &p.AssignedTo
+		case "dueDate":
+			input.DueDate = &p.DueDate
+		case "status":
+			input.Status = &p.Status
+		}
+	}
+	return input
+}
+
+// CreateTaskInput returns the input creating a task with its ID, in the
+// status it was exported in.
+func CreateTaskInput(t Task) graph.CreateTaskInput {
+	return graph.CreateTaskInput{
+		Imported:    true,
+		Id:          &t.ID,
+		Title:       t.Title,
+		Description: optional(t.Description),
+		Status:      t.Status,
+		Labels:      t.Labels,
+		AssignedTo:  optional(t.AssignedTo),
+		DueDate:     optional(t.DueDate),
+		DeferDate:   optional(t.DeferDate),
+		Department:  optional(t.Department),
+		ProjectId:   optional(t.ProjectID),
+		Duration:    t.Duration,
+		Estimate:    t.Estimate,
+		ParentId:    optional(t.ParentID),
+		BlockedBy:   t.BlockedBy,
+		Recurrence:  optional(t.Recurrence),
+	}
+}
+
+// UpdateTaskInput returns the input setting the changed fields of a task,
+// whatever moves between statuses the workflow would allow a user.
+func UpdateTaskInput(t Task, changes []string) graph.UpdateTaskInput {
+	input := graph.UpdateTaskInput{Id: t.ID, Imported: true}
+	for _, field := range changes {
+		switch field {
+		case "title":
+			input.Title = &t.Title
+		case "description":
+			input.Description = &t.Description
+		case "status":
+			input.Status = &t.Status
+		case "labels":
+			input.Labels = nonNil(t.Labels)
+		case "assignedTo":
+			input.AssignedTo = &t.AssignedTo
+		case "dueDate":
+			input.DueDate = &t.DueDate
+		case "deferDate":
+			input.DeferDate = &t.DeferDate
+		case "department":
+			input.Department = &t.Department
+		case "projectId":
+			input.ProjectId = &t.ProjectID
+		case "duration":
+			input.Duration = t.Duration
+		case "estimate":
+			input.Estimate = t.Estimate
+		case "parentId":
+			input.ParentId = &t.ParentID
+		case "blockedBy":
+			input.BlockedBy = nonNil(t.BlockedBy)
+		case "recurrence":
+			input.Recurrence = &t.Recurrence
+		}
+	}
+	return input
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nonNil returns an empty list for nil, so an update clears the field rather
+// than leaving it.
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// APIClient returns a GraphQL client of the API whose requests carry the
+// token, as imports must be made by an admin.
+func APIClient(api, token string) graphql.Client {
+	return graphql.NewClient(api, &http.Client{Transport: bearer{token: token, next: http.DefaultTransport}})
+}
+
+// bearer adds a bearer token to each request.
+type bearer struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (b bearer) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return b.next.RoundTrip(req)
+}
@@ -3,48 +3,28 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"cryptobotmanager.com/cbm-backend/cbm-api/backup"
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/microservices/dataManager/functions"
 	"cryptobotmanager.com/cbm-backend/shared/graph"
 	"github.com/Khan/genqlient/graphql"
 )
 
-type ExportProject struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Sop         bool     `json:"sop"`
-	Description string   `json:"description"`
-	Labels      []string `json:"labels"`
-	AssignedTo  string   `json:"assignedTo"`
-	DueDate     string   `json:"dueDate"`
-	Status      string   `json:"status"`
-	CreatedAt   string   `json:"createdAt"`
-	UpdatedAt   string   `json:"updatedAt"`
-}
-
-type ExportTask struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Priority    string `json:"priority"`
-	AssignedTo  string `json:"assignedTo"`
-	DueDate     string `json:"dueDate"`
-	Category    string `json:"category"`
-	ProjectID   string `json:"projectId"`
-	CreatedAt   string `json:"createdAt"`
-	UpdatedAt   string `json:"updatedAt"`
-}
-
 var seedDataDir = "/usr/local/share/seeds"
 
 func init() {
@@ -59,28 +39,60 @@ func main() {
 
 	_ = godotenv.Load()
 
-	mode := flag.String("mode", "export", "Mode to run: export, import, or seed-users")
+	mode := flag.String("mode", "export", "Mode to run: export, import, backup, restore, migrate, or seed-users")
+	file := flag.String("file", "export.json", "Export file to write, or to import, relative to SEED_DATA_DIR")
+	dryRun := flag.Bool("dry-run", false, "Report what an import would create, update and skip without changing anything; a real import needs an admin's CBM_API_TOKEN")
+	archive := flag.String("archive", "", "Backup archive to write, by default backup-<time>.zip, or to restore")
+	source := flag.String("source", "mongo", "Back up and restore directly from Mongo, or through the API with an admin's CBM_API_TOKEN: mongo or api")
+	include := flag.String("include", "", "Comma separated collections to back up or restore, all when empty")
+	exclude := flag.String("exclude", "", "Comma separated collections to leave out of a backup or restore")
+	action := flag.String("action", "status", "Migrate action: status, up, down, or unlock to release the claims of a run that died")
+	to := flag.Int("to", 0, "Version to migrate up to, all pending when 0, or down to, 0 undoing every migration, or to unlock, all when 0")
 	flag.Parse()
 
+	filter := backup.Filter{Include: backup.ParseList(*include), Exclude: backup.ParseList(*exclude)}
+
 	switch *mode {
 	case "export":
-		if err := runExport(); err != nil {
+		if err := runExport(*file); err != nil {
 			log.Error().Err(err).Msg("Export failed")
 		}
 	case "import":
-		if err := runImport(); err != nil {
+		if err := runImport(*file, *dryRun); err != nil {
 			log.Error().Err(err).Msg("Import failed")
 		}
+	case "backup":
+		if err := runBackup(*archive, *source, filter); err != nil {
+			log.Error().Err(err).Msg("Backup failed")
+		}
+	case "restore":
+		if err := runRestore(*archive, *source, filter); err != nil {
+			log.Error().Err(err).Msg("Restore failed")
+		}
+	case "migrate":
+		// Exit non-zero, so that docker compose holds cbm-api back.
+		if err := runMigrate(*action, *to); err != nil {
+			log.Fatal().Err(err).Msg("Migration failed")
+		}
 	case "seed-users":
 		if err := seedUsers(); err != nil {
 			log.Error().Err(err).Msg("User seeding failed")
 		}
 	default:
-		log.Warn().Str("mode", *mode).Msg("Unknown mode. Use 'export', 'import', or 'seed-users'")
+		log.Warn().Str("mode", *mode).Msg("Unknown mode. Use 'export', 'import', 'backup', 'restore', 'migrate', or 'seed-users'")
+	}
+}
+
+// seedPath resolves an export file relative to SEED_DATA_DIR, so that export
+// and import find the same file.
+func seedPath(file string) string {
+	if filepath.IsAbs(file) {
+		return file
 	}
+	return filepath.Join(seedDataDir, file)
 }
 
-func runExport() error {
+func runExport(file string) error {
 	client := graphql.NewClient("http://cbm-api:8080/query", http.DefaultClient)
 	ctx := context.Background()
 
@@ -102,105 +114,305 @@ func runExport() error {
 		return fmt.Errorf("fetching tasks: %w", err)
 	}
 
-	if err := writeJSON("projects.json", projectsResp.ReadProjectsFilter); err != nil {
-		return fmt.Errorf("writing projects.json: %w", err)
+	var projects []functions.Project
+	for _, project := range append(projectsResp.ReadProjectsFilter, sopsResp.ReadProjectsFilter...) {
+		projects = append(projects, functions.ProjectFromGraph(project))
+	}
+	var tasks []functions.Task
+	for _, task := range tasksResp.ReadAllTasks {
+		tasks = append(tasks, functions.TaskFromGraph(task))
 	}
-	log.Info().Msg("projects.json written")
 
-	if err := writeJSON("sops.json", sopsResp.ReadProjectsFilter); err != nil {
-		return fmt.Errorf("writing sops.json: %w", err)
+	fullPath := seedPath(file)
+	out, err := os.Create(fullPath)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", fullPath, err)
 	}
-	log.Info().Msg("sops.json written")
+	defer out.Close()
 
-	if err := writeJSON("tasks.json", tasksResp.ReadAllTasks); err != nil {
-		return fmt.Errorf("writing tasks.json: %w", err)
+	if err := functions.WriteExport(out, functions.NewExport(projects, tasks, time.Now())); err != nil {
+		return fmt.Errorf("writing %s: %w", fullPath, err)
 	}
-	log.Info().Msg("tasks.json written")
 
-	log.Info().Msg("Export complete.")
+	log.Info().Str("file", fullPath).Int("version", functions.Version).Int("projects", len(projects)).Int("tasks", len(tasks)).Msg("Export complete.")
 	return nil
 }
 
-func runImport() error {
-	var projects []ExportProject
-	var sops []ExportProject
-	var tasks []ExportTask
-
-	log.Info().Msg("Reading JSON files...")
+// runImport upserts the projects and tasks of an export by ID, so importing
+// the same file twice changes nothing the second time. With dryRun it only
+// reports what it would do.
+func runImport(file string, dryRun bool) error {
+	fullPath := seedPath(file)
+	in, err := os.Open(fullPath)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", fullPath, err)
+	}
+	defer in.Close()
 
-	if err := readJSON("projects.json", &projects); err != nil {
-		return fmt.Errorf("reading projects.json: %w", err)
+	export, err := functions.ReadExport(in)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", fullPath, err)
 	}
-	log.Info().Int("count", len(projects)).Msg("Loaded projects.json")
+	log.Info().Str("file", fullPath).Int("projects", len(export.Projects)).Int("tasks", len(export.Tasks)).Msg("Loaded export")
 
-	if err := readJSON("sops.json", &sops); err != nil {
-		return fmt.Errorf("reading sops.json: %w", err)
+	token := os.Getenv("CBM_API_TOKEN")
+	if token == "" && !dryRun {
+		return errors.New("importing needs an admin's token in CBM_API_TOKEN")
 	}
-	log.Info().Int("count", len(sops)).Msg("Loaded sops.json")
+	client := functions.APIClient("http://cbm-api:8080/query", token)
+	ctx := context.Background()
 
-	if err := readJSON("tasks.json", &tasks); err != nil {
-		return fmt.Errorf("reading tasks.json: %w", err)
+	log.Info().Msg("Fetching existing projects and tasks...")
+	var existingProjects []functions.Project
+	for _, sop := range []bool{false, true} {
+		resp, err := graph.ReadProjectsFilter(ctx, client, sop)
+		if err != nil {
+			return fmt.Errorf("fetching projects: %w", err)
+		}
+		for _, project := range resp.ReadProjectsFilter {
+			existingProjects = append(existingProjects, functions.ProjectFromGraph(project))
+		}
+	}
+	tasksResp, err := graph.ReadAllTasks(ctx, client)
+	if err != nil {
+		return fmt.Errorf("fetching tasks: %w", err)
+	}
+	var existingTasks []functions.Task
+	for _, task := range tasksResp.ReadAllTasks {
+		existingTasks = append(existingTasks, functions.TaskFromGraph(task))
 	}
-	log.Info().Int("count", len(tasks)).Msg("Loaded tasks.json")
 
-	client := graphql.NewClient("http://cbm-api:8080/query", http.DefaultClient)
-	ctx := context.Background()
+	plan := functions.PlanImport(export, existingProjects, existingTasks)
 
-	log.Info().Msg("Creating projects and SOPs...")
-	for _, proj := range append(projects, sops...) {
-		input := graph.CreateProjectInput{
-			Title:       proj.Title,
-			Sop:         proj.Sop,
-			Description: proj.Description,
-			Labels:      proj.Labels,
-			AssignedTo:  proj.AssignedTo,
-			DueDate:     proj.DueDate,
-			Status:      proj.Status,
+	if dryRun {
+		for _, step := range plan.Projects {
+			log.Info().Str("action", string(step.Action)).Str("project", step.Project.ID).Strs("changes", step.Changes).Msg(step.Project.Title)
+		}
+		for _, step := range plan.Tasks {
+			log.Info().Str("action", string(step.Action)).Str("task", step.Task.ID).Strs("changes", step.Changes).Msg(step.Task.Title)
 		}
+		projects, tasks := plan.Counts()
+		logCounts("Dry run, nothing imported: projects", projects)
+		logCounts("Dry run, nothing imported: tasks", tasks)
+		return nil
+	}
 
-		_, err := graph.CreateProject(ctx, client, input)
+	var projects functions.Counts
+	for _, step := range plan.Projects {
+		var err error
+		switch step.Action {
+		case functions.Create:
+			_, err = graph.CreateProject(ctx, client, functions.CreateProjectInput(step.Project))
+		case functions.Update:
+			_, err = graph.UpdateProject(ctx, client, functions.UpdateProjectInput(step.Project, step.Changes))
+		}
 		if err != nil {
-			log.Error().Str("project", proj.Title).Err(err).Msg("Failed to create project")
-		} else {
-			log.Info().Str("project", proj.Title).Msg("Project created")
+			projects.Failed++
+			log.Error().Str("project", step.Project.ID).Str("action", string(step.Action)).Err(err).Msg("Failed to import project")
+			continue
 		}
+		projects.Add(step.Action)
 	}
 
-	log.Info().Msg("Creating tasks...")
-	for _, task := range tasks {
-		input := graph.CreateTaskInput{
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-			Labels:      []string{},
-			AssignedTo:  task.AssignedTo,
-			DueDate:     task.DueDate,
-			ProjectId:   task.ProjectID,
+	var tasks functions.Counts
+	for _, step := range plan.Tasks {
+		var err error
+		switch step.Action {
+		case functions.Create:
+			_, err = graph.CreateTask(ctx, client, functions.CreateTaskInput(step.Task))
+		case functions.Update:
+			_, err = graph.UpdateTask(ctx, client, functions.UpdateTaskInput(step.Task, step.Changes))
 		}
-
-		_, err := graph.CreateTask(ctx, client, input)
 		if err != nil {
-			log.Error().Str("task", task.Title).Err(err).Msg("Failed to create task")
-		} else {
-			log.Info().Str("task", task.Title).Msg("Task created")
+			tasks.Failed++
+			log.Error().Str("task", step.Task.ID).Str("action", string(step.Action)).Err(err).Msg("Failed to import task")
+			continue
 		}
+		tasks.Add(step.Action)
+	}
+
+	logCounts("Imported projects", projects)
+	logCounts("Imported tasks", tasks)
+	if projects.Failed+tasks.Failed > 0 {
+		return fmt.Errorf("%d projects and %d tasks failed to import", projects.Failed, tasks.Failed)
 	}
 
 	log.Info().Msg("Import complete.")
 	return nil
 }
 
-func writeJSON(filename string, data interface{}) error {
-	bytes, err := json.MarshalIndent(data, "", "  ")
+func logCounts(msg string, counts functions.Counts) {
+	log.Info().Int("created", counts.Created).Int("updated", counts.Updated).Int("skipped", counts.Skipped).Int("failed", counts.Failed).Msg(msg)
+}
+
+// runBackup writes the collections passing the filter to a compressed,
+// checksummed archive, read directly from Mongo or through the API.
+func runBackup(file, source string, filter backup.Filter) error {
+	if file == "" {
+		file = fmt.Sprintf("backup-%s.zip", time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", file, err)
+	}
+	defer out.Close()
+
+	ctx := context.Background()
+	switch source {
+	case "mongo":
+		db := database.Connect()
+		defer db.Close()
+
+		manifest, err := db.Backup(ctx, out, filter)
+		if err != nil {
+			return err
+		}
+		for _, collection := range manifest.Collections {
+			log.Info().Str("collection", collection.Name).Int64("documents", collection.Documents).Str("sha256", collection.SHA256).Msg("Backed up")
+		}
+		if !manifest.Snapshot {
+			log.Warn().Msg("The server cannot read from a snapshot, so collections were read one after another and may be out of step if written to meanwhile.")
+		}
+	case "api":
+		token := os.Getenv("CBM_API_TOKEN")
+		if token == "" {
+			return errors.New("backing up through the API needs an admin's token in CBM_API_TOKEN")
+		}
+		if err := functions.DownloadBackup(ctx, "http://cbm-api:8080", token, filter, out); err != nil {
+			return fmt.Errorf("downloading backup: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown source %q, use mongo or api", source)
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", file, err)
+	}
+	log.Info().Str("archive", file).Msg("Backup complete.")
+	return nil
+}
+
+// runRestore replaces the collections passing the filter with those in an
+// archive, directly in Mongo or through the API, once the whole archive has
+// passed its checksums.
+func runRestore(file, source string, filter backup.Filter) error {
+	if file == "" {
+		return errors.New("name the archive to restore with -archive")
+	}
+
+	in, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", file, err)
+	}
+	defer in.Close()
+	stat, err := in.Stat()
 	if err != nil {
-		log.Error().Err(err).Str("file", filename).Msg("Failed to marshal JSON")
 		return err
 	}
-	err = os.WriteFile(filename, bytes, 0644)
+
+	// Check the archive here whichever way it is restored, so a damaged one
+	// is not even sent.
+	archive, err := backup.Open(in, stat.Size())
 	if err != nil {
-		log.Error().Err(err).Str("file", filename).Msg("Failed to write file")
+		return err
 	}
-	return err
+	if err := archive.Verify(); err != nil {
+		return err
+	}
+	log.Info().Str("database", archive.Manifest.Database).Str("startedAt", archive.Manifest.StartedAt).Int("collections", len(archive.Manifest.Collections)).Msg("Archive verified")
+
+	ctx := context.Background()
+	var restored []backup.Collection
+	switch source {
+	case "mongo":
+		db := database.Connect()
+		defer db.Close()
+
+		if restored, err = db.Restore(ctx, archive, filter); err != nil {
+			return err
+		}
+	case "api":
+		token := os.Getenv("CBM_API_TOKEN")
+		if token == "" {
+			return errors.New("restoring through the API needs an admin's token in CBM_API_TOKEN")
+		}
+		if _, err := in.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if restored, err = functions.UploadRestore(ctx, "http://cbm-api:8080", token, filter, in, stat.Size()); err != nil {
+			return fmt.Errorf("uploading archive: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown source %q, use mongo or api", source)
+	}
+
+	for _, collection := range restored {
+		log.Info().Str("collection", collection.Name).Int64("documents", collection.Documents).Msg("Restored")
+	}
+	log.Info().Str("archive", file).Int("collections", len(restored)).Msg("Restore complete.")
+	return nil
+}
+
+// runMigrate shows which migrations the database has, applies the pending
+// ones up to a version, undoes those above one, or releases the claims a run
+// that died left behind.
+func runMigrate(action string, to int) error {
+	db := database.Connect()
+	defer db.Close()
+
+	ctx := context.Background()
+	switch action {
+	case "status":
+		statuses, err := db.MigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			event := log.Info().Int("version", status.Version).Str("description", status.Description)
+			switch {
+			case status.AppliedAt != nil:
+				event.Time("appliedAt", *status.AppliedAt).Msg("Applied")
+			case status.Running:
+				event.Msg("Claimed by a run, or by one that died; unlock it if none is going")
+			default:
+				event.Msg("Pending")
+			}
+		}
+		if err := db.VerifySchema(ctx); err != nil {
+			log.Warn().Err(err).Msg("Database is not at the expected version")
+		}
+	case "up":
+		applied, err := db.Migrate(ctx, to)
+		for _, migration := range applied {
+			log.Info().Int("version", migration.Version).Str("description", migration.Description).Msg("Applied")
+		}
+		if err != nil {
+			return err
+		}
+		log.Info().Int("applied", len(applied)).Msg("Migrations complete.")
+	case "down":
+		undone, err := db.Rollback(ctx, to)
+		for _, migration := range undone {
+			log.Info().Int("version", migration.Version).Str("description", migration.Description).Msg("Undone")
+		}
+		if err != nil {
+			return err
+		}
+		log.Info().Int("undone", len(undone)).Int("version", to).Msg("Rollback complete.")
+	case "unlock":
+		unlocked, err := db.UnlockMigrations(ctx, to)
+		for _, version := range unlocked {
+			log.Info().Int("version", version).Msg("Unlocked")
+		}
+		if err != nil {
+			return err
+		}
+		log.Info().Int("unlocked", len(unlocked)).Msg("Unlock complete.")
+	default:
+		return fmt.Errorf("unknown action %q, use status, up, down or unlock", action)
+	}
+	return nil
 }
 
 func readJSON(filename string, out interface{}) error {
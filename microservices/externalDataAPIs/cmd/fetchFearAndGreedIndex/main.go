@@ -2,26 +2,22 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
-	"strconv"
 
+	"cryptobotmanager.com/cbm-backend/microservices/externalDataAPIs/jobs"
 	"cryptobotmanager.com/cbm-backend/shared"
-	"cryptobotmanager.com/cbm-backend/shared/graph"
 	"github.com/Khan/genqlient/graphql"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
 )
 
-type FNGResponse struct {
-	Data []struct {
-		Value               string `json:"value"`
-		ValueClassification string `json:"value_classification"`
-		Timestamp           string `json:"timestamp"`
-	} `json:"data"`
-}
+var (
+	backfill = flag.Bool("backfill", false, "import the full index history instead of just today's value")
+	file     = flag.String("file", "", "with -backfill, import from this local JSON file in the API's format instead of the API")
+)
 
 func main() {
 	err := godotenv.Load(".env")
@@ -34,43 +30,29 @@ func main() {
 	if backend == "" {
 		backend = "http://cbm-api:8080/query"
 	}
-	// Initialize logger
+	// Initialize logger, which also parses -backfill and -file with its own flags
 	shared.SetupLogger()
 
-	// Step 1: Fetch from FNG API
-	resp, err := http.Get("https://api.alternative.me/fng/?limit=1")
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to fetch FNG index")
-	}
-	defer resp.Body.Close()
-
-	var raw FNGResponse
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
-		log.Fatal().Err(err).Msg("Failed to decode response")
-	}
-
-	if len(raw.Data) == 0 {
-		log.Error().Msg("No data returned from FNG API")
-		return
+	// Step 1: Fetch from FNG API, or a local file when backfilling from one.
+	// A limit of 0 asks the API for every day it has.
+	var raw jobs.FNGResponse
+	switch {
+	case *backfill && *file != "":
+		raw, err = jobs.LoadFNG(*file)
+	case *backfill:
+		raw, err = jobs.FetchFNG(0)
+	default:
+		raw, err = jobs.FetchFNG(1)
 	}
-
-	latest := raw.Data[0]
-
-	tsInt, err := strconv.Atoi(latest.Timestamp)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Invalid timestamp")
+		log.Fatal().Err(err).Msg("Failed to fetch FNG index")
 	}
 
-	fmt.Printf("Fear & Greed Index: %s (%s) at %d\n", latest.Value, latest.ValueClassification, tsInt)
-
-	// Step 3: Prepare GraphQL client and input
+	// Step 2: Prepare GraphQL client and save each entry
 	client := graphql.NewClient(backend, &http.Client{})
 	ctx := context.Background()
 
-	req, err := graph.UpsertFearAndGreedIndex(ctx, client, tsInt, latest.Value, latest.ValueClassification)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to create GraphQL request")
+	if err := jobs.SaveFNGEntries(ctx, client, raw); err != nil {
+		log.Error().Err(err).Msg("Failed to store Fear & Greed Index")
 	}
-
-	fmt.Printf("Saved to DB: %+v\n", req.UpsertFearAndGreedIndex)
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	trade "cryptobotmanager.com/cbm-backend/microservices/externalDataAPIs/streamPrices"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/messaging"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog/log"
+)
+
+// The paper trading service trades each signal on the live price stream and
+// publishes the trades it opens and closes.
+func main() {
+	err := godotenv.Load(".env")
+	if err != nil {
+		fmt.Println("Warning: No .env file found or failed to load")
+	}
+
+	// Setup GraphQL backend client
+	backend := os.Getenv("TRADING_BOT_URL")
+	if backend == "" {
+		backend = "http://cbm-api:8080/query"
+	}
+	// Initialize logger
+	shared.SetupLogger()
+
+	ctx := context.Background()
+	client := graphql.NewClient(backend, &http.Client{})
+
+	bus, err := messaging.ConnectFromEnv(ctx, "paper-trading")
+	if err != nil || bus == nil {
+		log.Fatal().Err(err).Msg("The paper trading service needs NATS_URL to be set")
+	}
+	messaging.SetupSignalHandlers(bus.Conn())
+
+	// Trades run for minutes, longer than an event may go unacknowledged, so
+	// each runs on its own and the signal is acknowledged once the trade is
+	// open. A signal is only worth trading on until the next tick.
+	_, err = messaging.Subscribe(ctx, bus, "paper-trading", messaging.SubjectTradeSignal, messaging.Tick, func(ctx context.Context, signal messaging.TradeSignal) error {
+		log.Info().Str("BotName", signal.Strategy.BotInstanceName).Str("Symbol", signal.Symbol).Msg("Paper trading signal")
+		opened := make(chan error, 1)
+		go trade.ListenAndPaperTrade(ctx, client, bus, signal.Symbol, signal.Strategy, opened)
+		return <-opened
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to subscribe to trade signals")
+	}
+
+	select {}
+}
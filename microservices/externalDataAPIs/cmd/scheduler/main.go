@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/microservices/externalDataAPIs/jobs"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"cryptobotmanager.com/cbm-backend/shared/scheduler"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog/log"
+)
+
+// The scheduler service runs the external data jobs in process, in place of
+// the crontab that launched each of them as its own process, along with the
+// promotion of deferred tasks.
+func main() {
+	err := godotenv.Load(".env")
+	if err != nil {
+		fmt.Println("Warning: No .env file found or failed to load")
+	}
+
+	// Setup GraphQL backend client
+	backend := os.Getenv("TRADING_BOT_URL")
+	if backend == "" {
+		backend = "http://cbm-api:8080/query"
+	}
+	// Initialize logger
+	shared.SetupLogger()
+
+	client := graphql.NewClient(backend, &http.Client{})
+
+	// fetchPrices and fetchLiquidity save snapshots of the market now, so they
+	// do not catch up: a missed slot would be saved with today's figures. Their
+	// missed slots are recorded as skipped instead.
+	s, err := scheduler.New(client,
+		scheduler.Job{
+			Name:     "fetchPrices",
+			Schedule: "*/5 * * * *",
+			Run:      fetchPrices(client),
+		},
+		scheduler.Job{
+			Name:     "fetchFearAndGreedIndex",
+			Schedule: "3 0 * * *",
+			CatchUp:  1,
+			Run: func(ctx context.Context, _ time.Time, _ graph.JobTrigger) error {
+				return jobs.FetchFearAndGreedIndex(ctx, client)
+			},
+		},
+		scheduler.Job{
+			Name:     "fetchLiquidity",
+			Schedule: "5 0 * * *",
+			Run: func(ctx context.Context, slot time.Time, _ graph.JobTrigger) error {
+				startOfDay := time.Date(slot.Year(), slot.Month(), slot.Day(), 0, 0, 0, 0, time.UTC).Unix()
+				return jobs.SnapshotLiquidity(ctx, client, startOfDay)
+			},
+		},
+		scheduler.Job{
+			Name:     "promoteDeferredTasks",
+			Schedule: "*/15 * * * *",
+			Run: func(ctx context.Context, _ time.Time, _ graph.JobTrigger) error {
+				_, err := graph.PromoteDeferredTasks(ctx, client)
+				return err
+			},
+		},
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up the scheduler")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	log.Info().Msg("Scheduler started")
+	s.Run(ctx)
+	log.Info().Msg("Scheduler stopped")
+}
+
+// fetchPrices saves the slot's prices, then trades them in the background so
+// that trades lasting longer than a slot do not hold up the next one.
+func fetchPrices(client graphql.Client) func(context.Context, time.Time, graph.JobTrigger) error {
+	return func(ctx context.Context, slot time.Time, _ graph.JobTrigger) error {
+		currentDatetime := shared.RoundTimeToFiveMinuteInterval(slot.Unix())
+
+		market, err := jobs.FetchPrices(ctx, client, currentDatetime)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			if err := jobs.Trade(ctx, client, market, currentDatetime); err != nil {
+				log.Error().Err(err).Int("Timestamp", currentDatetime).Msg("Failed to trade prices")
+			}
+		}()
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/rs/zerolog/log"
+)
+
+// FNGResponse is the alternative.me Fear & Greed API response.
+type FNGResponse struct {
+	Data []FNGEntry `json:"data"`
+}
+
+// FNGEntry is one day of the index.
+type FNGEntry struct {
+	Value               string `json:"value"`
+	ValueClassification string `json:"value_classification"`
+	Timestamp           string `json:"timestamp"`
+}
+
+// FetchFearAndGreedIndex saves today's Fear & Greed index.
+func FetchFearAndGreedIndex(ctx context.Context, client graphql.Client) error {
+	raw, err := FetchFNG(1)
+	if err != nil {
+		return fmt.Errorf("failed to fetch FNG index: %w", err)
+	}
+	return SaveFNGEntries(ctx, client, raw)
+}
+
+// SaveFNGEntries saves every entry of the response, failing if none could be saved.
+func SaveFNGEntries(ctx context.Context, client graphql.Client, raw FNGResponse) error {
+	if len(raw.Data) == 0 {
+		return fmt.Errorf("no data returned from FNG API")
+	}
+
+	saved := 0
+	for _, entry := range raw.Data {
+		if err := SaveFNG(ctx, client, entry); err != nil {
+			log.Error().Err(err).Str("Timestamp", entry.Timestamp).Msg("Failed to save FNG index")
+			continue
+		}
+		saved++
+	}
+
+	log.Info().Int("Saved", saved).Int("Entries", len(raw.Data)).Msg("Fear & Greed Index stored")
+	if saved == 0 {
+		return fmt.Errorf("failed to save any of %d FNG entries", len(raw.Data))
+	}
+	return nil
+}
+
+// FetchFNG requests the latest limit days of the index from the API, or all of them when limit is 0.
+func FetchFNG(limit int) (FNGResponse, error) {
+	var raw FNGResponse
+
+	resp, err := http.Get(fmt.Sprintf("https://api.alternative.me/fng/?limit=%d", limit))
+	if err != nil {
+		return raw, err
+	}
+	defer resp.Body.Close()
+
+	return decodeFNG(resp.Body)
+}
+
+// LoadFNG reads index history saved from the API to a local file.
+func LoadFNG(path string) (FNGResponse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FNGResponse{}, err
+	}
+	defer f.Close()
+
+	return decodeFNG(f)
+}
+
+func decodeFNG(r io.Reader) (FNGResponse, error) {
+	var raw FNGResponse
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return raw, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return raw, nil
+}
+
+// SaveFNG upserts one day of the index, keyed by its timestamp so re-running a backfill is safe.
+func SaveFNG(ctx context.Context, client graphql.Client, entry FNGEntry) error {
+	tsInt, err := strconv.Atoi(entry.Timestamp)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q: %w", entry.Timestamp, err)
+	}
+
+	log.Debug().Str("Value", entry.Value).Str("Classification", entry.ValueClassification).Int("Timestamp", tsInt).Msg("Fear & Greed Index")
+
+	_, err = graph.UpsertFearAndGreedIndex(ctx, client, tsInt, entry.Value, entry.ValueClassification)
+	return err
+}
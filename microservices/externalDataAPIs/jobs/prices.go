@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	backTesting "cryptobotmanager.com/cbm-backend/microservices/backTesting/functions"
+	binance "cryptobotmanager.com/cbm-backend/microservices/externalDataAPIs"
+	filter "cryptobotmanager.com/cbm-backend/microservices/filters/functions"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/messaging"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/rs/zerolog/log"
+)
+
+// FetchPricesFromBinanceAPI fetches market prices from Binance API
+// using API and Secret keys from environment variables.
+// It returns a slice of PriceData structs and an error if any.
+func FetchPricesFromBinanceAPI() (market []model.Pair, err error) {
+	client := binance.NewBinanceClient()
+
+	prices, err := client.NewListPricesService().Do(context.Background())
+	if err != nil {
+		log.Error().Err(err).Msgf("NewListPricesService")
+		return nil, err
+	}
+	fmt.Println("qty", len(prices))
+
+	// iterate over prices and build into slice of structs
+	for _, price := range prices {
+		market = append(market, model.Pair{Symbol: price.Symbol, Price: price.Price})
+		log.Info().Str("Symbol:", price.Price).Str("Price", price.Price)
+	}
+
+	return market, nil
+}
+
+// FetchPrices saves the market's prices for the five minute slot, enriched
+// with the % change since the previous slot, and returns them. Binance only
+// gives the latest prices, so it must run at its slot: a run for a past slot
+// would save today's prices as that slot's history.
+func FetchPrices(ctx context.Context, client graphql.Client, currentDatetime int) ([]model.Pair, error) {
+	previousDateTime := currentDatetime - 300
+
+	currentPrices, err := FetchPricesFromBinanceAPI()
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get price data from Binance!")
+		return nil, err
+	}
+
+	previousPrices, err := filter.GetPriceData(ctx, client, previousDateTime, "Gopher")
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get previous price data!")
+		return nil, err
+	}
+
+	market, err := filter.EnrichWithPercentageChange(currentPrices, previousPrices)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to enrich prices with % change")
+		return nil, err
+	}
+
+	err = shared.SavePriceDataAsJSON(market, int64(currentDatetime))
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to save price data to JSON!")
+	}
+
+	if err := shared.SavePriceData(ctx, client, market, currentDatetime); err != nil {
+		log.Error().Err(err).Int("timestamp", currentDatetime).Msg("Save PriceData")
+		return market, err
+	}
+	return market, nil
+}
+
+// Trade hands the slot's prices to the trading services. With an event bus
+// the filters, reports and paper trading services take the snapshot from
+// here; without one, it trades in process until every trade has closed.
+func Trade(ctx context.Context, client graphql.Client, market []model.Pair, currentDatetime int) error {
+	bus, err := messaging.ConnectFromEnv(ctx, "fetchPrices")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to connect to NATS, trading in process")
+	}
+	if bus == nil {
+		return backTesting.LetsTrade(ctx, client, market, currentDatetime)
+	}
+	defer bus.Close()
+
+	snapshot := messaging.PricesSnapshot{Timestamp: currentDatetime, Pairs: market}
+	id := fmt.Sprintf("%s.%d", messaging.SubjectPricesSnapshot, currentDatetime)
+	return bus.Publish(ctx, messaging.SubjectPricesSnapshot, id, snapshot)
+}
@@ -13,6 +13,7 @@ import (
 	"cryptobotmanager.com/cbm-backend/microservices/reports/functions"
 	"cryptobotmanager.com/cbm-backend/shared"
 	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"cryptobotmanager.com/cbm-backend/shared/messaging"
 	"github.com/Khan/genqlient/graphql"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog/log"
@@ -32,14 +33,29 @@ type TradeValues struct {
 	StopLoss   float64
 }
 
-func ListenAndPaperTrade(ctx context.Context, client graphql.Client, symbol string, details model.StrategyInput) {
+// ListenAndPaperTrade paper trades the symbol with the strategy on the live
+// trade stream until it takes profit, stops out or times out. Opening and
+// closing the trade are published on the bus, which may be nil. Unless nil,
+// opened is sent nil once the trade is open, or the error that stopped it
+// opening; it needs room for the one value.
+func ListenAndPaperTrade(ctx context.Context, client graphql.Client, bus *messaging.Bus, symbol string, details model.StrategyInput, opened chan<- error) {
 	lowerSymbol := strings.ToLower(symbol)
 	wsURL := fmt.Sprintf("wss://stream.binance.com:9443/ws/%s@trade", lowerSymbol)
 
 	botName := details.BotInstanceName
+	var versionID string
+	if details.VersionID != nil {
+		versionID = *details.VersionID
+	}
 	accountBalance := details.AccountBalance
 	feesBalance := details.FeesTotal
 
+	notify := func(err error) {
+		if opened != nil {
+			opened <- err
+		}
+	}
+
 	// Connect
 	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
 	if err != nil {
@@ -47,6 +63,7 @@ func ListenAndPaperTrade(ctx context.Context, client graphql.Client, symbol stri
 			Err(err).
 			Str("symbol", symbol).
 			Msg("WebSocket dial failed")
+		notify(fmt.Errorf("opening the %s trade stream: %w", symbol, err))
 		return
 	}
 	defer conn.Close()
@@ -58,6 +75,7 @@ func ListenAndPaperTrade(ctx context.Context, client graphql.Client, symbol stri
 	openingPrice, err := getLatestPrice(symbol)
 	if err != nil {
 		fmt.Println("Error getting latest price:", err)
+		notify(fmt.Errorf("getting the latest %s price: %w", symbol, err))
 		return
 	}
 
@@ -68,6 +86,33 @@ func ListenAndPaperTrade(ctx context.Context, client graphql.Client, symbol stri
 		Float64("Take profit set at:", exitValues.TakeProfit).
 		Float64("Stop Loss set at", exitValues.StopLoss).
 		Msg("Exits")
+	functions.TradeOpenedReport(client, int(startTime), exitValues.TimedOut, botName, versionID, symbol, openingPrice, exitValues.TakeProfit, exitValues.StopLoss)
+	publishTradeEvent(ctx, bus, messaging.SubjectTradeOpened, botName, int(startTime), messaging.TradeOpened{
+		Timestamp:  int(startTime),
+		BotName:    botName,
+		VersionID:  versionID,
+		Symbol:     symbol,
+		EntryPrice: openingPrice,
+		TakeProfit: exitValues.TakeProfit,
+		StopLoss:   exitValues.StopLoss,
+		TimesOutAt: exitValues.TimedOut,
+	})
+	notify(nil)
+
+	// closed publishes the trade's exit on the bus
+	closed := func(outcome string, streamTime, elapsedTime int, change, balance, fees float64) {
+		publishTradeEvent(ctx, bus, messaging.SubjectTradeClosed, botName, int(startTime), messaging.TradeClosed{
+			Timestamp:        streamTime,
+			BotName:          botName,
+			VersionID:        versionID,
+			Symbol:           symbol,
+			Outcome:          outcome,
+			PercentageChange: change,
+			Balance:          balance,
+			Fee:              fees,
+			ElapsedTime:      elapsedTime,
+		})
+	}
 
 	for {
 		_, message, err := conn.ReadMessage()
@@ -109,7 +154,8 @@ func ListenAndPaperTrade(ctx context.Context, client graphql.Client, symbol stri
 		case currentPrice >= exitValues.TakeProfit:
 			change := shared.PercentageChange(openingPrice, currentPrice)
 			updatedBalance, fees, netOutcome := CalculateUpdatedBalance(accountBalance, change, 0.06)
-			functions.TradeOutcomeReport(client, streamTime, elapsedTime, botName, change, updatedBalance, volume, fees, symbol, "WIN")
+			functions.TradeOutcomeReport(client, streamTime, elapsedTime, botName, versionID, change, updatedBalance, volume, fees, symbol, "WIN")
+			closed("WIN", streamTime, elapsedTime, change, updatedBalance, fees)
 
 			outCome = graph.UpdateCountersInput{
 				BotInstanceName:    botName,
@@ -137,6 +183,9 @@ func ListenAndPaperTrade(ctx context.Context, client graphql.Client, symbol stri
 		case currentPrice <= exitValues.StopLoss:
 			change := shared.PercentageChange(openingPrice, currentPrice)
 			updatedBalance, fees, _ := CalculateUpdatedBalance(accountBalance, change, 0.06)
+			functions.TradeOutcomeReport(client, streamTime, elapsedTime, botName, versionID, change, updatedBalance, volume, fees, symbol, "LOSS")
+			closed("LOSS", streamTime, elapsedTime, change, updatedBalance, fees)
+
 			outCome = graph.UpdateCountersInput{
 				BotInstanceName:    botName,
 				WINCounter:         false,
@@ -160,7 +209,8 @@ func ListenAndPaperTrade(ctx context.Context, client graphql.Client, symbol stri
 		case streamTime >= exitValues.TimedOut:
 			change := shared.PercentageChange(openingPrice, currentPrice)
 			updatedBalance, fees, netOutcome := CalculateUpdatedBalance(accountBalance, change, 0.06)
-			functions.TradeOutcomeReport(client, streamTime, elapsedTime, botName, change, updatedBalance, volume, fees, symbol, "TIMED OUT")
+			functions.TradeOutcomeReport(client, streamTime, elapsedTime, botName, versionID, change, updatedBalance, volume, fees, symbol, "TIMED OUT")
+			closed("TIMED OUT", streamTime, elapsedTime, change, updatedBalance, fees)
 
 			// Assuming change is the percentage change
 			if change > 0 {
@@ -204,6 +254,15 @@ func ListenAndPaperTrade(ctx context.Context, client graphql.Client, symbol stri
 	}
 }
 
+// publishTradeEvent publishes the trade event, logging rather than failing the
+// trade if the bus is down. The bot and opening time identify the trade.
+func publishTradeEvent(ctx context.Context, bus *messaging.Bus, subject, botName string, openedAt int, event any) {
+	id := fmt.Sprintf("%s.%s.%d", subject, botName, openedAt)
+	if err := bus.Publish(ctx, subject, id, event); err != nil {
+		log.Error().Err(err).Str("Subject", subject).Str("BotName", botName).Msg("Failed to publish trade event")
+	}
+}
+
 func getLatestPrice(symbol string) (float64, error) {
 	key := "https://api.binance.com/api/v3/ticker/price?symbol=" + strings.ToUpper(symbol)
 	resp, err := http.Get(key)
@@ -14,7 +14,9 @@ replace cryptobotmanager.com/cbm-backend/cbm-api/graph/generated => ../../cbm-ap
 
 require (
 	cryptobotmanager.com/cbm-backend/cbm-api v0.0.0-00010101000000-000000000000
+	cryptobotmanager.com/cbm-backend/microservices/backTesting v0.0.0-00010101000000-000000000000
 	cryptobotmanager.com/cbm-backend/shared v0.0.0-00010101000000-000000000000
+	github.com/joho/godotenv v1.5.1
 	github.com/rs/zerolog v1.34.0
 )
 
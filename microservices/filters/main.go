@@ -1,38 +1,62 @@
 package main
 
-// Add any other necessary imports
-
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	backTesting "cryptobotmanager.com/cbm-backend/microservices/backTesting/functions"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/messaging"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog/log"
+)
+
+// The filters service turns each price snapshot into trade signals for the
+// strategies that find a symbol to trade.
 func main() {
-
-	// backend := os.Getenv("TRADING_BOT_URL")
-	// if backend == "" {
-	// 	backend = "http://cbm-api:8080/query"
-	// }
-
-	// // Get the nearest whole 5 minutes & print the current time
-	// now := time.Now().Unix()
-	// roundedEpochSeconds := shared.RoundTimeToFiveMinuteInterval(now)
-	// log.Info().Int64("Executing task at:", now).Int("Rounded time", roundedEpochSeconds).Msg("Time")
-
-	// // Create Client & Context
-	// client := graphql.NewClient(backend, &http.Client{})
-	// ctx := context.Background()
-	// var market []model.Pair
-	// var err error
-
-	// market, err = functions.FetchPricesFromBinanceAPI()
-	// if err != nil {
-	// 	log.Error().Err(err).Msgf("Failed to get price data from Binance!")
-	// }
-
-	// err = functions.SavePriceDataAsJSON(market, int64(roundedEpochSeconds))
-	// if err != nil {
-	// 	log.Error().Err(err).Msgf("Failed to save price data to JSON!")
-	// }
-
-	// err = functions.SavePriceData(ctx, client, market, roundedEpochSeconds)
-	// if err != nil {
-	// 	log.Error().Err(err).Msgf("Save PriceData")
-	// }
-
+	err := godotenv.Load(".env")
+	if err != nil {
+		fmt.Println("Warning: No .env file found or failed to load")
+	}
+
+	// Setup GraphQL backend client
+	backend := os.Getenv("TRADING_BOT_URL")
+	if backend == "" {
+		backend = "http://cbm-api:8080/query"
+	}
+	// Initialize logger
+	shared.SetupLogger()
+
+	ctx := context.Background()
+	client := graphql.NewClient(backend, &http.Client{})
+
+	bus, err := messaging.ConnectFromEnv(ctx, "filters")
+	if err != nil || bus == nil {
+		log.Fatal().Err(err).Msg("The filters service needs NATS_URL to be set")
+	}
+	messaging.SetupSignalHandlers(bus.Conn())
+
+	// A snapshot is only worth trading on until the next one
+	_, err = messaging.Subscribe(ctx, bus, "filters", messaging.SubjectPricesSnapshot, messaging.Tick, func(ctx context.Context, snapshot messaging.PricesSnapshot) error {
+		signals, err := backTesting.SelectTrades(ctx, client, snapshot.Pairs, snapshot.Timestamp)
+		if err != nil {
+			return err
+		}
+		for _, signal := range signals {
+			id := fmt.Sprintf("%s.%s.%d", messaging.SubjectTradeSignal, signal.Strategy.BotInstanceName, signal.Timestamp)
+			if err := bus.Publish(ctx, messaging.SubjectTradeSignal, id, signal); err != nil {
+				return err
+			}
+		}
+		log.Info().Int("Timestamp", snapshot.Timestamp).Int("Signals", len(signals)).Msg("Published trade signals")
+		return nil
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to subscribe to price snapshots")
+	}
+
+	select {}
 }
@@ -2,63 +2,50 @@ package functions
 
 import (
 	"cryptobotmanager.com/cbm-backend/shared"
-	"github.com/go-gota/gota/dataframe"
+	"cryptobotmanager.com/cbm-backend/shared/stats"
 	"github.com/rs/zerolog/log"
 )
 
-// AverageGain function takes in a slice of Gainers and an integer value topN as
-// arguments. It converts the slice into a dataframe, sorts it in descending order
-// by IncrementPriceGain, and then selects the top N coins using Subset() method.
-// It then calculates the mean of IncrementPriceGain for the selected coins and
-// logs relevant information using zerolog package. The function returns the
-// average gain as a float64 value.
+// AverageGain returns the mean IncrementPriceGain of the topN gainers, rounded
+// to 2 decimal places. A topN larger than the slice averages all of it; an
+// empty slice or a topN below one gives zero. Callers needing several figures
+// from the same slice should build a stats.Sample once instead.
 func AverageGain(coinsWithMomentum *[]shared.Gainers, topN int) float64 {
-	newOne := *coinsWithMomentum
-
-	qty := len(newOne)
-	coinsDf := dataframe.LoadStructs(newOne)
-	//fmt.Print("COINS WITH MOMENTUM", coinsDf)
-
-	sorted := coinsDf.Arrange(dataframe.RevSort("IncrementPriceGain"))
-	//fmt.Print("SORTED", sorted)
-
-	// Ensure topN does not exceed the number of coins
-	if topN > qty {
-		topN = qty
-	}
-
-	// Check if the DataFrame is empty
-	if qty == 0 {
-		log.Warn().Msg("DataFrame is empty")
+	sample := stats.FromGainers(*coinsWithMomentum)
+	if sample.Len() == 0 || topN <= 0 {
+		log.Warn().Int("coins_qty", sample.Len()).Int("top_n", topN).Msg("No gainers to average")
 		return 0.0
 	}
 
-	// Check if topN is valid
-	if topN <= 0 {
-		log.Warn().Msg("Invalid topN value")
-		return 0.0
-	}
-
-	// Create a subset only if there are elements in the DataFrame
-	subsetIndices := make([]int, topN)
-	for i := 0; i < topN; i++ {
-		subsetIndices[i] = i
-	}
+	average := sample.TopMean(topN)
+	log.Debug().Int("coins_qty", sample.Len()).Int("top_n", topN).Float64("avg_gain", average).Msg("average gain for top coins")
 
-	sub := sorted.Subset(subsetIndices)
-	//fmt.Print("SUBSET", sub)
-
-	// Log top N coins with momentum
-	log.Trace().Int("top_n", topN).Msg("top coins with momentum")
-
-	averageDF := sub.Col("IncrementPriceGain")
-	col := coinsDf.Col("Symbol")
-	tickers := col.Records()
+	return shared.Round(average, 2)
+}
 
-	average := averageDF.Mean()
+// ActivityFigures are the gains summarised by an activity report.
+type ActivityFigures struct {
+	AllMovers float64   // Mean gain of every pair on the move
+	Tops      []float64 // Mean gain of the top N for each of the requested tops, zero if fewer pairs are on the move
+	Median    float64
+	StdDev    float64
+}
 
-	// Log average gain for top N coins
-	log.Debug().Int("coins_qty", qty).Float64("avg_gain", average).Interface("tickers", tickers).Msg("average gain for top coins")
+// SummariseGains computes all of an activity report's figures from a single
+// sort of the pairs on the move. Means are rounded to 2 decimal places.
+func SummariseGains(pairsOnTheMove []shared.Gainers, topAverages []int) ActivityFigures {
+	sample := stats.FromGainers(pairsOnTheMove)
 
-	return shared.Round(average, 2)
+	figures := ActivityFigures{
+		AllMovers: shared.Round(sample.Mean(), 2),
+		Tops:      make([]float64, len(topAverages)),
+		Median:    sample.Median(),
+		StdDev:    sample.StdDev(),
+	}
+	for i, topN := range topAverages {
+		if topN > 0 && sample.Len() >= topN {
+			figures.Tops[i] = shared.Round(sample.TopMean(topN), 2)
+		}
+	}
+	return figures
 }
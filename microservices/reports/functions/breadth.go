@@ -0,0 +1,179 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"cryptobotmanager.com/cbm-backend/shared/stats"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/rs/zerolog/log"
+)
+
+// breadthEdges are the % change boundaries of the distribution buckets. The
+// lowest and highest buckets are open ended.
+var breadthEdges = []float64{-2, -1, -0.5, -0.1, 0, 0.1, 0.5, 1, 2}
+
+// quoteAssets are the quote assets pairs are broken down by, longest first so
+// that e.g. FDUSD is matched before USD.
+var quoteAssets = []string{"FDUSD", "USDT", "USDC", "TUSD", "BUSD", "USD", "BTC", "ETH", "BNB", "TRY", "EUR", "GBP", "BRL", "JPY"}
+
+// OtherQuoteAsset groups pairs whose quote asset is not in the breakdown.
+const OtherQuoteAsset = "OTHER"
+
+// QuoteAsset returns the quote asset of the symbol, or OtherQuoteAsset.
+func QuoteAsset(symbol string) string {
+	for _, quote := range quoteAssets {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return quote
+		}
+	}
+	return OtherQuoteAsset
+}
+
+// BuildMarketBreadth summarises the tick's % changes across the whole market.
+// quoteVolumes maps symbols to their 24h quote volume; without any the share of
+// volume on the move is left unknown. Pairs without a % change are ignored.
+func BuildMarketBreadth(market []model.Pair, quoteVolumes map[string]float64, threshold float64, timestamp int) graph.MarketBreadthInput {
+	breadth := graph.MarketBreadthInput{
+		Timestamp:   timestamp,
+		Buckets:     breadthBuckets(),
+		QuoteAssets: []graph.QuoteAssetBreadthInput{},
+	}
+
+	var changes []float64
+	perQuote := map[string][]float64{}
+	var totalVolume, movingVolume float64
+	for _, pair := range market {
+		if pair.PercentageChange == nil {
+			continue
+		}
+		change, err := strconv.ParseFloat(*pair.PercentageChange, 64)
+		if err != nil {
+			continue
+		}
+		changes = append(changes, change)
+
+		quote := QuoteAsset(pair.Symbol)
+		perQuote[quote] = append(perQuote[quote], change)
+
+		switch {
+		case change > 0:
+			breadth.Advancers++
+		case change < 0:
+			breadth.Decliners++
+		default:
+			breadth.Unchanged++
+		}
+		if change >= threshold {
+			breadth.OnTheMove++
+		}
+		breadth.Buckets[stats.Bin(change, breadthEdges)].Count++
+
+		if volume, ok := quoteVolumes[pair.Symbol]; ok {
+			totalVolume += volume
+			if change >= threshold {
+				movingVolume += volume
+			}
+		}
+	}
+
+	breadth.Pairs = len(changes)
+	if breadth.Pairs == 0 {
+		return breadth
+	}
+
+	breadth.AdvancePercent = float64(breadth.Advancers) / float64(breadth.Pairs) * 100
+	sample := stats.New(changes)
+	breadth.MedianChange = sample.Median()
+	breadth.MeanChange = sample.Mean()
+
+	if totalVolume > 0 {
+		share := movingVolume / totalVolume * 100
+		breadth.VolumeShareMoving = &share
+	}
+
+	for quote, quoteChanges := range perQuote {
+		entry := graph.QuoteAssetBreadthInput{
+			QuoteAsset:   quote,
+			Pairs:        len(quoteChanges),
+			MedianChange: stats.New(quoteChanges).Median(),
+		}
+		for _, change := range quoteChanges {
+			if change > 0 {
+				entry.Advancers++
+			} else if change < 0 {
+				entry.Decliners++
+			}
+		}
+		breadth.QuoteAssets = append(breadth.QuoteAssets, entry)
+	}
+	sort.Slice(breadth.QuoteAssets, func(i, j int) bool {
+		a, b := breadth.QuoteAssets[i], breadth.QuoteAssets[j]
+		if a.Pairs == b.Pairs {
+			return a.QuoteAsset < b.QuoteAsset
+		}
+		return a.Pairs > b.Pairs
+	})
+
+	return breadth
+}
+
+// breadthBuckets returns the empty distribution buckets, lowest first.
+func breadthBuckets() []graph.BreadthBucketInput {
+	buckets := make([]graph.BreadthBucketInput, 0, len(breadthEdges)+1)
+	for i := 0; i <= len(breadthEdges); i++ {
+		var bucket graph.BreadthBucketInput
+		switch {
+		case i == 0:
+			bucket.Max = &breadthEdges[i]
+			bucket.Label = fmt.Sprintf("below %g", breadthEdges[i])
+		case i == len(breadthEdges):
+			bucket.Min = &breadthEdges[i-1]
+			bucket.Label = fmt.Sprintf("%g and above", breadthEdges[i-1])
+		default:
+			bucket.Min, bucket.Max = &breadthEdges[i-1], &breadthEdges[i]
+			bucket.Label = fmt.Sprintf("%g to %g", breadthEdges[i-1], breadthEdges[i])
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+// MarketBreadthReport records the breadth of the whole market for the tick,
+// using the ticker stats stored at the same time for the volume share.
+func MarketBreadthReport(client graphql.Client, market []model.Pair, threshold float64, now int) {
+	ctx := context.Background()
+
+	quoteVolumes := map[string]float64{}
+	resp, err := graph.ReadHistoricTickerStatsAtTimestamp(ctx, client, now)
+	if err != nil {
+		log.Warn().Err(err).Int("Timestamp", now).Msg("No ticker stats for market breadth volume share")
+	} else {
+		for _, snapshot := range resp.ReadHistoricTickerStatsAtTimestamp {
+			for _, ticker := range snapshot.Stats {
+				volume, err := strconv.ParseFloat(ticker.QuoteVolume, 64)
+				if err != nil {
+					continue
+				}
+				quoteVolumes[ticker.Symbol] = volume
+			}
+		}
+	}
+
+	breadth := BuildMarketBreadth(market, quoteVolumes, threshold, now)
+	if breadth.Pairs == 0 {
+		log.Warn().Int("Timestamp", now).Msg("No price changes, skipping market breadth report")
+		return
+	}
+
+	log.Debug().Int("Advancers", breadth.Advancers).Int("Decliners", breadth.Decliners).Float64("MedianChange", breadth.MedianChange).Msg("Market breadth")
+
+	if _, err := graph.CreateMarketBreadth(ctx, client, breadth); err != nil {
+		log.Error().Err(err).Int("Timestamp", now).Msg("Failed to add market breadth report")
+	}
+}
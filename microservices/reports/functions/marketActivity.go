@@ -4,14 +4,14 @@ import (
 	"context"
 
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	filter "cryptobotmanager.com/cbm-backend/microservices/filters/functions"
 	"cryptobotmanager.com/cbm-backend/shared"
 	"cryptobotmanager.com/cbm-backend/shared/graph"
-	sharedmodel "cryptobotmanager.com/cbm-backend/shared/model"
 	"github.com/Khan/genqlient/graphql"
 	"github.com/rs/zerolog/log"
 )
 
-func MarketActivityReport(client graphql.Client, TopAverages []int, pairsOnTheMove []shared.Gainers, now int) {
+func MarketActivityReport(client graphql.Client, TopAverages []int, pairsOnTheMove []shared.Gainers, marketSize, now int) {
 	if len(pairsOnTheMove) == 0 {
 		log.Warn().Msg("No pairs on the move, skipping market activity report")
 		return
@@ -28,55 +28,43 @@ func MarketActivityReport(client graphql.Client, TopAverages []int, pairsOnTheMo
 		}
 	}
 
-	ManageSymbolStats(client, pairsOnTheMove[:min(10, len(pairsOnTheMove))])
+	ManageSymbolStats(client, pairsOnTheMove[:min(10, len(pairsOnTheMove))], now)
 
 	// Call the ActivityReport function to handle the report creation
-	ActivityReport(client, TopAverages, pairsOnTheMove, now)
+	ActivityReport(client, TopAverages, pairsOnTheMove, marketSize, now)
 
 }
 
-func ActivityReport(client graphql.Client, TopAverages []int, pairsOnTheMove []shared.Gainers, now int) {
+// ActivityReport records how much of the market is on the move, stamped with
+// the fear and greed index and market status at the time.
+func ActivityReport(client graphql.Client, TopAverages []int, pairsOnTheMove []shared.Gainers, marketSize, now int) {
 	allPairs := len(pairsOnTheMove)
-	allMovers := AverageGain(&pairsOnTheMove, allPairs)
+	figures := SummariseGains(pairsOnTheMove, TopAverages)
 
 	// Log the number of pairs on the move
-	log.Debug().Int("pairs_count", allPairs).Msg("the market activity report")
+	log.Debug().Int("pairs_count", allPairs).Float64("Median", figures.Median).Float64("StdDev", figures.StdDev).Msg("the market activity report")
 	ctx := context.Background()
 
-	const fearGreedIndex = 80 // Set a constant value for fearGreedIndex
-
 	if allPairs != 0 {
-		// Calculate topN averages
 		var topA, topB, topC float64
-		if len(pairsOnTheMove) >= TopAverages[0] {
-			topA = AverageGain(&pairsOnTheMove, TopAverages[0])
-		}
-		if len(pairsOnTheMove) >= TopAverages[1] {
-			topB = AverageGain(&pairsOnTheMove, TopAverages[1])
+		if len(figures.Tops) >= 3 {
+			topA, topB, topC = figures.Tops[0], figures.Tops[1], figures.Tops[2]
 		}
-		if len(pairsOnTheMove) >= TopAverages[2] {
-			topC = AverageGain(&pairsOnTheMove, TopAverages[2])
-		}
-
-		// Make the GraphQL mutation request for each numberOfCoins
-		for _, numberOfCoins := range TopAverages {
-			if numberOfCoins+1 > allPairs {
-				break
-			}
-			avgGain := AverageGain(&pairsOnTheMove, numberOfCoins)
 
-			log.Debug().Int("time", now).Int("Qty", numberOfCoins).Float64("avgGain", avgGain).Msg("Check")
-		}
+		regime := ResolveMarketRegime(ctx, client, now, Breadth(allPairs, marketSize))
+		log.Debug().Int("FearGreedIndex", regime.FearGreedIndex).Str("MarketStatus", regime.MarketStatus).Float64("Breadth", regime.Breadth).Msg("Market regime")
 
 		// Move the GraphQL mutation request here with the correct values for topA, topB, topC
 		_, err := graph.CreateActivityReport(ctx, client,
 			now,
 			allPairs,
-			allMovers,
+			figures.AllMovers,
 			topA,
 			topB,
 			topC,
-			fearGreedIndex)
+			regime.FearGreedIndex,
+			regime.Breadth,
+			regime.MarketStatus)
 
 		if err != nil {
 			log.Error().Err(err).Msg("failed to add activity report")
@@ -84,52 +72,38 @@ func ActivityReport(client graphql.Client, TopAverages []int, pairsOnTheMove []s
 	}
 }
 
-func ManageSymbolStats(client graphql.Client, top10 []shared.Gainers) {
+// ManageSymbolStats records the top movers of this tick against their symbol
+// stats in a single bulk write.
+func ManageSymbolStats(client graphql.Client, top10 []shared.Gainers, now int) {
 	ctx := context.Background()
 
+	movers := make([]graph.TopMoverInput, 0, len(top10))
 	for i, pair := range top10 {
-		res, err := graph.ReadSingleSymbolStatsBySymbol(ctx, client, pair.Symbol)
-		// Always create a fresh slice for positionCounts
-		positionCounts := make([]*model.MeanInput, 10)
-		for j := 0; j < 10; j++ {
-			positionCounts[j] = &model.MeanInput{Avg: 0, Count: 0}
-		}
+		movers = append(movers, graph.TopMoverInput{
+			Symbol:   pair.Symbol,
+			Position: i + 1,
+			Gain:     pair.IncrementPriceGain,
+		})
+	}
 
-		if err != nil || res == nil || res.ReadSingleSymbolStatsBySymbol.Symbol == "" {
-			log.Warn().Str("symbol", pair.Symbol).Msg("No existing stats found, creating new entry")
-			// New entry: set this position
-			if i < 10 {
-				positionCounts[i] = &model.MeanInput{Avg: pair.IncrementPriceGain, Count: 1}
-			}
-		} else {
-			// Existing stats: copy over old values and update this position
-			for j := 0; j < len(res.ReadSingleSymbolStatsBySymbol.PositionCounts) && j < 10; j++ {
-				old := res.ReadSingleSymbolStatsBySymbol.PositionCounts[j]
-				positionCounts[j] = &model.MeanInput{Avg: old.Avg, Count: old.Count}
-			}
-			if i < 10 {
-				oldStat := res.ReadSingleSymbolStatsBySymbol.PositionCounts[i]
-				newCount := oldStat.Count + 1
-				newAvg := ((oldStat.Avg * float64(oldStat.Count)) + pair.IncrementPriceGain) / float64(newCount)
-				positionCounts[i] = &model.MeanInput{
-					Avg:   newAvg,
-					Count: newCount,
-				}
-			}
-		}
+	_, err := graph.RecordTopMovers(ctx, client, now, movers)
+	if err != nil {
+		log.Error().Err(err).Int("movers", len(movers)).Msg("Failed to record top movers in SymbolStats")
+	}
+}
 
-		// Convert to []model.MeanInput just before passing to UpsertPositionCounts
-		sharedModelPositionCounts := make([]sharedmodel.MeanInput, 0, len(positionCounts))
-		for _, v := range positionCounts {
-			sharedModelPositionCounts = append(sharedModelPositionCounts, sharedmodel.MeanInput{
-				Avg:   v.Avg,
-				Count: v.Count,
-			})
-		}
+// ReportOnMarket records the tick's market breadth and, when any pairs are on
+// the move, the market activity report and top movers.
+func ReportOnMarket(client graphql.Client, market []model.Pair, now int) {
+	cfg := shared.GetDefaultCfg()
 
-		_, err = graph.UpsertPositionCounts(ctx, client, pair.Symbol, sharedModelPositionCounts)
-		if err != nil {
-			log.Error().Err(err).Str("symbol", pair.Symbol).Msg("Failed to create or update SymbolStats")
-		}
+	// Record the breadth of the whole market, whether or not anything is on the move
+	MarketBreadthReport(client, market, cfg.ActiveMarketThreshold, now)
+
+	pairsOnTheMove, err := filter.FirstFilter(market, cfg.ActiveMarketThreshold)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find pairs on the move")
+		return
 	}
+	MarketActivityReport(client, cfg.TopAverages, pairsOnTheMove, len(market), now)
 }
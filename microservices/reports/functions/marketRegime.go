@@ -0,0 +1,182 @@
+package functions
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/rs/zerolog/log"
+)
+
+// Market statuses stamped on activity and trade outcome reports.
+const (
+	MarketBull     = "BULL"
+	MarketBear     = "BEAR"
+	MarketSideways = "SIDEWAYS"
+	MarketVolatile = "VOLATILE"
+	MarketUnknown  = "UNKNOWN"
+)
+
+// UnknownFearGreedIndex is stamped on reports when no index has been recorded
+// at or before their time.
+const UnknownFearGreedIndex = -1
+
+// MarketRegime is the state of the market at a moment in time.
+type MarketRegime struct {
+	FearGreedIndex int
+	MarketStatus   string
+	Breadth        float64 // % of all pairs on the move
+	TrendChange    float64 // % change of the reference symbol over the lookback
+	Volatility     float64 // % standard deviation of the reference symbol between snapshots
+}
+
+// Breadth returns the percentage of the market that is on the move.
+func Breadth(pairsOnTheMove, marketSize int) float64 {
+	if marketSize <= 0 {
+		return 0
+	}
+	return float64(pairsOnTheMove) / float64(marketSize) * 100
+}
+
+// Trend returns the percentage change from the first to the last price and the
+// standard deviation of the percentage changes between consecutive prices.
+// Prices are oldest first; fewer than two give no trend.
+func Trend(prices []float64) (change, volatility float64) {
+	if len(prices) < 2 || prices[0] == 0 {
+		return 0, 0
+	}
+
+	steps := make([]float64, 0, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] != 0 {
+			steps = append(steps, (prices[i]-prices[i-1])/prices[i-1]*100)
+		}
+	}
+
+	var mean float64
+	for _, s := range steps {
+		mean += s
+	}
+	mean /= float64(len(steps))
+
+	var squares float64
+	for _, s := range steps {
+		squares += (s - mean) * (s - mean)
+	}
+
+	return (prices[len(prices)-1] - prices[0]) / prices[0] * 100, math.Sqrt(squares / float64(len(steps)))
+}
+
+// ClassifyMarket names the market status. Volatility above the threshold wins
+// outright; otherwise a trend in the reference symbol is only a bull or bear
+// market when the breadth of the market agrees with it, and anything else is
+// sideways.
+func ClassifyMarket(breadth, trendChange, volatility float64, cfg shared.RegimeConfig) string {
+	switch {
+	case volatility >= cfg.Volatility:
+		return MarketVolatile
+	case trendChange >= cfg.TrendChange && breadth >= cfg.BullBreadth:
+		return MarketBull
+	case trendChange <= -cfg.TrendChange && breadth <= cfg.BearBreadth:
+		return MarketBear
+	default:
+		return MarketSideways
+	}
+}
+
+// ResolveFearGreedIndex returns the fear and greed index in force at the
+// timestamp and its classification, or UnknownFearGreedIndex and an empty
+// classification if none has been recorded by then.
+func ResolveFearGreedIndex(ctx context.Context, client graphql.Client, timestamp int) (int, string, error) {
+	resp, err := graph.ReadFearAndGreedIndexForTime(ctx, client, timestamp)
+	if err != nil {
+		return UnknownFearGreedIndex, "", err
+	}
+	if resp.ReadFearAndGreedIndexForTime.Value == "" {
+		return UnknownFearGreedIndex, "", nil
+	}
+
+	index, err := strconv.Atoi(resp.ReadFearAndGreedIndexForTime.Value)
+	if err != nil {
+		return UnknownFearGreedIndex, "", err
+	}
+	return index, resp.ReadFearAndGreedIndexForTime.ValueClassification, nil
+}
+
+// referencePrices returns the reference symbol's prices over the lookback
+// ending at the timestamp, oldest first.
+func referencePrices(ctx context.Context, client graphql.Client, timestamp int, cfg shared.RegimeConfig) ([]float64, error) {
+	resp, err := graph.ReadHistoricPriceBefore(ctx, client, cfg.Symbol, timestamp, cfg.Lookback)
+	if err != nil {
+		return nil, err
+	}
+
+	history := resp.ReadHistoricPriceBefore
+	prices := make([]float64, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		for _, pair := range history[i].Pair {
+			price, err := strconv.ParseFloat(pair.Price, 64)
+			if err != nil {
+				continue
+			}
+			prices = append(prices, price)
+		}
+	}
+	return prices, nil
+}
+
+// ResolveMarketRegime works out the market regime at the timestamp from the
+// fear and greed index, the breadth of the market and the reference symbol's
+// trend. Lookups that fail are logged and leave their part of the regime unknown.
+func ResolveMarketRegime(ctx context.Context, client graphql.Client, timestamp int, breadth float64) MarketRegime {
+	cfg := shared.GetDefaultCfg().Regime
+	regime := MarketRegime{Breadth: breadth, MarketStatus: MarketUnknown}
+
+	index, _, err := ResolveFearGreedIndex(ctx, client, timestamp)
+	if err != nil {
+		log.Error().Err(err).Int("Timestamp", timestamp).Msg("Failed to resolve fear and greed index")
+	}
+	regime.FearGreedIndex = index
+
+	prices, err := referencePrices(ctx, client, timestamp, cfg)
+	if err != nil {
+		log.Error().Err(err).Str("Symbol", cfg.Symbol).Msg("Failed to read reference prices")
+		return regime
+	}
+	if len(prices) < 2 {
+		log.Warn().Str("Symbol", cfg.Symbol).Int("Timestamp", timestamp).Msg("Not enough reference prices to classify the market")
+		return regime
+	}
+
+	regime.TrendChange, regime.Volatility = Trend(prices)
+	regime.MarketStatus = ClassifyMarket(breadth, regime.TrendChange, regime.Volatility, cfg)
+	return regime
+}
+
+// RegimeAt returns the regime recorded by the latest activity report at or
+// before the timestamp, with the fear and greed index resolved for the
+// timestamp itself. Trade outcomes use it so they share the activity report's
+// classification rather than re-classifying from partial data.
+func RegimeAt(ctx context.Context, client graphql.Client, timestamp int) MarketRegime {
+	regime := MarketRegime{MarketStatus: MarketUnknown}
+
+	index, _, err := ResolveFearGreedIndex(ctx, client, timestamp)
+	if err != nil {
+		log.Error().Err(err).Int("Timestamp", timestamp).Msg("Failed to resolve fear and greed index")
+	}
+	regime.FearGreedIndex = index
+
+	resp, err := graph.ReadActivityReportAt(ctx, client, timestamp)
+	if err != nil {
+		log.Error().Err(err).Int("Timestamp", timestamp).Msg("Failed to read activity report")
+		return regime
+	}
+	if resp.ReadActivityReportAt.MarketStatus != "" {
+		regime.MarketStatus = resp.ReadActivityReportAt.MarketStatus
+		regime.Breadth = resp.ReadActivityReportAt.Breadth
+	}
+	return regime
+}
@@ -37,9 +37,13 @@ type NewTradeOutcomeReport struct {
 	MarketStatus     string  `json:"MarketStatus"`
 }
 
-func TradeOutcomeReport(client graphql.Client, timeStamp, elapsedTime int, botName string, PercentageChange, updatedBalance, volume, Fee float64, symbol, outcome string) {
+// TradeOutcomeReport records a closed trade against the strategy version that
+// placed it, stamped with the market regime when it closed. versionID may be
+// empty for strategies saved before versioning. timeStamp is in milliseconds.
+func TradeOutcomeReport(client graphql.Client, timeStamp, elapsedTime int, botName, versionID string, PercentageChange, updatedBalance, volume, Fee float64, symbol, outcome string) {
 
 	ctx := context.Background()
+	regime := RegimeAt(ctx, client, timeStamp/1000)
 
 	// Make the GraphQL mutation request
 	_, err := graph.CreateTradeOutcomeReport(ctx, client,
@@ -52,8 +56,9 @@ func TradeOutcomeReport(client graphql.Client, timeStamp, elapsedTime int, botNa
 		Fee,
 		int(elapsedTime),
 		volume,
-		70,
-		"TBC",
+		regime.FearGreedIndex,
+		regime.MarketStatus,
+		versionID,
 	)
 
 	if err != nil {
@@ -61,3 +66,21 @@ func TradeOutcomeReport(client graphql.Client, timeStamp, elapsedTime int, botNa
 	}
 
 }
+
+// TradeOpenedReport records that the bot has opened a trade, so subscribers
+// can follow it live. Times are in milliseconds.
+func TradeOpenedReport(client graphql.Client, timeStamp, timesOutAt int, botName, versionID, symbol string, entryPrice, takeProfit, stopLoss float64) {
+	_, err := graph.CreateTradeOpened(context.Background(), client, graph.NewTradeOpened{
+		Timestamp:  timeStamp,
+		BotName:    botName,
+		Symbol:     symbol,
+		EntryPrice: entryPrice,
+		TakeProfit: takeProfit,
+		StopLoss:   stopLoss,
+		TimesOutAt: timesOutAt,
+		VersionID:  versionID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("BotName", botName).Str("Symbol", symbol).Msg("failed to record opened trade")
+	}
+}
@@ -6,10 +6,13 @@ replace cryptobotmanager.com/cbm-backend/shared => ../../shared
 
 replace cryptobotmanager.com/cbm-backend/shared/graph => ../graph
 
+replace cryptobotmanager.com/cbm-backend/microservices/filters => ../filters
+
 require (
+	cryptobotmanager.com/cbm-backend/microservices/filters v0.0.0-00010101000000-000000000000
 	cryptobotmanager.com/cbm-backend/shared v0.0.0-00010101000000-000000000000
 	github.com/Khan/genqlient v0.8.0
-	github.com/go-gota/gota v0.12.0
+	github.com/joho/godotenv v1.5.1
 	github.com/rs/zerolog v1.34.0
 )
 
@@ -1 +1,51 @@
 package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"cryptobotmanager.com/cbm-backend/microservices/reports/functions"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/messaging"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog/log"
+)
+
+// The reports service records the market breadth, activity and top movers for
+// each price snapshot.
+func main() {
+	err := godotenv.Load(".env")
+	if err != nil {
+		fmt.Println("Warning: No .env file found or failed to load")
+	}
+
+	// Setup GraphQL backend client
+	backend := os.Getenv("TRADING_BOT_URL")
+	if backend == "" {
+		backend = "http://cbm-api:8080/query"
+	}
+	// Initialize logger
+	shared.SetupLogger()
+
+	ctx := context.Background()
+	client := graphql.NewClient(backend, &http.Client{})
+
+	bus, err := messaging.ConnectFromEnv(ctx, "reports")
+	if err != nil || bus == nil {
+		log.Fatal().Err(err).Msg("The reports service needs NATS_URL to be set")
+	}
+	messaging.SetupSignalHandlers(bus.Conn())
+
+	_, err = messaging.Subscribe(ctx, bus, "reports", messaging.SubjectPricesSnapshot, 0, func(ctx context.Context, snapshot messaging.PricesSnapshot) error {
+		functions.ReportOnMarket(client, snapshot.Pairs, snapshot.Timestamp)
+		return nil
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to subscribe to price snapshots")
+	}
+
+	select {}
+}
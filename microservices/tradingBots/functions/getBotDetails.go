@@ -3,24 +3,22 @@ package functions
 import (
 	"context"
 	"encoding/json"
+	"slices"
 
 	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
 	"cryptobotmanager.com/cbm-backend/shared/graph"
 	"github.com/Khan/genqlient/graphql"
 )
 
-// This function takes in two file paths for strategy and exit parameters, loads the
-// parameters from the respective files, and returns the loaded strategy and exit parameters.
-//
-// The loadStrategies and loadExits functions are called to load the parameters from
-// the files specified in the strategyParams and exitParams arguments, respectively. If
-// an error occurs during the loading of the strategy or exit parameters, the function logs an
-// error message, exits the program with status code 1, and returns nil for both
-// strategyDetails and exitDetails.
-//
-// If both sets of parameters are loaded successfully, strategyDetails and
-// exitDetails are returned along with nil for the err return value.
+// GetParameters returns the strategies that are paper or live trading, converted
+// to StrategyInput so they can be handed straight to the trading functions.
 func GetParameters(ctx context.Context, client graphql.Client) ([]model.StrategyInput, error) {
+	return GetStrategiesByLifecycle(ctx, client, graph.StrategyLifecyclePaper, graph.StrategyLifecycleLive)
+}
+
+// GetStrategiesByLifecycle returns the strategies currently in any of the given
+// lifecycle states, converted to StrategyInput.
+func GetStrategiesByLifecycle(ctx context.Context, client graphql.Client, lifecycles ...graph.StrategyLifecycle) ([]model.StrategyInput, error) {
 	response, err := graph.ReadAllStrategies(ctx, client)
 	if err != nil {
 		return nil, err
@@ -30,22 +28,23 @@ func GetParameters(ctx context.Context, client graphql.Client) ([]model.Strategy
 
 	// Access the "data" key and then the "getAllStrategies" key
 	for _, obj := range response.ReadAllStrategies {
-		// Check if the strategy is not tested
-		if !obj.Tested {
-			objJSON, err := json.Marshal(obj)
-			if err != nil {
-				return nil, err
-			}
-
-			var details model.StrategyInput
-			err = json.Unmarshal(objJSON, &details)
-			if err != nil {
-				return nil, err
-			}
-
-			// Append the converted object to the slice
-			strategyDetails = append(strategyDetails, details)
+		if !slices.Contains(lifecycles, obj.Lifecycle) {
+			continue
+		}
+
+		objJSON, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
 		}
+
+		var details model.StrategyInput
+		err = json.Unmarshal(objJSON, &details)
+		if err != nil {
+			return nil, err
+		}
+
+		// Append the converted object to the slice
+		strategyDetails = append(strategyDetails, details)
 	}
 
 	return strategyDetails, nil
@@ -0,0 +1,39 @@
+package functions
+
+import (
+	"strings"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+// HasSentimentGate reports whether the strategy restricts trading by the Fear & Greed index.
+func HasSentimentGate(details model.StrategyInput) bool {
+	return details.MinFearGreed != nil || details.MaxFearGreed != nil || len(details.AllowedSentiments) > 0
+}
+
+// SentimentAllows reports whether the strategy may enter a trade at the given
+// Fear & Greed index and classification. A strategy without bounds always
+// may; one with bounds may not when the index is unknown (negative).
+func SentimentAllows(details model.StrategyInput, index int, classification string) bool {
+	if !HasSentimentGate(details) {
+		return true
+	}
+	if index < 0 {
+		return false
+	}
+	if details.MinFearGreed != nil && index < *details.MinFearGreed {
+		return false
+	}
+	if details.MaxFearGreed != nil && index > *details.MaxFearGreed {
+		return false
+	}
+	if len(details.AllowedSentiments) == 0 {
+		return true
+	}
+	for _, allowed := range details.AllowedSentiments {
+		if strings.EqualFold(allowed, classification) {
+			return true
+		}
+	}
+	return false
+}
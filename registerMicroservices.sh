@@ -6,10 +6,17 @@ export PATH=$PATH:/usr/local/bin
 
 echo "Starting Nex and registering binaries with NEX..."
 
+# Long-running services run in their own containers, see docker-compose.yml
+services="filters reports paperTrade scheduler"
+
 # Loop through all binaries in the microservice-binaries directory
 for binary in /usr/local/bin/microservice-binaries/*; do
    if [[ -x "$binary" && -f "$binary" ]]; then
        binary_name=$(basename "$binary")
+       if [[ " $services " == *" $binary_name "* ]]; then
+           echo "Skipping $binary_name - runs as its own service."
+           continue
+       fi
        echo "Attempting to register $binary_name binary with Nex..."
        "$binary" --server=nats://nats:4222 --loglevel=debug --logcolor || echo "Failed to register $binary_name"
    else
@@ -1,10 +1,97 @@
 package shared
 
+import "math"
+
 type AppConfig struct {
 	TopAverages                   []int
 	TradeDuration                 int
 	PackageNames, TestExemptFuncs []string
 	ActiveMarketThreshold         float64
+	FeePercentage                 float64
+	StartingBalance               float64
+	ParameterRanges               ParameterRanges
+	WalkForward                   WalkForwardConfig
+	Regime                        RegimeConfig
+}
+
+// PromotionThresholds are the out-of-sample results a strategy must reach to
+// move up to the next lifecycle state.
+type PromotionThresholds struct {
+	MinTrades            int
+	MinWinRate           float64 // %
+	MinSharpe            float64
+	MaxDrawdown          float64 // %
+	MinProfitableWindows float64 // % of out-of-sample windows ending in profit
+}
+
+// WalkForwardConfig sets the rolling window sizes used for walk-forward
+// validation and the thresholds for promoting strategies.
+type WalkForwardConfig struct {
+	InSampleDays    int
+	OutOfSampleDays int
+	ToValidated     PromotionThresholds // backtesting -> validated
+	ToPaper         PromotionThresholds // validated -> paper
+}
+
+// RegimeConfig sets how the market status is classified from the breadth of
+// the market and the trend of a reference symbol.
+type RegimeConfig struct {
+	Symbol      string  // reference symbol whose trend leads the market
+	Lookback    int     // price snapshots the trend is measured over
+	TrendChange float64 // % move over the lookback that counts as a trend
+	Volatility  float64 // % standard deviation between snapshots that counts as volatile
+	BullBreadth float64 // % of pairs on the move needed to confirm an uptrend
+	BearBreadth float64 // % of pairs on the move at or below which a downtrend is confirmed
+}
+
+// IntRange is an inclusive range of whole values walked in Step increments.
+type IntRange struct {
+	Min, Max, Step int
+}
+
+// FloatRange is an inclusive range of decimal values walked in Step increments.
+type FloatRange struct {
+	Min, Max, Step float64
+}
+
+// ParameterRanges holds the strategy settings that are permuted into
+// candidate strategies by the backtesting parameter sweep.
+type ParameterRanges struct {
+	TradeDuration        IntRange
+	ShortSMADuration     IntRange
+	LongSMADuration      IntRange
+	MovingAveMomentum    FloatRange
+	TakeProfitPercentage FloatRange
+	StopLossPercentage   FloatRange
+	IncrementsATR        IntRange
+	ATRtollerance        FloatRange
+}
+
+// Values expands the range into each value from Min to Max. A zero or
+// negative Step, or Max below Min, yields just Min.
+func (r IntRange) Values() []int {
+	if r.Step <= 0 || r.Max < r.Min {
+		return []int{r.Min}
+	}
+	var values []int
+	for v := r.Min; v <= r.Max; v += r.Step {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Values expands the range into each value from Min to Max, rounded to
+// avoid float drift. A zero or negative Step, or Max below Min, yields just Min.
+func (r FloatRange) Values() []float64 {
+	if r.Step <= 0 || r.Max < r.Min {
+		return []float64{r.Min}
+	}
+	var values []float64
+	steps := int(math.Floor((r.Max-r.Min)/r.Step + 1e-9))
+	for i := 0; i <= steps; i++ {
+		values = append(values, Round(r.Min+float64(i)*r.Step, 3))
+	}
+	return values
 }
 
 func GetDefaultCfg() AppConfig {
@@ -27,7 +114,53 @@ func GetDefaultCfg() AppConfig {
 		"PrintFunctionsWithoutTestCoverage",
 		"SavePricesToDB"}
 
-	//REMEMBER TO BRING PERMUTATION INTO HERE
+	// Fee charged by the exchange on each side of a trade (%)
+	feePercentage := 0.06
+
+	// Balance each simulated strategy starts a backtest with
+	startingBalance := 1000.0
+
+	// Ranges permuted into candidate strategies by the parameter sweep
+	parameterRanges := ParameterRanges{
+		TradeDuration:        IntRange{Min: 5, Max: 30, Step: 5},
+		ShortSMADuration:     IntRange{Min: 2, Max: 6, Step: 2},
+		LongSMADuration:      IntRange{Min: 10, Max: 30, Step: 10},
+		MovingAveMomentum:    FloatRange{Min: 0.1, Max: 0.5, Step: 0.2},
+		TakeProfitPercentage: FloatRange{Min: 0.5, Max: 2, Step: 0.5},
+		StopLossPercentage:   FloatRange{Min: 0.5, Max: 2, Step: 0.5},
+		IncrementsATR:        IntRange{Min: 10, Max: 10, Step: 0},
+		ATRtollerance:        FloatRange{Min: 0, Max: 0, Step: 0},
+	}
+
+	// Rolling windows and promotion thresholds for walk-forward validation
+	walkForward := WalkForwardConfig{
+		InSampleDays:    14,
+		OutOfSampleDays: 7,
+		ToValidated: PromotionThresholds{
+			MinTrades:            20,
+			MinWinRate:           50,
+			MinSharpe:            0.1,
+			MaxDrawdown:          15,
+			MinProfitableWindows: 50,
+		},
+		ToPaper: PromotionThresholds{
+			MinTrades:            50,
+			MinWinRate:           55,
+			MinSharpe:            0.2,
+			MaxDrawdown:          10,
+			MinProfitableWindows: 66,
+		},
+	}
+
+	// Market status classification for activity and trade outcome reports
+	regime := RegimeConfig{
+		Symbol:      "BTCUSDT",
+		Lookback:    12,
+		TrendChange: 1,
+		Volatility:  0.5,
+		BullBreadth: 20,
+		BearBreadth: 5,
+	}
 
 	cfg := &AppConfig{
 		ActiveMarketThreshold: activeMarketThreshold,
@@ -35,6 +168,11 @@ func GetDefaultCfg() AppConfig {
 		PackageNames:          packageNames,
 		TestExemptFuncs:       testExemptFuncs,
 		TopAverages:           topAverages,
+		FeePercentage:         feePercentage,
+		StartingBalance:       startingBalance,
+		ParameterRanges:       parameterRanges,
+		WalkForward:           walkForward,
+		Regime:                regime,
 	}
 
 	return *cfg
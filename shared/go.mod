@@ -5,13 +5,20 @@ go 1.24.2
 require (
 	cryptobotmanager.com/cbm-backend/cbm-api v0.0.0-00010101000000-000000000000
 	cryptobotmanager.com/cbm-backend/microservices/backTesting v0.0.0-00010101000000-000000000000
+	cryptobotmanager.com/cbm-backend/microservices/dataManager v0.0.0-00010101000000-000000000000
+	cryptobotmanager.com/cbm-backend/microservices/reports v0.0.0-00010101000000-000000000000
+	cryptobotmanager.com/cbm-backend/microservices/tradingBots v0.0.0-00010101000000-000000000000
 	github.com/Khan/genqlient v0.8.0
+	github.com/nats-io/nats-server/v2 v2.10.26
 	github.com/nats-io/nats.go v1.39.1
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/rs/zerolog v1.34.0
 )
 
 replace cryptobotmanager.com/cbm-backend/microservices/backTesting => ../microservices/backTesting
 
+replace cryptobotmanager.com/cbm-backend/microservices/dataManager => ../microservices/dataManager
+
 replace cryptobotmanager.com/cbm-backend/microservices/filters => ../microservices/filters
 
 replace cryptobotmanager.com/cbm-backend/cbm-api => ../cbm-api
@@ -25,19 +32,21 @@ replace cryptobotmanager.com/cbm-backend/microservices/tradingBots => ../microse
 require (
 	cryptobotmanager.com/cbm-backend/microservices/externalDataAPIs v0.0.0-00010101000000-000000000000 // indirect
 	cryptobotmanager.com/cbm-backend/microservices/filters v0.0.0-00010101000000-000000000000 // indirect
-	cryptobotmanager.com/cbm-backend/microservices/reports v0.0.0-00010101000000-000000000000 // indirect
-	cryptobotmanager.com/cbm-backend/microservices/tradingBots v0.0.0-00010101000000-000000000000 // indirect
 	github.com/go-gota/gota v0.12.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/gorilla/websocket v1.5.3 // indirect
-	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	github.com/nats-io/nkeys v0.4.9 // indirect
+	github.com/minio/highwayhash v1.0.3 // indirect
+	github.com/nats-io/jwt/v2 v2.7.3 // indirect
+	github.com/nats-io/nkeys v0.4.10 // indirect
 	github.com/nats-io/nuid v1.0.1 // indirect
 	github.com/vektah/gqlparser/v2 v2.5.25 // indirect
-	golang.org/x/crypto v0.31.0 // indirect
+	go.uber.org/automaxprocs v1.6.0 // indirect
+	golang.org/x/crypto v0.34.0 // indirect
 	golang.org/x/net v0.29.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
+	golang.org/x/time v0.10.0 // indirect
 	gonum.org/v1/gonum v0.9.1 // indirect
 )
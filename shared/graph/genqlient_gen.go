@@ -9,6 +9,259 @@ import (
 	"github.com/Khan/genqlient/graphql"
 )
 
+type BacktestParametersInput struct {
+	TradeDuration        int     `json:"TradeDuration"`
+	IncrementsATR        int     `json:"IncrementsATR"`
+	LongSMADuration      int     `json:"LongSMADuration"`
+	ShortSMADuration     int     `json:"ShortSMADuration"`
+	MovingAveMomentum    float64 `json:"MovingAveMomentum"`
+	TakeProfitPercentage float64 `json:"TakeProfitPercentage"`
+	StopLossPercentage   float64 `json:"StopLossPercentage"`
+	ATRtollerance        float64 `json:"ATRtollerance"`
+	FeePercentage        float64 `json:"FeePercentage"`
+}
+
+// GetTradeDuration returns BacktestParametersInput.TradeDuration, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetTradeDuration() int { return v.TradeDuration }
+
+// GetIncrementsATR returns BacktestParametersInput.IncrementsATR, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetIncrementsATR() int { return v.IncrementsATR }
+
+// GetLongSMADuration returns BacktestParametersInput.LongSMADuration, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetLongSMADuration() int { return v.LongSMADuration }
+
+// GetShortSMADuration returns BacktestParametersInput.ShortSMADuration, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetShortSMADuration() int { return v.ShortSMADuration }
+
+// GetMovingAveMomentum returns BacktestParametersInput.MovingAveMomentum, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetMovingAveMomentum() float64 { return v.MovingAveMomentum }
+
+// GetTakeProfitPercentage returns BacktestParametersInput.TakeProfitPercentage, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetTakeProfitPercentage() float64 { return v.TakeProfitPercentage }
+
+// GetStopLossPercentage returns BacktestParametersInput.StopLossPercentage, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetStopLossPercentage() float64 { return v.StopLossPercentage }
+
+// GetATRtollerance returns BacktestParametersInput.ATRtollerance, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetATRtollerance() float64 { return v.ATRtollerance }
+
+// GetFeePercentage returns BacktestParametersInput.FeePercentage, and is useful for accessing the field via an interface.
+func (v *BacktestParametersInput) GetFeePercentage() float64 { return v.FeePercentage }
+
+type BacktestRunInput struct {
+	BotInstanceName string                  `json:"BotInstanceName"`
+	Parameters      BacktestParametersInput `json:"Parameters"`
+	DatasetID       string                  `json:"DatasetID"`
+	From            int                     `json:"From"`
+	To              int                     `json:"To"`
+	StartingBalance float64                 `json:"StartingBalance"`
+	EndingBalance   float64                 `json:"EndingBalance"`
+	FeesTotal       float64                 `json:"FeesTotal"`
+	WINCounter      int                     `json:"WINCounter"`
+	LOSSCounter     int                     `json:"LOSSCounter"`
+	TIMEOUTCounter  int                     `json:"TIMEOUTCounter"`
+	CreatedOn       int                     `json:"CreatedOn"`
+	Stats           BacktestStatsInput      `json:"Stats"`
+	EquityCurve     []EquityPointInput      `json:"EquityCurve"`
+	Trades          []BacktestTradeInput    `json:"Trades"`
+}
+
+// GetBotInstanceName returns BacktestRunInput.BotInstanceName, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetBotInstanceName() string { return v.BotInstanceName }
+
+// GetParameters returns BacktestRunInput.Parameters, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetParameters() BacktestParametersInput { return v.Parameters }
+
+// GetDatasetID returns BacktestRunInput.DatasetID, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetDatasetID() string { return v.DatasetID }
+
+// GetFrom returns BacktestRunInput.From, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetFrom() int { return v.From }
+
+// GetTo returns BacktestRunInput.To, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetTo() int { return v.To }
+
+// GetStartingBalance returns BacktestRunInput.StartingBalance, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetStartingBalance() float64 { return v.StartingBalance }
+
+// GetEndingBalance returns BacktestRunInput.EndingBalance, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetEndingBalance() float64 { return v.EndingBalance }
+
+// GetFeesTotal returns BacktestRunInput.FeesTotal, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetFeesTotal() float64 { return v.FeesTotal }
+
+// GetWINCounter returns BacktestRunInput.WINCounter, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetWINCounter() int { return v.WINCounter }
+
+// GetLOSSCounter returns BacktestRunInput.LOSSCounter, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetLOSSCounter() int { return v.LOSSCounter }
+
+// GetTIMEOUTCounter returns BacktestRunInput.TIMEOUTCounter, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetTIMEOUTCounter() int { return v.TIMEOUTCounter }
+
+// GetCreatedOn returns BacktestRunInput.CreatedOn, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetCreatedOn() int { return v.CreatedOn }
+
+// GetStats returns BacktestRunInput.Stats, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetStats() BacktestStatsInput { return v.Stats }
+
+// GetEquityCurve returns BacktestRunInput.EquityCurve, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetEquityCurve() []EquityPointInput { return v.EquityCurve }
+
+// GetTrades returns BacktestRunInput.Trades, and is useful for accessing the field via an interface.
+func (v *BacktestRunInput) GetTrades() []BacktestTradeInput { return v.Trades }
+
+type BacktestStatsInput struct {
+	Trades       int     `json:"Trades"`
+	NetPnL       float64 `json:"NetPnL"`
+	WinRate      float64 `json:"WinRate"`
+	CAGR         float64 `json:"CAGR"`
+	MaxDrawdown  float64 `json:"MaxDrawdown"`
+	Sharpe       float64 `json:"Sharpe"`
+	Sortino      float64 `json:"Sortino"`
+	ProfitFactor float64 `json:"ProfitFactor"`
+	Expectancy   float64 `json:"Expectancy"`
+	AvgHoldTime  float64 `json:"AvgHoldTime"`
+	Exposure     float64 `json:"Exposure"`
+}
+
+// GetTrades returns BacktestStatsInput.Trades, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetTrades() int { return v.Trades }
+
+// GetNetPnL returns BacktestStatsInput.NetPnL, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetNetPnL() float64 { return v.NetPnL }
+
+// GetWinRate returns BacktestStatsInput.WinRate, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetWinRate() float64 { return v.WinRate }
+
+// GetCAGR returns BacktestStatsInput.CAGR, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetCAGR() float64 { return v.CAGR }
+
+// GetMaxDrawdown returns BacktestStatsInput.MaxDrawdown, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetMaxDrawdown() float64 { return v.MaxDrawdown }
+
+// GetSharpe returns BacktestStatsInput.Sharpe, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetSharpe() float64 { return v.Sharpe }
+
+// GetSortino returns BacktestStatsInput.Sortino, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetSortino() float64 { return v.Sortino }
+
+// GetProfitFactor returns BacktestStatsInput.ProfitFactor, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetProfitFactor() float64 { return v.ProfitFactor }
+
+// GetExpectancy returns BacktestStatsInput.Expectancy, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetExpectancy() float64 { return v.Expectancy }
+
+// GetAvgHoldTime returns BacktestStatsInput.AvgHoldTime, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetAvgHoldTime() float64 { return v.AvgHoldTime }
+
+// GetExposure returns BacktestStatsInput.Exposure, and is useful for accessing the field via an interface.
+func (v *BacktestStatsInput) GetExposure() float64 { return v.Exposure }
+
+type BacktestTradeInput struct {
+	Symbol           string  `json:"Symbol"`
+	Outcome          string  `json:"Outcome"`
+	OpenTime         int     `json:"OpenTime"`
+	CloseTime        int     `json:"CloseTime"`
+	OpenPrice        float64 `json:"OpenPrice"`
+	ClosePrice       float64 `json:"ClosePrice"`
+	PercentageChange float64 `json:"PercentageChange"`
+	Fee              float64 `json:"Fee"`
+	Balance          float64 `json:"Balance"`
+}
+
+// GetSymbol returns BacktestTradeInput.Symbol, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetSymbol() string { return v.Symbol }
+
+// GetOutcome returns BacktestTradeInput.Outcome, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetOutcome() string { return v.Outcome }
+
+// GetOpenTime returns BacktestTradeInput.OpenTime, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetOpenTime() int { return v.OpenTime }
+
+// GetCloseTime returns BacktestTradeInput.CloseTime, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetCloseTime() int { return v.CloseTime }
+
+// GetOpenPrice returns BacktestTradeInput.OpenPrice, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetOpenPrice() float64 { return v.OpenPrice }
+
+// GetClosePrice returns BacktestTradeInput.ClosePrice, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetClosePrice() float64 { return v.ClosePrice }
+
+// GetPercentageChange returns BacktestTradeInput.PercentageChange, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetPercentageChange() float64 { return v.PercentageChange }
+
+// GetFee returns BacktestTradeInput.Fee, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetFee() float64 { return v.Fee }
+
+// GetBalance returns BacktestTradeInput.Balance, and is useful for accessing the field via an interface.
+func (v *BacktestTradeInput) GetBalance() float64 { return v.Balance }
+
+type BreadthBucketInput struct {
+	Label string   `json:"Label"`
+	Min   *float64 `json:"Min"`
+	Max   *float64 `json:"Max"`
+	Count int      `json:"Count"`
+}
+
+// GetLabel returns BreadthBucketInput.Label, and is useful for accessing the field via an interface.
+func (v *BreadthBucketInput) GetLabel() string { return v.Label }
+
+// GetMin returns BreadthBucketInput.Min, and is useful for accessing the field via an interface.
+func (v *BreadthBucketInput) GetMin() *float64 { return v.Min }
+
+// GetMax returns BreadthBucketInput.Max, and is useful for accessing the field via an interface.
+func (v *BreadthBucketInput) GetMax() *float64 { return v.Max }
+
+// GetCount returns BreadthBucketInput.Count, and is useful for accessing the field via an interface.
+func (v *BreadthBucketInput) GetCount() int { return v.Count }
+
+// CalendarFeedCalendarFeed includes the requested fields of the GraphQL type CalendarFeed.
+type CalendarFeedCalendarFeed struct {
+	Url string `json:"url"`
+}
+
+// GetUrl returns CalendarFeedCalendarFeed.Url, and is useful for accessing the field via an interface.
+func (v *CalendarFeedCalendarFeed) GetUrl() string { return v.Url }
+
+// CalendarFeedResponse is returned by CalendarFeed on success.
+type CalendarFeedResponse struct {
+	// Get the logged-in user's iCalendar feed of their open tasks and project due dates, limited to tasks with any of the labels and in any of the departments
+	CalendarFeed CalendarFeedCalendarFeed `json:"calendarFeed"`
+}
+
+// GetCalendarFeed returns CalendarFeedResponse.CalendarFeed, and is useful for accessing the field via an interface.
+func (v *CalendarFeedResponse) GetCalendarFeed() CalendarFeedCalendarFeed { return v.CalendarFeed }
+
+// ClaimJobRunClaimJobRun includes the requested fields of the GraphQL type JobRun.
+type ClaimJobRunClaimJobRun struct {
+	RunID   string     `json:"RunID"`
+	Job     string     `json:"Job"`
+	Trigger JobTrigger `json:"Trigger"`
+	Status  JobStatus  `json:"Status"`
+}
+
+// GetRunID returns ClaimJobRunClaimJobRun.RunID, and is useful for accessing the field via an interface.
+func (v *ClaimJobRunClaimJobRun) GetRunID() string { return v.RunID }
+
+// GetJob returns ClaimJobRunClaimJobRun.Job, and is useful for accessing the field via an interface.
+func (v *ClaimJobRunClaimJobRun) GetJob() string { return v.Job }
+
+// GetTrigger returns ClaimJobRunClaimJobRun.Trigger, and is useful for accessing the field via an interface.
+func (v *ClaimJobRunClaimJobRun) GetTrigger() JobTrigger { return v.Trigger }
+
+// GetStatus returns ClaimJobRunClaimJobRun.Status, and is useful for accessing the field via an interface.
+func (v *ClaimJobRunClaimJobRun) GetStatus() JobStatus { return v.Status }
+
+// ClaimJobRunResponse is returned by ClaimJobRun on success.
+type ClaimJobRunResponse struct {
+	// Starts the oldest queued manual run of the job, if there is one
+	ClaimJobRun *ClaimJobRunClaimJobRun `json:"claimJobRun"`
+}
+
+// GetClaimJobRun returns ClaimJobRunResponse.ClaimJobRun, and is useful for accessing the field via an interface.
+func (v *ClaimJobRunResponse) GetClaimJobRun() *ClaimJobRunClaimJobRun { return v.ClaimJobRun }
+
 // CreateActivityReportCreateActivityReport includes the requested fields of the GraphQL type ActivityReport.
 type CreateActivityReportCreateActivityReport struct {
 	Id             string  `json:"_id"`
@@ -19,6 +272,8 @@ type CreateActivityReportCreateActivityReport struct {
 	TopBGain       float64 `json:"TopBGain"`
 	TopCGain       float64 `json:"TopCGain"`
 	FearGreedIndex int     `json:"FearGreedIndex"`
+	Breadth        float64 `json:"Breadth"`
+	MarketStatus   string  `json:"MarketStatus"`
 }
 
 // GetId returns CreateActivityReportCreateActivityReport.Id, and is useful for accessing the field via an interface.
@@ -45,6 +300,12 @@ func (v *CreateActivityReportCreateActivityReport) GetTopCGain() float64 { retur
 // GetFearGreedIndex returns CreateActivityReportCreateActivityReport.FearGreedIndex, and is useful for accessing the field via an interface.
 func (v *CreateActivityReportCreateActivityReport) GetFearGreedIndex() int { return v.FearGreedIndex }
 
+// GetBreadth returns CreateActivityReportCreateActivityReport.Breadth, and is useful for accessing the field via an interface.
+func (v *CreateActivityReportCreateActivityReport) GetBreadth() float64 { return v.Breadth }
+
+// GetMarketStatus returns CreateActivityReportCreateActivityReport.MarketStatus, and is useful for accessing the field via an interface.
+func (v *CreateActivityReportCreateActivityReport) GetMarketStatus() string { return v.MarketStatus }
+
 // CreateActivityReportResponse is returned by CreateActivityReport on success.
 type CreateActivityReportResponse struct {
 	// Creates a new market Activity Report
@@ -56,6 +317,25 @@ func (v *CreateActivityReportResponse) GetCreateActivityReport() CreateActivityR
 	return v.CreateActivityReport
 }
 
+// CreateBacktestRunCreateBacktestRun includes the requested fields of the GraphQL type BacktestRun.
+type CreateBacktestRunCreateBacktestRun struct {
+	RunID string `json:"RunID"`
+}
+
+// GetRunID returns CreateBacktestRunCreateBacktestRun.RunID, and is useful for accessing the field via an interface.
+func (v *CreateBacktestRunCreateBacktestRun) GetRunID() string { return v.RunID }
+
+// CreateBacktestRunResponse is returned by CreateBacktestRun on success.
+type CreateBacktestRunResponse struct {
+	// Stores the result of replaying a strategy in the backtest engine
+	CreateBacktestRun CreateBacktestRunCreateBacktestRun `json:"createBacktestRun"`
+}
+
+// GetCreateBacktestRun returns CreateBacktestRunResponse.CreateBacktestRun, and is useful for accessing the field via an interface.
+func (v *CreateBacktestRunResponse) GetCreateBacktestRun() CreateBacktestRunCreateBacktestRun {
+	return v.CreateBacktestRun
+}
+
 // CreateHistoricPricesCreateHistoricPrices includes the requested fields of the GraphQL type HistoricPrices.
 type CreateHistoricPricesCreateHistoricPrices struct {
 	Pair []CreateHistoricPricesCreateHistoricPricesPair `json:"Pair"`
@@ -184,6 +464,70 @@ func (v *CreateHistoricTickerStatsResponse) GetCreateHistoricTickerStats() []Cre
 	return v.CreateHistoricTickerStats
 }
 
+// CreateJobRunCreateJobRun includes the requested fields of the GraphQL type JobRun.
+type CreateJobRunCreateJobRun struct {
+	RunID   string     `json:"RunID"`
+	Job     string     `json:"Job"`
+	Slot    int        `json:"Slot"`
+	Trigger JobTrigger `json:"Trigger"`
+	Status  JobStatus  `json:"Status"`
+}
+
+// GetRunID returns CreateJobRunCreateJobRun.RunID, and is useful for accessing the field via an interface.
+func (v *CreateJobRunCreateJobRun) GetRunID() string { return v.RunID }
+
+// GetJob returns CreateJobRunCreateJobRun.Job, and is useful for accessing the field via an interface.
+func (v *CreateJobRunCreateJobRun) GetJob() string { return v.Job }
+
+// GetSlot returns CreateJobRunCreateJobRun.Slot, and is useful for accessing the field via an interface.
+func (v *CreateJobRunCreateJobRun) GetSlot() int { return v.Slot }
+
+// GetTrigger returns CreateJobRunCreateJobRun.Trigger, and is useful for accessing the field via an interface.
+func (v *CreateJobRunCreateJobRun) GetTrigger() JobTrigger { return v.Trigger }
+
+// GetStatus returns CreateJobRunCreateJobRun.Status, and is useful for accessing the field via an interface.
+func (v *CreateJobRunCreateJobRun) GetStatus() JobStatus { return v.Status }
+
+// CreateJobRunResponse is returned by CreateJobRun on success.
+type CreateJobRunResponse struct {
+	// Records a run the scheduler has started or skipped
+	CreateJobRun CreateJobRunCreateJobRun `json:"createJobRun"`
+}
+
+// GetCreateJobRun returns CreateJobRunResponse.CreateJobRun, and is useful for accessing the field via an interface.
+func (v *CreateJobRunResponse) GetCreateJobRun() CreateJobRunCreateJobRun { return v.CreateJobRun }
+
+// CreateMarketBreadthCreateMarketBreadth includes the requested fields of the GraphQL type MarketBreadth.
+type CreateMarketBreadthCreateMarketBreadth struct {
+	Timestamp      int     `json:"Timestamp"`
+	Pairs          int     `json:"Pairs"`
+	AdvancePercent float64 `json:"AdvancePercent"`
+	MedianChange   float64 `json:"MedianChange"`
+}
+
+// GetTimestamp returns CreateMarketBreadthCreateMarketBreadth.Timestamp, and is useful for accessing the field via an interface.
+func (v *CreateMarketBreadthCreateMarketBreadth) GetTimestamp() int { return v.Timestamp }
+
+// GetPairs returns CreateMarketBreadthCreateMarketBreadth.Pairs, and is useful for accessing the field via an interface.
+func (v *CreateMarketBreadthCreateMarketBreadth) GetPairs() int { return v.Pairs }
+
+// GetAdvancePercent returns CreateMarketBreadthCreateMarketBreadth.AdvancePercent, and is useful for accessing the field via an interface.
+func (v *CreateMarketBreadthCreateMarketBreadth) GetAdvancePercent() float64 { return v.AdvancePercent }
+
+// GetMedianChange returns CreateMarketBreadthCreateMarketBreadth.MedianChange, and is useful for accessing the field via an interface.
+func (v *CreateMarketBreadthCreateMarketBreadth) GetMedianChange() float64 { return v.MedianChange }
+
+// CreateMarketBreadthResponse is returned by CreateMarketBreadth on success.
+type CreateMarketBreadthResponse struct {
+	// Stores the breadth snapshot for a tick, replacing any already stored for its timestamp
+	CreateMarketBreadth CreateMarketBreadthCreateMarketBreadth `json:"createMarketBreadth"`
+}
+
+// GetCreateMarketBreadth returns CreateMarketBreadthResponse.CreateMarketBreadth, and is useful for accessing the field via an interface.
+func (v *CreateMarketBreadthResponse) GetCreateMarketBreadth() CreateMarketBreadthCreateMarketBreadth {
+	return v.CreateMarketBreadth
+}
+
 // CreateProjectCreateProject includes the requested fields of the GraphQL type Project.
 type CreateProjectCreateProject struct {
 	Id          string   `json:"id"`
@@ -229,15 +573,19 @@ func (v *CreateProjectCreateProject) GetCreatedAt() string { return v.CreatedAt
 func (v *CreateProjectCreateProject) GetUpdatedAt() string { return v.UpdatedAt }
 
 type CreateProjectInput struct {
+	Id          *string  `json:"id"`
 	Title       string   `json:"title"`
 	Sop         bool     `json:"sop"`
-	Description string   `json:"description"`
+	Description *string  `json:"description"`
 	Labels      []string `json:"labels"`
-	AssignedTo  string   `json:"assignedTo"`
-	DueDate     string   `json:"dueDate"`
+	AssignedTo  *string  `json:"assignedTo"`
+	DueDate     *string  `json:"dueDate"`
 	Status      string   `json:"status"`
 }
 
+// GetId returns CreateProjectInput.Id, and is useful for accessing the field via an interface.
+func (v *CreateProjectInput) GetId() *string { return v.Id }
+
 // GetTitle returns CreateProjectInput.Title, and is useful for accessing the field via an interface.
 func (v *CreateProjectInput) GetTitle() string { return v.Title }
 
@@ -245,16 +593,16 @@ func (v *CreateProjectInput) GetTitle() string { return v.Title }
 func (v *CreateProjectInput) GetSop() bool { return v.Sop }
 
 // GetDescription returns CreateProjectInput.Description, and is useful for accessing the field via an interface.
-func (v *CreateProjectInput) GetDescription() string { return v.Description }
+func (v *CreateProjectInput) GetDescription() *string { return v.Description }
 
 // GetLabels returns CreateProjectInput.Labels, and is useful for accessing the field via an interface.
 func (v *CreateProjectInput) GetLabels() []string { return v.Labels }
 
 // GetAssignedTo returns CreateProjectInput.AssignedTo, and is useful for accessing the field via an interface.
-func (v *CreateProjectInput) GetAssignedTo() string { return v.AssignedTo }
+func (v *CreateProjectInput) GetAssignedTo() *string { return v.AssignedTo }
 
 // GetDueDate returns CreateProjectInput.DueDate, and is useful for accessing the field via an interface.
-func (v *CreateProjectInput) GetDueDate() string { return v.DueDate }
+func (v *CreateProjectInput) GetDueDate() *string { return v.DueDate }
 
 // GetStatus returns CreateProjectInput.Status, and is useful for accessing the field via an interface.
 func (v *CreateProjectInput) GetStatus() string { return v.Status }
@@ -268,6 +616,48 @@ type CreateProjectResponse struct {
 // GetCreateProject returns CreateProjectResponse.CreateProject, and is useful for accessing the field via an interface.
 func (v *CreateProjectResponse) GetCreateProject() CreateProjectCreateProject { return v.CreateProject }
 
+// CreateStrategyCreateStrategy includes the requested fields of the GraphQL type Strategy.
+type CreateStrategyCreateStrategy struct {
+	BotInstanceName string `json:"BotInstanceName"`
+}
+
+// GetBotInstanceName returns CreateStrategyCreateStrategy.BotInstanceName, and is useful for accessing the field via an interface.
+func (v *CreateStrategyCreateStrategy) GetBotInstanceName() string { return v.BotInstanceName }
+
+// CreateStrategyResponse is returned by CreateStrategy on success.
+type CreateStrategyResponse struct {
+	// Creates a New strategy
+	CreateStrategy CreateStrategyCreateStrategy `json:"createStrategy"`
+}
+
+// GetCreateStrategy returns CreateStrategyResponse.CreateStrategy, and is useful for accessing the field via an interface.
+func (v *CreateStrategyResponse) GetCreateStrategy() CreateStrategyCreateStrategy {
+	return v.CreateStrategy
+}
+
+// CreateStrategySweepCreateStrategySweep includes the requested fields of the GraphQL type StrategySweep.
+type CreateStrategySweepCreateStrategySweep struct {
+	SweepID      string `json:"SweepID"`
+	Permutations int    `json:"Permutations"`
+}
+
+// GetSweepID returns CreateStrategySweepCreateStrategySweep.SweepID, and is useful for accessing the field via an interface.
+func (v *CreateStrategySweepCreateStrategySweep) GetSweepID() string { return v.SweepID }
+
+// GetPermutations returns CreateStrategySweepCreateStrategySweep.Permutations, and is useful for accessing the field via an interface.
+func (v *CreateStrategySweepCreateStrategySweep) GetPermutations() int { return v.Permutations }
+
+// CreateStrategySweepResponse is returned by CreateStrategySweep on success.
+type CreateStrategySweepResponse struct {
+	// Stores the ranked results of a strategy parameter sweep
+	CreateStrategySweep CreateStrategySweepCreateStrategySweep `json:"createStrategySweep"`
+}
+
+// GetCreateStrategySweep returns CreateStrategySweepResponse.CreateStrategySweep, and is useful for accessing the field via an interface.
+func (v *CreateStrategySweepResponse) GetCreateStrategySweep() CreateStrategySweepCreateStrategySweep {
+	return v.CreateStrategySweep
+}
+
 // CreateTaskCreateTask includes the requested fields of the GraphQL type Task.
 type CreateTaskCreateTask struct {
 	Id          string   `json:"id"`
@@ -281,6 +671,10 @@ type CreateTaskCreateTask struct {
 	Department  string   `json:"department"`
 	ProjectId   string   `json:"projectId"`
 	Duration    int      `json:"duration"`
+	Estimate    int      `json:"estimate"`
+	ParentId    string   `json:"parentId"`
+	BlockedBy   []string `json:"blockedBy"`
+	Recurrence  string   `json:"recurrence"`
 	CreatedAt   string   `json:"createdAt"`
 	UpdatedAt   string   `json:"updatedAt"`
 }
@@ -318,6 +712,18 @@ func (v *CreateTaskCreateTask) GetProjectId() string { return v.ProjectId }
 // GetDuration returns CreateTaskCreateTask.Duration, and is useful for accessing the field via an interface.
 func (v *CreateTaskCreateTask) GetDuration() int { return v.Duration }
 
+// GetEstimate returns CreateTaskCreateTask.Estimate, and is useful for accessing the field via an interface.
+func (v *CreateTaskCreateTask) GetEstimate() int { return v.Estimate }
+
+// GetParentId returns CreateTaskCreateTask.ParentId, and is useful for accessing the field via an interface.
+func (v *CreateTaskCreateTask) GetParentId() string { return v.ParentId }
+
+// GetBlockedBy returns CreateTaskCreateTask.BlockedBy, and is useful for accessing the field via an interface.
+func (v *CreateTaskCreateTask) GetBlockedBy() []string { return v.BlockedBy }
+
+// GetRecurrence returns CreateTaskCreateTask.Recurrence, and is useful for accessing the field via an interface.
+func (v *CreateTaskCreateTask) GetRecurrence() string { return v.Recurrence }
+
 // GetCreatedAt returns CreateTaskCreateTask.CreatedAt, and is useful for accessing the field via an interface.
 func (v *CreateTaskCreateTask) GetCreatedAt() string { return v.CreatedAt }
 
@@ -325,23 +731,32 @@ func (v *CreateTaskCreateTask) GetCreatedAt() string { return v.CreatedAt }
 func (v *CreateTaskCreateTask) GetUpdatedAt() string { return v.UpdatedAt }
 
 type CreateTaskInput struct {
+	Id          *string  `json:"id"`
 	Title       string   `json:"title"`
-	Description string   `json:"description"`
+	Description *string  `json:"description"`
 	Status      string   `json:"status"`
 	Labels      []string `json:"labels"`
-	AssignedTo  string   `json:"assignedTo"`
-	DueDate     string   `json:"dueDate"`
-	DeferDate   string   `json:"deferDate"`
-	Department  string   `json:"department"`
-	ProjectId   string   `json:"projectId"`
-	Duration    int      `json:"duration"`
-}
+	AssignedTo  *string  `json:"assignedTo"`
+	DueDate     *string  `json:"dueDate"`
+	DeferDate   *string  `json:"deferDate"`
+	Department  *string  `json:"department"`
+	ProjectId   *string  `json:"projectId"`
+	Duration    *int     `json:"duration"`
+	Estimate    *int     `json:"estimate"`
+	ParentId    *string  `json:"parentId"`
+	BlockedBy   []string `json:"blockedBy"`
+	Recurrence  *string  `json:"recurrence"`
+	Imported    bool     `json:"imported"`
+}
+
+// GetId returns CreateTaskInput.Id, and is useful for accessing the field via an interface.
+func (v *CreateTaskInput) GetId() *string { return v.Id }
 
 // GetTitle returns CreateTaskInput.Title, and is useful for accessing the field via an interface.
 func (v *CreateTaskInput) GetTitle() string { return v.Title }
 
 // GetDescription returns CreateTaskInput.Description, and is useful for accessing the field via an interface.
-func (v *CreateTaskInput) GetDescription() string { return v.Description }
+func (v *CreateTaskInput) GetDescription() *string { return v.Description }
 
 // GetStatus returns CreateTaskInput.Status, and is useful for accessing the field via an interface.
 func (v *CreateTaskInput) GetStatus() string { return v.Status }
@@ -350,22 +765,37 @@ func (v *CreateTaskInput) GetStatus() string { return v.Status }
 func (v *CreateTaskInput) GetLabels() []string { return v.Labels }
 
 // GetAssignedTo returns CreateTaskInput.AssignedTo, and is useful for accessing the field via an interface.
-func (v *CreateTaskInput) GetAssignedTo() string { return v.AssignedTo }
+func (v *CreateTaskInput) GetAssignedTo() *string { return v.AssignedTo }
 
 // GetDueDate returns CreateTaskInput.DueDate, and is useful for accessing the field via an interface.
-func (v *CreateTaskInput) GetDueDate() string { return v.DueDate }
+func (v *CreateTaskInput) GetDueDate() *string { return v.DueDate }
 
 // GetDeferDate returns CreateTaskInput.DeferDate, and is useful for accessing the field via an interface.
-func (v *CreateTaskInput) GetDeferDate() string { return v.DeferDate }
+func (v *CreateTaskInput) GetDeferDate() *string { return v.DeferDate }
 
 // GetDepartment returns CreateTaskInput.Department, and is useful for accessing the field via an interface.
-func (v *CreateTaskInput) GetDepartment() string { return v.Department }
+func (v *CreateTaskInput) GetDepartment() *string { return v.Department }
 
 // GetProjectId returns CreateTaskInput.ProjectId, and is useful for accessing the field via an interface.
-func (v *CreateTaskInput) GetProjectId() string { return v.ProjectId }
+func (v *CreateTaskInput) GetProjectId() *string { return v.ProjectId }
 
 // GetDuration returns CreateTaskInput.Duration, and is useful for accessing the field via an interface.
-func (v *CreateTaskInput) GetDuration() int { return v.Duration }
+func (v *CreateTaskInput) GetDuration() *int { return v.Duration }
+
+// GetEstimate returns CreateTaskInput.Estimate, and is useful for accessing the field via an interface.
+func (v *CreateTaskInput) GetEstimate() *int { return v.Estimate }
+
+// GetParentId returns CreateTaskInput.ParentId, and is useful for accessing the field via an interface.
+func (v *CreateTaskInput) GetParentId() *string { return v.ParentId }
+
+// GetBlockedBy returns CreateTaskInput.BlockedBy, and is useful for accessing the field via an interface.
+func (v *CreateTaskInput) GetBlockedBy() []string { return v.BlockedBy }
+
+// GetRecurrence returns CreateTaskInput.Recurrence, and is useful for accessing the field via an interface.
+func (v *CreateTaskInput) GetRecurrence() *string { return v.Recurrence }
+
+// GetImported returns CreateTaskInput.Imported, and is useful for accessing the field via an interface.
+func (v *CreateTaskInput) GetImported() bool { return v.Imported }
 
 // CreateTaskResponse is returned by CreateTask on success.
 type CreateTaskResponse struct {
@@ -376,6 +806,37 @@ type CreateTaskResponse struct {
 // GetCreateTask returns CreateTaskResponse.CreateTask, and is useful for accessing the field via an interface.
 func (v *CreateTaskResponse) GetCreateTask() CreateTaskCreateTask { return v.CreateTask }
 
+// CreateTradeOpenedCreateTradeOpened includes the requested fields of the GraphQL type TradeOpened.
+type CreateTradeOpenedCreateTradeOpened struct {
+	Id        string `json:"_id"`
+	Timestamp int    `json:"Timestamp"`
+	BotName   string `json:"BotName"`
+	Symbol    string `json:"Symbol"`
+}
+
+// GetId returns CreateTradeOpenedCreateTradeOpened.Id, and is useful for accessing the field via an interface.
+func (v *CreateTradeOpenedCreateTradeOpened) GetId() string { return v.Id }
+
+// GetTimestamp returns CreateTradeOpenedCreateTradeOpened.Timestamp, and is useful for accessing the field via an interface.
+func (v *CreateTradeOpenedCreateTradeOpened) GetTimestamp() int { return v.Timestamp }
+
+// GetBotName returns CreateTradeOpenedCreateTradeOpened.BotName, and is useful for accessing the field via an interface.
+func (v *CreateTradeOpenedCreateTradeOpened) GetBotName() string { return v.BotName }
+
+// GetSymbol returns CreateTradeOpenedCreateTradeOpened.Symbol, and is useful for accessing the field via an interface.
+func (v *CreateTradeOpenedCreateTradeOpened) GetSymbol() string { return v.Symbol }
+
+// CreateTradeOpenedResponse is returned by CreateTradeOpened on success.
+type CreateTradeOpenedResponse struct {
+	// Records that a bot has opened a trade
+	CreateTradeOpened CreateTradeOpenedCreateTradeOpened `json:"createTradeOpened"`
+}
+
+// GetCreateTradeOpened returns CreateTradeOpenedResponse.CreateTradeOpened, and is useful for accessing the field via an interface.
+func (v *CreateTradeOpenedResponse) GetCreateTradeOpened() CreateTradeOpenedCreateTradeOpened {
+	return v.CreateTradeOpened
+}
+
 // CreateTradeOutcomeReportCreateTradeOutcomeReport includes the requested fields of the GraphQL type TradeOutcomeReport.
 type CreateTradeOutcomeReportCreateTradeOutcomeReport struct {
 	Id               string  `json:"_id"`
@@ -390,6 +851,7 @@ type CreateTradeOutcomeReportCreateTradeOutcomeReport struct {
 	Volume           float64 `json:"Volume"`
 	FearGreedIndex   int     `json:"FearGreedIndex"`
 	MarketStatus     string  `json:"MarketStatus"`
+	VersionID        string  `json:"VersionID"`
 }
 
 // GetId returns CreateTradeOutcomeReportCreateTradeOutcomeReport.Id, and is useful for accessing the field via an interface.
@@ -434,6 +896,9 @@ func (v *CreateTradeOutcomeReportCreateTradeOutcomeReport) GetMarketStatus() str
 	return v.MarketStatus
 }
 
+// GetVersionID returns CreateTradeOutcomeReportCreateTradeOutcomeReport.VersionID, and is useful for accessing the field via an interface.
+func (v *CreateTradeOutcomeReportCreateTradeOutcomeReport) GetVersionID() string { return v.VersionID }
+
 // CreateTradeOutcomeReportResponse is returned by CreateTradeOutcomeReport on success.
 type CreateTradeOutcomeReportResponse struct {
 	// Creates a new Trade Outcome Report
@@ -513,140 +978,545 @@ type CreateUserResponse struct {
 // GetCreateUser returns CreateUserResponse.CreateUser, and is useful for accessing the field via an interface.
 func (v *CreateUserResponse) GetCreateUser() CreateUserCreateUser { return v.CreateUser }
 
-type NewHistoricPriceInput struct {
-	Pairs     []PairInput `json:"Pairs"`
-	Timestamp int         `json:"Timestamp"`
+type DepartmentAssigneeInput struct {
+	Department string `json:"department"`
+	AssignedTo string `json:"assignedTo"`
 }
 
-// GetPairs returns NewHistoricPriceInput.Pairs, and is useful for accessing the field via an interface.
-func (v *NewHistoricPriceInput) GetPairs() []PairInput { return v.Pairs }
+// GetDepartment returns DepartmentAssigneeInput.Department, and is useful for accessing the field via an interface.
+func (v *DepartmentAssigneeInput) GetDepartment() string { return v.Department }
 
-// GetTimestamp returns NewHistoricPriceInput.Timestamp, and is useful for accessing the field via an interface.
-func (v *NewHistoricPriceInput) GetTimestamp() int { return v.Timestamp }
+// GetAssignedTo returns DepartmentAssigneeInput.AssignedTo, and is useful for accessing the field via an interface.
+func (v *DepartmentAssigneeInput) GetAssignedTo() string { return v.AssignedTo }
 
-type NewHistoricTickerStatsInput struct {
-	Timestamp int                `json:"Timestamp"`
-	Stats     []TickerStatsInput `json:"Stats"`
+type EquityPointInput struct {
+	Timestamp int     `json:"Timestamp"`
+	Balance   float64 `json:"Balance"`
 }
 
-// GetTimestamp returns NewHistoricTickerStatsInput.Timestamp, and is useful for accessing the field via an interface.
-func (v *NewHistoricTickerStatsInput) GetTimestamp() int { return v.Timestamp }
+// GetTimestamp returns EquityPointInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *EquityPointInput) GetTimestamp() int { return v.Timestamp }
 
-// GetStats returns NewHistoricTickerStatsInput.Stats, and is useful for accessing the field via an interface.
-func (v *NewHistoricTickerStatsInput) GetStats() []TickerStatsInput { return v.Stats }
+// GetBalance returns EquityPointInput.Balance, and is useful for accessing the field via an interface.
+func (v *EquityPointInput) GetBalance() float64 { return v.Balance }
 
-type PairInput struct {
-	Symbol           string `json:"Symbol"`
-	Price            string `json:"Price"`
-	PercentageChange string `json:"PercentageChange"`
+// FailStaleJobRunsResponse is returned by FailStaleJobRuns on success.
+type FailStaleJobRunsResponse struct {
+	// Marks runs left RUNNING for longer than olderThan seconds as FAILED, returning how many
+	FailStaleJobRuns int `json:"failStaleJobRuns"`
 }
 
-// GetSymbol returns PairInput.Symbol, and is useful for accessing the field via an interface.
-func (v *PairInput) GetSymbol() string { return v.Symbol }
+// GetFailStaleJobRuns returns FailStaleJobRunsResponse.FailStaleJobRuns, and is useful for accessing the field via an interface.
+func (v *FailStaleJobRunsResponse) GetFailStaleJobRuns() int { return v.FailStaleJobRuns }
 
-// GetPrice returns PairInput.Price, and is useful for accessing the field via an interface.
-func (v *PairInput) GetPrice() string { return v.Price }
+// FinishJobRunFinishJobRun includes the requested fields of the GraphQL type JobRun.
+type FinishJobRunFinishJobRun struct {
+	RunID      string    `json:"RunID"`
+	Status     JobStatus `json:"Status"`
+	DurationMs int       `json:"DurationMs"`
+}
 
-// GetPercentageChange returns PairInput.PercentageChange, and is useful for accessing the field via an interface.
-func (v *PairInput) GetPercentageChange() string { return v.PercentageChange }
+// GetRunID returns FinishJobRunFinishJobRun.RunID, and is useful for accessing the field via an interface.
+func (v *FinishJobRunFinishJobRun) GetRunID() string { return v.RunID }
 
-// ReadAllStrategiesReadAllStrategiesStrategy includes the requested fields of the GraphQL type Strategy.
-type ReadAllStrategiesReadAllStrategiesStrategy struct {
-	BotInstanceName      string  `json:"BotInstanceName"`
-	TradeDuration        int     `json:"TradeDuration"`
-	IncrementsATR        int     `json:"IncrementsATR"`
-	LongSMADuration      int     `json:"LongSMADuration"`
-	ShortSMADuration     int     `json:"ShortSMADuration"`
-	WINCounter           int     `json:"WINCounter"`
-	LOSSCounter          int     `json:"LOSSCounter"`
-	TIMEOUTGainCounter   int     `json:"TIMEOUTGainCounter"`
-	TIMEOUTLossCounter   int     `json:"TIMEOUTLossCounter"`
-	NetGainCounter       int     `json:"NetGainCounter"`
-	NetLossCounter       int     `json:"NetLossCounter"`
-	AccountBalance       float64 `json:"AccountBalance"`
-	MovingAveMomentum    float64 `json:"MovingAveMomentum"`
-	TakeProfitPercentage float64 `json:"TakeProfitPercentage"`
-	StopLossPercentage   float64 `json:"StopLossPercentage"`
-	ATRtollerance        float64 `json:"ATRtollerance"`
-	FeesTotal            float64 `json:"FeesTotal"`
-	Tested               bool    `json:"Tested"`
-	Owner                string  `json:"Owner"`
-	CreatedOn            int     `json:"CreatedOn"`
+// GetStatus returns FinishJobRunFinishJobRun.Status, and is useful for accessing the field via an interface.
+func (v *FinishJobRunFinishJobRun) GetStatus() JobStatus { return v.Status }
+
+// GetDurationMs returns FinishJobRunFinishJobRun.DurationMs, and is useful for accessing the field via an interface.
+func (v *FinishJobRunFinishJobRun) GetDurationMs() int { return v.DurationMs }
+
+// FinishJobRunResponse is returned by FinishJobRun on success.
+type FinishJobRunResponse struct {
+	// Records how a running job finished
+	FinishJobRun FinishJobRunFinishJobRun `json:"finishJobRun"`
 }
 
-// GetBotInstanceName returns ReadAllStrategiesReadAllStrategiesStrategy.BotInstanceName, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetBotInstanceName() string {
-	return v.BotInstanceName
+// GetFinishJobRun returns FinishJobRunResponse.FinishJobRun, and is useful for accessing the field via an interface.
+func (v *FinishJobRunResponse) GetFinishJobRun() FinishJobRunFinishJobRun { return v.FinishJobRun }
+
+// InstantiateSopInstantiateSopProject includes the requested fields of the GraphQL type Project.
+type InstantiateSopInstantiateSopProject struct {
+	Id         string                                         `json:"id"`
+	Title      string                                         `json:"title"`
+	AssignedTo string                                         `json:"assignedTo"`
+	DueDate    string                                         `json:"dueDate"`
+	Status     string                                         `json:"status"`
+	Tasks      []InstantiateSopInstantiateSopProjectTasksTask `json:"tasks"`
 }
 
-// GetTradeDuration returns ReadAllStrategiesReadAllStrategiesStrategy.TradeDuration, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTradeDuration() int { return v.TradeDuration }
+// GetId returns InstantiateSopInstantiateSopProject.Id, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProject) GetId() string { return v.Id }
 
-// GetIncrementsATR returns ReadAllStrategiesReadAllStrategiesStrategy.IncrementsATR, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetIncrementsATR() int { return v.IncrementsATR }
+// GetTitle returns InstantiateSopInstantiateSopProject.Title, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProject) GetTitle() string { return v.Title }
 
-// GetLongSMADuration returns ReadAllStrategiesReadAllStrategiesStrategy.LongSMADuration, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetLongSMADuration() int {
-	return v.LongSMADuration
+// GetAssignedTo returns InstantiateSopInstantiateSopProject.AssignedTo, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProject) GetAssignedTo() string { return v.AssignedTo }
+
+// GetDueDate returns InstantiateSopInstantiateSopProject.DueDate, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProject) GetDueDate() string { return v.DueDate }
+
+// GetStatus returns InstantiateSopInstantiateSopProject.Status, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProject) GetStatus() string { return v.Status }
+
+// GetTasks returns InstantiateSopInstantiateSopProject.Tasks, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProject) GetTasks() []InstantiateSopInstantiateSopProjectTasksTask {
+	return v.Tasks
 }
 
-// GetShortSMADuration returns ReadAllStrategiesReadAllStrategiesStrategy.ShortSMADuration, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetShortSMADuration() int {
-	return v.ShortSMADuration
+// InstantiateSopInstantiateSopProjectTasksTask includes the requested fields of the GraphQL type Task.
+type InstantiateSopInstantiateSopProjectTasksTask struct {
+	Id         string `json:"id"`
+	Title      string `json:"title"`
+	AssignedTo string `json:"assignedTo"`
+	DueDate    string `json:"dueDate"`
+	DeferDate  string `json:"deferDate"`
+	Department string `json:"department"`
 }
 
-// GetWINCounter returns ReadAllStrategiesReadAllStrategiesStrategy.WINCounter, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetWINCounter() int { return v.WINCounter }
+// GetId returns InstantiateSopInstantiateSopProjectTasksTask.Id, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProjectTasksTask) GetId() string { return v.Id }
 
-// GetLOSSCounter returns ReadAllStrategiesReadAllStrategiesStrategy.LOSSCounter, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetLOSSCounter() int { return v.LOSSCounter }
+// GetTitle returns InstantiateSopInstantiateSopProjectTasksTask.Title, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProjectTasksTask) GetTitle() string { return v.Title }
 
-// GetTIMEOUTGainCounter returns ReadAllStrategiesReadAllStrategiesStrategy.TIMEOUTGainCounter, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTIMEOUTGainCounter() int {
-	return v.TIMEOUTGainCounter
-}
+// GetAssignedTo returns InstantiateSopInstantiateSopProjectTasksTask.AssignedTo, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProjectTasksTask) GetAssignedTo() string { return v.AssignedTo }
 
-// GetTIMEOUTLossCounter returns ReadAllStrategiesReadAllStrategiesStrategy.TIMEOUTLossCounter, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTIMEOUTLossCounter() int {
-	return v.TIMEOUTLossCounter
-}
+// GetDueDate returns InstantiateSopInstantiateSopProjectTasksTask.DueDate, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProjectTasksTask) GetDueDate() string { return v.DueDate }
 
-// GetNetGainCounter returns ReadAllStrategiesReadAllStrategiesStrategy.NetGainCounter, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetNetGainCounter() int { return v.NetGainCounter }
+// GetDeferDate returns InstantiateSopInstantiateSopProjectTasksTask.DeferDate, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProjectTasksTask) GetDeferDate() string { return v.DeferDate }
 
-// GetNetLossCounter returns ReadAllStrategiesReadAllStrategiesStrategy.NetLossCounter, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetNetLossCounter() int { return v.NetLossCounter }
+// GetDepartment returns InstantiateSopInstantiateSopProjectTasksTask.Department, and is useful for accessing the field via an interface.
+func (v *InstantiateSopInstantiateSopProjectTasksTask) GetDepartment() string { return v.Department }
 
-// GetAccountBalance returns ReadAllStrategiesReadAllStrategiesStrategy.AccountBalance, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetAccountBalance() float64 {
-	return v.AccountBalance
+// InstantiateSopResponse is returned by InstantiateSop on success.
+type InstantiateSopResponse struct {
+	// Copy an SOP template and its tasks into a new active project. Task due and
+	// defer dates are rebased from the start date, either as offsets such as "+3d"
+	// or "2w", or as dates kept at the same distance from the template's earliest.
+	// Tasks in the given departments are reassigned.
+	InstantiateSop InstantiateSopInstantiateSopProject `json:"instantiateSop"`
 }
 
-// GetMovingAveMomentum returns ReadAllStrategiesReadAllStrategiesStrategy.MovingAveMomentum, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetMovingAveMomentum() float64 {
-	return v.MovingAveMomentum
+// GetInstantiateSop returns InstantiateSopResponse.InstantiateSop, and is useful for accessing the field via an interface.
+func (v *InstantiateSopResponse) GetInstantiateSop() InstantiateSopInstantiateSopProject {
+	return v.InstantiateSop
 }
 
-// GetTakeProfitPercentage returns ReadAllStrategiesReadAllStrategiesStrategy.TakeProfitPercentage, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTakeProfitPercentage() float64 {
-	return v.TakeProfitPercentage
+type JobRunInput struct {
+	Job     string     `json:"Job"`
+	Slot    *int       `json:"Slot"`
+	Trigger JobTrigger `json:"Trigger"`
+	Status  JobStatus  `json:"Status"`
+	Error   *string    `json:"Error"`
 }
 
-// GetStopLossPercentage returns ReadAllStrategiesReadAllStrategiesStrategy.StopLossPercentage, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetStopLossPercentage() float64 {
-	return v.StopLossPercentage
-}
+// GetJob returns JobRunInput.Job, and is useful for accessing the field via an interface.
+func (v *JobRunInput) GetJob() string { return v.Job }
 
-// GetATRtollerance returns ReadAllStrategiesReadAllStrategiesStrategy.ATRtollerance, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetATRtollerance() float64 {
-	return v.ATRtollerance
-}
+// GetSlot returns JobRunInput.Slot, and is useful for accessing the field via an interface.
+func (v *JobRunInput) GetSlot() *int { return v.Slot }
 
-// GetFeesTotal returns ReadAllStrategiesReadAllStrategiesStrategy.FeesTotal, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetFeesTotal() float64 { return v.FeesTotal }
+// GetTrigger returns JobRunInput.Trigger, and is useful for accessing the field via an interface.
+func (v *JobRunInput) GetTrigger() JobTrigger { return v.Trigger }
 
-// GetTested returns ReadAllStrategiesReadAllStrategiesStrategy.Tested, and is useful for accessing the field via an interface.
-func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTested() bool { return v.Tested }
+// GetStatus returns JobRunInput.Status, and is useful for accessing the field via an interface.
+func (v *JobRunInput) GetStatus() JobStatus { return v.Status }
+
+// GetError returns JobRunInput.Error, and is useful for accessing the field via an interface.
+func (v *JobRunInput) GetError() *string { return v.Error }
+
+type JobRunResult struct {
+	Status     JobStatus `json:"Status"`
+	DurationMs int       `json:"DurationMs"`
+	Error      *string   `json:"Error"`
+}
+
+// GetStatus returns JobRunResult.Status, and is useful for accessing the field via an interface.
+func (v *JobRunResult) GetStatus() JobStatus { return v.Status }
+
+// GetDurationMs returns JobRunResult.DurationMs, and is useful for accessing the field via an interface.
+func (v *JobRunResult) GetDurationMs() int { return v.DurationMs }
+
+// GetError returns JobRunResult.Error, and is useful for accessing the field via an interface.
+func (v *JobRunResult) GetError() *string { return v.Error }
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "QUEUED"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusSucceeded JobStatus = "SUCCEEDED"
+	JobStatusFailed    JobStatus = "FAILED"
+	JobStatusSkipped   JobStatus = "SKIPPED"
+)
+
+var AllJobStatus = []JobStatus{
+	JobStatusQueued,
+	JobStatusRunning,
+	JobStatusSucceeded,
+	JobStatusFailed,
+	JobStatusSkipped,
+}
+
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "SCHEDULE"
+	JobTriggerCatchUp  JobTrigger = "CATCH_UP"
+	JobTriggerManual   JobTrigger = "MANUAL"
+)
+
+var AllJobTrigger = []JobTrigger{
+	JobTriggerSchedule,
+	JobTriggerCatchUp,
+	JobTriggerManual,
+}
+
+type MarketBreadthInput struct {
+	Timestamp         int                      `json:"Timestamp"`
+	Pairs             int                      `json:"Pairs"`
+	Advancers         int                      `json:"Advancers"`
+	Decliners         int                      `json:"Decliners"`
+	Unchanged         int                      `json:"Unchanged"`
+	AdvancePercent    float64                  `json:"AdvancePercent"`
+	MedianChange      float64                  `json:"MedianChange"`
+	MeanChange        float64                  `json:"MeanChange"`
+	OnTheMove         int                      `json:"OnTheMove"`
+	VolumeShareMoving *float64                 `json:"VolumeShareMoving"`
+	Buckets           []BreadthBucketInput     `json:"Buckets"`
+	QuoteAssets       []QuoteAssetBreadthInput `json:"QuoteAssets"`
+}
+
+// GetTimestamp returns MarketBreadthInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetTimestamp() int { return v.Timestamp }
+
+// GetPairs returns MarketBreadthInput.Pairs, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetPairs() int { return v.Pairs }
+
+// GetAdvancers returns MarketBreadthInput.Advancers, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetAdvancers() int { return v.Advancers }
+
+// GetDecliners returns MarketBreadthInput.Decliners, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetDecliners() int { return v.Decliners }
+
+// GetUnchanged returns MarketBreadthInput.Unchanged, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetUnchanged() int { return v.Unchanged }
+
+// GetAdvancePercent returns MarketBreadthInput.AdvancePercent, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetAdvancePercent() float64 { return v.AdvancePercent }
+
+// GetMedianChange returns MarketBreadthInput.MedianChange, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetMedianChange() float64 { return v.MedianChange }
+
+// GetMeanChange returns MarketBreadthInput.MeanChange, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetMeanChange() float64 { return v.MeanChange }
+
+// GetOnTheMove returns MarketBreadthInput.OnTheMove, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetOnTheMove() int { return v.OnTheMove }
+
+// GetVolumeShareMoving returns MarketBreadthInput.VolumeShareMoving, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetVolumeShareMoving() *float64 { return v.VolumeShareMoving }
+
+// GetBuckets returns MarketBreadthInput.Buckets, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetBuckets() []BreadthBucketInput { return v.Buckets }
+
+// GetQuoteAssets returns MarketBreadthInput.QuoteAssets, and is useful for accessing the field via an interface.
+func (v *MarketBreadthInput) GetQuoteAssets() []QuoteAssetBreadthInput { return v.QuoteAssets }
+
+type NewHistoricPriceInput struct {
+	Pairs     []PairInput `json:"Pairs"`
+	Timestamp int         `json:"Timestamp"`
+}
+
+// GetPairs returns NewHistoricPriceInput.Pairs, and is useful for accessing the field via an interface.
+func (v *NewHistoricPriceInput) GetPairs() []PairInput { return v.Pairs }
+
+// GetTimestamp returns NewHistoricPriceInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *NewHistoricPriceInput) GetTimestamp() int { return v.Timestamp }
+
+type NewHistoricTickerStatsInput struct {
+	Timestamp int                `json:"Timestamp"`
+	Stats     []TickerStatsInput `json:"Stats"`
+}
+
+// GetTimestamp returns NewHistoricTickerStatsInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *NewHistoricTickerStatsInput) GetTimestamp() int { return v.Timestamp }
+
+// GetStats returns NewHistoricTickerStatsInput.Stats, and is useful for accessing the field via an interface.
+func (v *NewHistoricTickerStatsInput) GetStats() []TickerStatsInput { return v.Stats }
+
+type NewTradeOpened struct {
+	Timestamp  int     `json:"Timestamp"`
+	BotName    string  `json:"BotName"`
+	Symbol     string  `json:"Symbol"`
+	EntryPrice float64 `json:"EntryPrice"`
+	TakeProfit float64 `json:"TakeProfit"`
+	StopLoss   float64 `json:"StopLoss"`
+	TimesOutAt int     `json:"TimesOutAt"`
+	VersionID  string  `json:"VersionID,omitempty"`
+}
+
+// GetTimestamp returns NewTradeOpened.Timestamp, and is useful for accessing the field via an interface.
+func (v *NewTradeOpened) GetTimestamp() int { return v.Timestamp }
+
+// GetBotName returns NewTradeOpened.BotName, and is useful for accessing the field via an interface.
+func (v *NewTradeOpened) GetBotName() string { return v.BotName }
+
+// GetSymbol returns NewTradeOpened.Symbol, and is useful for accessing the field via an interface.
+func (v *NewTradeOpened) GetSymbol() string { return v.Symbol }
+
+// GetEntryPrice returns NewTradeOpened.EntryPrice, and is useful for accessing the field via an interface.
+func (v *NewTradeOpened) GetEntryPrice() float64 { return v.EntryPrice }
+
+// GetTakeProfit returns NewTradeOpened.TakeProfit, and is useful for accessing the field via an interface.
+func (v *NewTradeOpened) GetTakeProfit() float64 { return v.TakeProfit }
+
+// GetStopLoss returns NewTradeOpened.StopLoss, and is useful for accessing the field via an interface.
+func (v *NewTradeOpened) GetStopLoss() float64 { return v.StopLoss }
+
+// GetTimesOutAt returns NewTradeOpened.TimesOutAt, and is useful for accessing the field via an interface.
+func (v *NewTradeOpened) GetTimesOutAt() int { return v.TimesOutAt }
+
+// GetVersionID returns NewTradeOpened.VersionID, and is useful for accessing the field via an interface.
+func (v *NewTradeOpened) GetVersionID() string { return v.VersionID }
+
+type PairInput struct {
+	Symbol           string `json:"Symbol"`
+	Price            string `json:"Price"`
+	PercentageChange string `json:"PercentageChange"`
+}
+
+// GetSymbol returns PairInput.Symbol, and is useful for accessing the field via an interface.
+func (v *PairInput) GetSymbol() string { return v.Symbol }
+
+// GetPrice returns PairInput.Price, and is useful for accessing the field via an interface.
+func (v *PairInput) GetPrice() string { return v.Price }
+
+// GetPercentageChange returns PairInput.PercentageChange, and is useful for accessing the field via an interface.
+func (v *PairInput) GetPercentageChange() string { return v.PercentageChange }
+
+// PromoteDeferredTasksPromoteDeferredTasksTask includes the requested fields of the GraphQL type Task.
+type PromoteDeferredTasksPromoteDeferredTasksTask struct {
+	Id        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	DeferDate string `json:"deferDate"`
+}
+
+// GetId returns PromoteDeferredTasksPromoteDeferredTasksTask.Id, and is useful for accessing the field via an interface.
+func (v *PromoteDeferredTasksPromoteDeferredTasksTask) GetId() string { return v.Id }
+
+// GetTitle returns PromoteDeferredTasksPromoteDeferredTasksTask.Title, and is useful for accessing the field via an interface.
+func (v *PromoteDeferredTasksPromoteDeferredTasksTask) GetTitle() string { return v.Title }
+
+// GetStatus returns PromoteDeferredTasksPromoteDeferredTasksTask.Status, and is useful for accessing the field via an interface.
+func (v *PromoteDeferredTasksPromoteDeferredTasksTask) GetStatus() string { return v.Status }
+
+// GetDeferDate returns PromoteDeferredTasksPromoteDeferredTasksTask.DeferDate, and is useful for accessing the field via an interface.
+func (v *PromoteDeferredTasksPromoteDeferredTasksTask) GetDeferDate() string { return v.DeferDate }
+
+// PromoteDeferredTasksResponse is returned by PromoteDeferredTasks on success.
+type PromoteDeferredTasksResponse struct {
+	// Move scheduled tasks whose deferDate has been reached to nextAction
+	PromoteDeferredTasks []PromoteDeferredTasksPromoteDeferredTasksTask `json:"promoteDeferredTasks"`
+}
+
+// GetPromoteDeferredTasks returns PromoteDeferredTasksResponse.PromoteDeferredTasks, and is useful for accessing the field via an interface.
+func (v *PromoteDeferredTasksResponse) GetPromoteDeferredTasks() []PromoteDeferredTasksPromoteDeferredTasksTask {
+	return v.PromoteDeferredTasks
+}
+
+type QuoteAssetBreadthInput struct {
+	QuoteAsset   string  `json:"QuoteAsset"`
+	Pairs        int     `json:"Pairs"`
+	Advancers    int     `json:"Advancers"`
+	Decliners    int     `json:"Decliners"`
+	MedianChange float64 `json:"MedianChange"`
+}
+
+// GetQuoteAsset returns QuoteAssetBreadthInput.QuoteAsset, and is useful for accessing the field via an interface.
+func (v *QuoteAssetBreadthInput) GetQuoteAsset() string { return v.QuoteAsset }
+
+// GetPairs returns QuoteAssetBreadthInput.Pairs, and is useful for accessing the field via an interface.
+func (v *QuoteAssetBreadthInput) GetPairs() int { return v.Pairs }
+
+// GetAdvancers returns QuoteAssetBreadthInput.Advancers, and is useful for accessing the field via an interface.
+func (v *QuoteAssetBreadthInput) GetAdvancers() int { return v.Advancers }
+
+// GetDecliners returns QuoteAssetBreadthInput.Decliners, and is useful for accessing the field via an interface.
+func (v *QuoteAssetBreadthInput) GetDecliners() int { return v.Decliners }
+
+// GetMedianChange returns QuoteAssetBreadthInput.MedianChange, and is useful for accessing the field via an interface.
+func (v *QuoteAssetBreadthInput) GetMedianChange() float64 { return v.MedianChange }
+
+// ReadActivityReportAtReadActivityReportAtActivityReport includes the requested fields of the GraphQL type ActivityReport.
+type ReadActivityReportAtReadActivityReportAtActivityReport struct {
+	Timestamp      int     `json:"Timestamp"`
+	Qty            int     `json:"Qty"`
+	FearGreedIndex int     `json:"FearGreedIndex"`
+	Breadth        float64 `json:"Breadth"`
+	MarketStatus   string  `json:"MarketStatus"`
+}
+
+// GetTimestamp returns ReadActivityReportAtReadActivityReportAtActivityReport.Timestamp, and is useful for accessing the field via an interface.
+func (v *ReadActivityReportAtReadActivityReportAtActivityReport) GetTimestamp() int {
+	return v.Timestamp
+}
+
+// GetQty returns ReadActivityReportAtReadActivityReportAtActivityReport.Qty, and is useful for accessing the field via an interface.
+func (v *ReadActivityReportAtReadActivityReportAtActivityReport) GetQty() int { return v.Qty }
+
+// GetFearGreedIndex returns ReadActivityReportAtReadActivityReportAtActivityReport.FearGreedIndex, and is useful for accessing the field via an interface.
+func (v *ReadActivityReportAtReadActivityReportAtActivityReport) GetFearGreedIndex() int {
+	return v.FearGreedIndex
+}
+
+// GetBreadth returns ReadActivityReportAtReadActivityReportAtActivityReport.Breadth, and is useful for accessing the field via an interface.
+func (v *ReadActivityReportAtReadActivityReportAtActivityReport) GetBreadth() float64 {
+	return v.Breadth
+}
+
+// GetMarketStatus returns ReadActivityReportAtReadActivityReportAtActivityReport.MarketStatus, and is useful for accessing the field via an interface.
+func (v *ReadActivityReportAtReadActivityReportAtActivityReport) GetMarketStatus() string {
+	return v.MarketStatus
+}
+
+// ReadActivityReportAtResponse is returned by ReadActivityReportAt on success.
+type ReadActivityReportAtResponse struct {
+	// Get the latest activity report at or before a timestamp
+	ReadActivityReportAt ReadActivityReportAtReadActivityReportAtActivityReport `json:"readActivityReportAt"`
+}
+
+// GetReadActivityReportAt returns ReadActivityReportAtResponse.ReadActivityReportAt, and is useful for accessing the field via an interface.
+func (v *ReadActivityReportAtResponse) GetReadActivityReportAt() ReadActivityReportAtReadActivityReportAtActivityReport {
+	return v.ReadActivityReportAt
+}
+
+// ReadAllStrategiesReadAllStrategiesStrategy includes the requested fields of the GraphQL type Strategy.
+type ReadAllStrategiesReadAllStrategiesStrategy struct {
+	BotInstanceName      string            `json:"BotInstanceName"`
+	TradeDuration        int               `json:"TradeDuration"`
+	IncrementsATR        int               `json:"IncrementsATR"`
+	LongSMADuration      int               `json:"LongSMADuration"`
+	ShortSMADuration     int               `json:"ShortSMADuration"`
+	WINCounter           int               `json:"WINCounter"`
+	LOSSCounter          int               `json:"LOSSCounter"`
+	TIMEOUTGainCounter   int               `json:"TIMEOUTGainCounter"`
+	TIMEOUTLossCounter   int               `json:"TIMEOUTLossCounter"`
+	NetGainCounter       int               `json:"NetGainCounter"`
+	NetLossCounter       int               `json:"NetLossCounter"`
+	AccountBalance       float64           `json:"AccountBalance"`
+	MovingAveMomentum    float64           `json:"MovingAveMomentum"`
+	TakeProfitPercentage float64           `json:"TakeProfitPercentage"`
+	StopLossPercentage   float64           `json:"StopLossPercentage"`
+	ATRtollerance        float64           `json:"ATRtollerance"`
+	MinFearGreed         *int              `json:"MinFearGreed"`
+	MaxFearGreed         *int              `json:"MaxFearGreed"`
+	AllowedSentiments    []string          `json:"AllowedSentiments"`
+	FeesTotal            float64           `json:"FeesTotal"`
+	Tested               bool              `json:"Tested"`
+	Lifecycle            StrategyLifecycle `json:"Lifecycle"`
+	VersionID            string            `json:"VersionID"`
+	Owner                string            `json:"Owner"`
+	CreatedOn            int               `json:"CreatedOn"`
+}
+
+// GetBotInstanceName returns ReadAllStrategiesReadAllStrategiesStrategy.BotInstanceName, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetBotInstanceName() string {
+	return v.BotInstanceName
+}
+
+// GetTradeDuration returns ReadAllStrategiesReadAllStrategiesStrategy.TradeDuration, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTradeDuration() int { return v.TradeDuration }
+
+// GetIncrementsATR returns ReadAllStrategiesReadAllStrategiesStrategy.IncrementsATR, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetIncrementsATR() int { return v.IncrementsATR }
+
+// GetLongSMADuration returns ReadAllStrategiesReadAllStrategiesStrategy.LongSMADuration, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetLongSMADuration() int {
+	return v.LongSMADuration
+}
+
+// GetShortSMADuration returns ReadAllStrategiesReadAllStrategiesStrategy.ShortSMADuration, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetShortSMADuration() int {
+	return v.ShortSMADuration
+}
+
+// GetWINCounter returns ReadAllStrategiesReadAllStrategiesStrategy.WINCounter, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetWINCounter() int { return v.WINCounter }
+
+// GetLOSSCounter returns ReadAllStrategiesReadAllStrategiesStrategy.LOSSCounter, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetLOSSCounter() int { return v.LOSSCounter }
+
+// GetTIMEOUTGainCounter returns ReadAllStrategiesReadAllStrategiesStrategy.TIMEOUTGainCounter, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTIMEOUTGainCounter() int {
+	return v.TIMEOUTGainCounter
+}
+
+// GetTIMEOUTLossCounter returns ReadAllStrategiesReadAllStrategiesStrategy.TIMEOUTLossCounter, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTIMEOUTLossCounter() int {
+	return v.TIMEOUTLossCounter
+}
+
+// GetNetGainCounter returns ReadAllStrategiesReadAllStrategiesStrategy.NetGainCounter, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetNetGainCounter() int { return v.NetGainCounter }
+
+// GetNetLossCounter returns ReadAllStrategiesReadAllStrategiesStrategy.NetLossCounter, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetNetLossCounter() int { return v.NetLossCounter }
+
+// GetAccountBalance returns ReadAllStrategiesReadAllStrategiesStrategy.AccountBalance, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetAccountBalance() float64 {
+	return v.AccountBalance
+}
+
+// GetMovingAveMomentum returns ReadAllStrategiesReadAllStrategiesStrategy.MovingAveMomentum, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetMovingAveMomentum() float64 {
+	return v.MovingAveMomentum
+}
+
+// GetTakeProfitPercentage returns ReadAllStrategiesReadAllStrategiesStrategy.TakeProfitPercentage, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTakeProfitPercentage() float64 {
+	return v.TakeProfitPercentage
+}
+
+// GetStopLossPercentage returns ReadAllStrategiesReadAllStrategiesStrategy.StopLossPercentage, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetStopLossPercentage() float64 {
+	return v.StopLossPercentage
+}
+
+// GetATRtollerance returns ReadAllStrategiesReadAllStrategiesStrategy.ATRtollerance, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetATRtollerance() float64 {
+	return v.ATRtollerance
+}
+
+// GetMinFearGreed returns ReadAllStrategiesReadAllStrategiesStrategy.MinFearGreed, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetMinFearGreed() *int { return v.MinFearGreed }
+
+// GetMaxFearGreed returns ReadAllStrategiesReadAllStrategiesStrategy.MaxFearGreed, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetMaxFearGreed() *int { return v.MaxFearGreed }
+
+// GetAllowedSentiments returns ReadAllStrategiesReadAllStrategiesStrategy.AllowedSentiments, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetAllowedSentiments() []string {
+	return v.AllowedSentiments
+}
+
+// GetFeesTotal returns ReadAllStrategiesReadAllStrategiesStrategy.FeesTotal, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetFeesTotal() float64 { return v.FeesTotal }
+
+// GetTested returns ReadAllStrategiesReadAllStrategiesStrategy.Tested, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetTested() bool { return v.Tested }
+
+// GetLifecycle returns ReadAllStrategiesReadAllStrategiesStrategy.Lifecycle, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetLifecycle() StrategyLifecycle {
+	return v.Lifecycle
+}
+
+// GetVersionID returns ReadAllStrategiesReadAllStrategiesStrategy.VersionID, and is useful for accessing the field via an interface.
+func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetVersionID() string { return v.VersionID }
 
 // GetOwner returns ReadAllStrategiesReadAllStrategiesStrategy.Owner, and is useful for accessing the field via an interface.
 func (v *ReadAllStrategiesReadAllStrategiesStrategy) GetOwner() string { return v.Owner }
@@ -677,7 +1547,11 @@ type ReadAllTasksReadAllTasksTask struct {
 	DeferDate   string   `json:"deferDate"`
 	Department  string   `json:"department"`
 	ProjectId   string   `json:"projectId"`
-	Duration    int      `json:"duration"`
+	Duration    *int     `json:"duration"`
+	Estimate    *int     `json:"estimate"`
+	ParentId    string   `json:"parentId"`
+	BlockedBy   []string `json:"blockedBy"`
+	Recurrence  string   `json:"recurrence"`
 	CreatedAt   string   `json:"createdAt"`
 	UpdatedAt   string   `json:"updatedAt"`
 }
@@ -713,7 +1587,19 @@ func (v *ReadAllTasksReadAllTasksTask) GetDepartment() string { return v.Departm
 func (v *ReadAllTasksReadAllTasksTask) GetProjectId() string { return v.ProjectId }
 
 // GetDuration returns ReadAllTasksReadAllTasksTask.Duration, and is useful for accessing the field via an interface.
-func (v *ReadAllTasksReadAllTasksTask) GetDuration() int { return v.Duration }
+func (v *ReadAllTasksReadAllTasksTask) GetDuration() *int { return v.Duration }
+
+// GetEstimate returns ReadAllTasksReadAllTasksTask.Estimate, and is useful for accessing the field via an interface.
+func (v *ReadAllTasksReadAllTasksTask) GetEstimate() *int { return v.Estimate }
+
+// GetParentId returns ReadAllTasksReadAllTasksTask.ParentId, and is useful for accessing the field via an interface.
+func (v *ReadAllTasksReadAllTasksTask) GetParentId() string { return v.ParentId }
+
+// GetBlockedBy returns ReadAllTasksReadAllTasksTask.BlockedBy, and is useful for accessing the field via an interface.
+func (v *ReadAllTasksReadAllTasksTask) GetBlockedBy() []string { return v.BlockedBy }
+
+// GetRecurrence returns ReadAllTasksReadAllTasksTask.Recurrence, and is useful for accessing the field via an interface.
+func (v *ReadAllTasksReadAllTasksTask) GetRecurrence() string { return v.Recurrence }
 
 // GetCreatedAt returns ReadAllTasksReadAllTasksTask.CreatedAt, and is useful for accessing the field via an interface.
 func (v *ReadAllTasksReadAllTasksTask) GetCreatedAt() string { return v.CreatedAt }
@@ -732,6 +1618,82 @@ func (v *ReadAllTasksResponse) GetReadAllTasks() []ReadAllTasksReadAllTasksTask
 	return v.ReadAllTasks
 }
 
+// ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex includes the requested fields of the GraphQL type FearAndGreedIndex.
+type ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex struct {
+	Timestamp           int    `json:"Timestamp"`
+	Value               string `json:"Value"`
+	ValueClassification string `json:"ValueClassification"`
+}
+
+// GetTimestamp returns ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex.Timestamp, and is useful for accessing the field via an interface.
+func (v *ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex) GetTimestamp() int {
+	return v.Timestamp
+}
+
+// GetValue returns ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex.Value, and is useful for accessing the field via an interface.
+func (v *ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex) GetValue() string {
+	return v.Value
+}
+
+// GetValueClassification returns ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex.ValueClassification, and is useful for accessing the field via an interface.
+func (v *ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex) GetValueClassification() string {
+	return v.ValueClassification
+}
+
+// ReadFearAndGreedIndexForTimeResponse is returned by ReadFearAndGreedIndexForTime on success.
+type ReadFearAndGreedIndexForTimeResponse struct {
+	// Reads the index in force at any time: the latest daily value at or before it
+	ReadFearAndGreedIndexForTime ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex `json:"readFearAndGreedIndexForTime"`
+}
+
+// GetReadFearAndGreedIndexForTime returns ReadFearAndGreedIndexForTimeResponse.ReadFearAndGreedIndexForTime, and is useful for accessing the field via an interface.
+func (v *ReadFearAndGreedIndexForTimeResponse) GetReadFearAndGreedIndexForTime() ReadFearAndGreedIndexForTimeReadFearAndGreedIndexForTimeFearAndGreedIndex {
+	return v.ReadFearAndGreedIndexForTime
+}
+
+// ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPrices includes the requested fields of the GraphQL type HistoricPrices.
+type ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPrices struct {
+	Pair      []ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPricesPair `json:"Pair"`
+	Timestamp int                                                                `json:"Timestamp"`
+}
+
+// GetPair returns ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPrices.Pair, and is useful for accessing the field via an interface.
+func (v *ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPrices) GetPair() []ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPricesPair {
+	return v.Pair
+}
+
+// GetTimestamp returns ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPrices.Timestamp, and is useful for accessing the field via an interface.
+func (v *ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPrices) GetTimestamp() int {
+	return v.Timestamp
+}
+
+// ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPricesPair includes the requested fields of the GraphQL type Pair.
+type ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPricesPair struct {
+	Symbol string `json:"Symbol"`
+	Price  string `json:"Price"`
+}
+
+// GetSymbol returns ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPricesPair.Symbol, and is useful for accessing the field via an interface.
+func (v *ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPricesPair) GetSymbol() string {
+	return v.Symbol
+}
+
+// GetPrice returns ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPricesPair.Price, and is useful for accessing the field via an interface.
+func (v *ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPricesPair) GetPrice() string {
+	return v.Price
+}
+
+// ReadHistoricPriceBeforeResponse is returned by ReadHistoricPriceBefore on success.
+type ReadHistoricPriceBeforeResponse struct {
+	// Fetches price data for a given symbol at or before a timestamp, most recent first, up to a given limit of records
+	ReadHistoricPriceBefore []ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPrices `json:"readHistoricPriceBefore"`
+}
+
+// GetReadHistoricPriceBefore returns ReadHistoricPriceBeforeResponse.ReadHistoricPriceBefore, and is useful for accessing the field via an interface.
+func (v *ReadHistoricPriceBeforeResponse) GetReadHistoricPriceBefore() []ReadHistoricPriceBeforeReadHistoricPriceBeforeHistoricPrices {
+	return v.ReadHistoricPriceBefore
+}
+
 // ReadHistoricPriceReadHistoricPriceHistoricPrices includes the requested fields of the GraphQL type HistoricPrices.
 type ReadHistoricPriceReadHistoricPriceHistoricPrices struct {
 	Pair      []ReadHistoricPriceReadHistoricPriceHistoricPricesPair `json:"Pair"`
@@ -903,6 +1865,15 @@ func (v *ReadHistoricTickerStatsAtTimestampResponse) GetReadHistoricTickerStatsA
 	return v.ReadHistoricTickerStatsAtTimestamp
 }
 
+// ReadLastJobSlotResponse is returned by ReadLastJobSlot on success.
+type ReadLastJobSlotResponse struct {
+	// Reads the latest schedule slot recorded for the job, so missed slots can be caught up
+	ReadLastJobSlot *int `json:"readLastJobSlot"`
+}
+
+// GetReadLastJobSlot returns ReadLastJobSlotResponse.ReadLastJobSlot, and is useful for accessing the field via an interface.
+func (v *ReadLastJobSlotResponse) GetReadLastJobSlot() *int { return v.ReadLastJobSlot }
+
 // ReadProjectsFilterReadProjectsFilterProject includes the requested fields of the GraphQL type Project.
 type ReadProjectsFilterReadProjectsFilterProject struct {
 	Id          string                                                 `json:"id"`
@@ -963,7 +1934,7 @@ func (v *ReadProjectsFilterReadProjectsFilterProjectTasksTask) GetId() string {
 
 // ReadProjectsFilterResponse is returned by ReadProjectsFilter on success.
 type ReadProjectsFilterResponse struct {
-	// Get projects filtered by SOP standard operating proceedure
+	// Get projects filtered by SOP standard operating proceedure, or all projects without a filter
 	ReadProjectsFilter []ReadProjectsFilterReadProjectsFilterProject `json:"readProjectsFilter"`
 }
 
@@ -1061,14 +2032,340 @@ func (v *ReadUserByEmailResponse) GetReadUserByEmail() ReadUserByEmailReadUserBy
 	return v.ReadUserByEmail
 }
 
-type TickerStatsInput struct {
-	Symbol            string `json:"Symbol"`
-	PriceChange       string `json:"PriceChange"`
-	PriceChangePct    string `json:"PriceChangePct"`
-	QuoteVolume       string `json:"QuoteVolume"`
-	Volume            string `json:"Volume"`
-	TradeCount        int    `json:"TradeCount"`
-	HighPrice         string `json:"HighPrice"`
+// RecordTopMoversResponse is returned by RecordTopMovers on success.
+type RecordTopMoversResponse struct {
+	// Records a tick's top movers against their Symbol Stats in one bulk write, returning how many were written
+	RecordTopMovers int `json:"recordTopMovers"`
+}
+
+// GetRecordTopMovers returns RecordTopMoversResponse.RecordTopMovers, and is useful for accessing the field via an interface.
+func (v *RecordTopMoversResponse) GetRecordTopMovers() int { return v.RecordTopMovers }
+
+// ResetCalendarFeedResponse is returned by ResetCalendarFeed on success.
+type ResetCalendarFeedResponse struct {
+	// Replace the logged-in user's feed token, so the feed URLs already handed out stop working
+	ResetCalendarFeed bool `json:"resetCalendarFeed"`
+}
+
+// GetResetCalendarFeed returns ResetCalendarFeedResponse.ResetCalendarFeed, and is useful for accessing the field via an interface.
+func (v *ResetCalendarFeedResponse) GetResetCalendarFeed() bool { return v.ResetCalendarFeed }
+
+type StrategyInput struct {
+	BotInstanceName      string            `json:"BotInstanceName"`
+	TradeDuration        int               `json:"TradeDuration"`
+	IncrementsATR        int               `json:"IncrementsATR"`
+	LongSMADuration      int               `json:"LongSMADuration"`
+	ShortSMADuration     int               `json:"ShortSMADuration"`
+	WINCounter           int               `json:"WINCounter"`
+	LOSSCounter          int               `json:"LOSSCounter"`
+	TIMEOUTGainCounter   int               `json:"TIMEOUTGainCounter"`
+	TIMEOUTLossCounter   int               `json:"TIMEOUTLossCounter"`
+	NetGainCounter       int               `json:"NetGainCounter"`
+	NetLossCounter       int               `json:"NetLossCounter"`
+	AccountBalance       float64           `json:"AccountBalance"`
+	MovingAveMomentum    float64           `json:"MovingAveMomentum"`
+	TakeProfitPercentage float64           `json:"TakeProfitPercentage"`
+	StopLossPercentage   float64           `json:"StopLossPercentage"`
+	ATRtollerance        float64           `json:"ATRtollerance"`
+	MinFearGreed         int               `json:"MinFearGreed"`
+	MaxFearGreed         int               `json:"MaxFearGreed"`
+	AllowedSentiments    []string          `json:"AllowedSentiments"`
+	FeesTotal            float64           `json:"FeesTotal"`
+	Tested               bool              `json:"Tested"`
+	Lifecycle            StrategyLifecycle `json:"Lifecycle,omitempty"`
+	VersionID            string            `json:"VersionID"`
+	Owner                string            `json:"Owner"`
+	CreatedOn            int               `json:"CreatedOn"`
+}
+
+// GetBotInstanceName returns StrategyInput.BotInstanceName, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetBotInstanceName() string { return v.BotInstanceName }
+
+// GetTradeDuration returns StrategyInput.TradeDuration, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetTradeDuration() int { return v.TradeDuration }
+
+// GetIncrementsATR returns StrategyInput.IncrementsATR, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetIncrementsATR() int { return v.IncrementsATR }
+
+// GetLongSMADuration returns StrategyInput.LongSMADuration, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetLongSMADuration() int { return v.LongSMADuration }
+
+// GetShortSMADuration returns StrategyInput.ShortSMADuration, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetShortSMADuration() int { return v.ShortSMADuration }
+
+// GetWINCounter returns StrategyInput.WINCounter, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetWINCounter() int { return v.WINCounter }
+
+// GetLOSSCounter returns StrategyInput.LOSSCounter, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetLOSSCounter() int { return v.LOSSCounter }
+
+// GetTIMEOUTGainCounter returns StrategyInput.TIMEOUTGainCounter, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetTIMEOUTGainCounter() int { return v.TIMEOUTGainCounter }
+
+// GetTIMEOUTLossCounter returns StrategyInput.TIMEOUTLossCounter, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetTIMEOUTLossCounter() int { return v.TIMEOUTLossCounter }
+
+// GetNetGainCounter returns StrategyInput.NetGainCounter, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetNetGainCounter() int { return v.NetGainCounter }
+
+// GetNetLossCounter returns StrategyInput.NetLossCounter, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetNetLossCounter() int { return v.NetLossCounter }
+
+// GetAccountBalance returns StrategyInput.AccountBalance, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetAccountBalance() float64 { return v.AccountBalance }
+
+// GetMovingAveMomentum returns StrategyInput.MovingAveMomentum, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetMovingAveMomentum() float64 { return v.MovingAveMomentum }
+
+// GetTakeProfitPercentage returns StrategyInput.TakeProfitPercentage, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetTakeProfitPercentage() float64 { return v.TakeProfitPercentage }
+
+// GetStopLossPercentage returns StrategyInput.StopLossPercentage, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetStopLossPercentage() float64 { return v.StopLossPercentage }
+
+// GetATRtollerance returns StrategyInput.ATRtollerance, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetATRtollerance() float64 { return v.ATRtollerance }
+
+// GetMinFearGreed returns StrategyInput.MinFearGreed, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetMinFearGreed() int { return v.MinFearGreed }
+
+// GetMaxFearGreed returns StrategyInput.MaxFearGreed, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetMaxFearGreed() int { return v.MaxFearGreed }
+
+// GetAllowedSentiments returns StrategyInput.AllowedSentiments, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetAllowedSentiments() []string { return v.AllowedSentiments }
+
+// GetFeesTotal returns StrategyInput.FeesTotal, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetFeesTotal() float64 { return v.FeesTotal }
+
+// GetTested returns StrategyInput.Tested, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetTested() bool { return v.Tested }
+
+// GetLifecycle returns StrategyInput.Lifecycle, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetLifecycle() StrategyLifecycle { return v.Lifecycle }
+
+// GetVersionID returns StrategyInput.VersionID, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetVersionID() string { return v.VersionID }
+
+// GetOwner returns StrategyInput.Owner, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetOwner() string { return v.Owner }
+
+// GetCreatedOn returns StrategyInput.CreatedOn, and is useful for accessing the field via an interface.
+func (v *StrategyInput) GetCreatedOn() int { return v.CreatedOn }
+
+type StrategyLifecycle string
+
+const (
+	StrategyLifecycleDraft       StrategyLifecycle = "DRAFT"
+	StrategyLifecycleBacktesting StrategyLifecycle = "BACKTESTING"
+	StrategyLifecycleValidated   StrategyLifecycle = "VALIDATED"
+	StrategyLifecyclePaper       StrategyLifecycle = "PAPER"
+	StrategyLifecycleLive        StrategyLifecycle = "LIVE"
+	StrategyLifecyclePaused      StrategyLifecycle = "PAUSED"
+	StrategyLifecycleRetired     StrategyLifecycle = "RETIRED"
+)
+
+var AllStrategyLifecycle = []StrategyLifecycle{
+	StrategyLifecycleDraft,
+	StrategyLifecycleBacktesting,
+	StrategyLifecycleValidated,
+	StrategyLifecyclePaper,
+	StrategyLifecycleLive,
+	StrategyLifecyclePaused,
+	StrategyLifecycleRetired,
+}
+
+type StrategySweepInput struct {
+	Sampling        string             `json:"Sampling"`
+	RankedBy        string             `json:"RankedBy"`
+	From            int                `json:"From"`
+	To              int                `json:"To"`
+	Permutations    int                `json:"Permutations"`
+	StartingBalance float64            `json:"StartingBalance"`
+	CreatedOn       int                `json:"CreatedOn"`
+	Results         []SweepResultInput `json:"Results"`
+}
+
+// GetSampling returns StrategySweepInput.Sampling, and is useful for accessing the field via an interface.
+func (v *StrategySweepInput) GetSampling() string { return v.Sampling }
+
+// GetRankedBy returns StrategySweepInput.RankedBy, and is useful for accessing the field via an interface.
+func (v *StrategySweepInput) GetRankedBy() string { return v.RankedBy }
+
+// GetFrom returns StrategySweepInput.From, and is useful for accessing the field via an interface.
+func (v *StrategySweepInput) GetFrom() int { return v.From }
+
+// GetTo returns StrategySweepInput.To, and is useful for accessing the field via an interface.
+func (v *StrategySweepInput) GetTo() int { return v.To }
+
+// GetPermutations returns StrategySweepInput.Permutations, and is useful for accessing the field via an interface.
+func (v *StrategySweepInput) GetPermutations() int { return v.Permutations }
+
+// GetStartingBalance returns StrategySweepInput.StartingBalance, and is useful for accessing the field via an interface.
+func (v *StrategySweepInput) GetStartingBalance() float64 { return v.StartingBalance }
+
+// GetCreatedOn returns StrategySweepInput.CreatedOn, and is useful for accessing the field via an interface.
+func (v *StrategySweepInput) GetCreatedOn() int { return v.CreatedOn }
+
+// GetResults returns StrategySweepInput.Results, and is useful for accessing the field via an interface.
+func (v *StrategySweepInput) GetResults() []SweepResultInput { return v.Results }
+
+type SweepResultInput struct {
+	Rank                 int     `json:"Rank"`
+	BotInstanceName      string  `json:"BotInstanceName"`
+	TradeDuration        int     `json:"TradeDuration"`
+	IncrementsATR        int     `json:"IncrementsATR"`
+	LongSMADuration      int     `json:"LongSMADuration"`
+	ShortSMADuration     int     `json:"ShortSMADuration"`
+	MovingAveMomentum    float64 `json:"MovingAveMomentum"`
+	TakeProfitPercentage float64 `json:"TakeProfitPercentage"`
+	StopLossPercentage   float64 `json:"StopLossPercentage"`
+	ATRtollerance        float64 `json:"ATRtollerance"`
+	Trades               int     `json:"Trades"`
+	NetPnL               float64 `json:"NetPnL"`
+	WinRate              float64 `json:"WinRate"`
+	MaxDrawdown          float64 `json:"MaxDrawdown"`
+	Sharpe               float64 `json:"Sharpe"`
+}
+
+// GetRank returns SweepResultInput.Rank, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetRank() int { return v.Rank }
+
+// GetBotInstanceName returns SweepResultInput.BotInstanceName, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetBotInstanceName() string { return v.BotInstanceName }
+
+// GetTradeDuration returns SweepResultInput.TradeDuration, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetTradeDuration() int { return v.TradeDuration }
+
+// GetIncrementsATR returns SweepResultInput.IncrementsATR, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetIncrementsATR() int { return v.IncrementsATR }
+
+// GetLongSMADuration returns SweepResultInput.LongSMADuration, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetLongSMADuration() int { return v.LongSMADuration }
+
+// GetShortSMADuration returns SweepResultInput.ShortSMADuration, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetShortSMADuration() int { return v.ShortSMADuration }
+
+// GetMovingAveMomentum returns SweepResultInput.MovingAveMomentum, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetMovingAveMomentum() float64 { return v.MovingAveMomentum }
+
+// GetTakeProfitPercentage returns SweepResultInput.TakeProfitPercentage, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetTakeProfitPercentage() float64 { return v.TakeProfitPercentage }
+
+// GetStopLossPercentage returns SweepResultInput.StopLossPercentage, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetStopLossPercentage() float64 { return v.StopLossPercentage }
+
+// GetATRtollerance returns SweepResultInput.ATRtollerance, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetATRtollerance() float64 { return v.ATRtollerance }
+
+// GetTrades returns SweepResultInput.Trades, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetTrades() int { return v.Trades }
+
+// GetNetPnL returns SweepResultInput.NetPnL, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetNetPnL() float64 { return v.NetPnL }
+
+// GetWinRate returns SweepResultInput.WinRate, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetWinRate() float64 { return v.WinRate }
+
+// GetMaxDrawdown returns SweepResultInput.MaxDrawdown, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetMaxDrawdown() float64 { return v.MaxDrawdown }
+
+// GetSharpe returns SweepResultInput.Sharpe, and is useful for accessing the field via an interface.
+func (v *SweepResultInput) GetSharpe() float64 { return v.Sharpe }
+
+// TaskGraphResponse is returned by TaskGraph on success.
+type TaskGraphResponse struct {
+	// Get the tasks linked to a task through subtasks and blockers, up to depth links away
+	TaskGraph *TaskGraphTaskGraph `json:"taskGraph"`
+}
+
+// GetTaskGraph returns TaskGraphResponse.TaskGraph, and is useful for accessing the field via an interface.
+func (v *TaskGraphResponse) GetTaskGraph() *TaskGraphTaskGraph { return v.TaskGraph }
+
+// TaskGraphTaskGraph includes the requested fields of the GraphQL type TaskGraph.
+type TaskGraphTaskGraph struct {
+	Root  TaskGraphTaskGraphRootTask        `json:"root"`
+	Tasks []TaskGraphTaskGraphTasksTask     `json:"tasks"`
+	Links []TaskGraphTaskGraphLinksTaskLink `json:"links"`
+}
+
+// GetRoot returns TaskGraphTaskGraph.Root, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraph) GetRoot() TaskGraphTaskGraphRootTask { return v.Root }
+
+// GetTasks returns TaskGraphTaskGraph.Tasks, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraph) GetTasks() []TaskGraphTaskGraphTasksTask { return v.Tasks }
+
+// GetLinks returns TaskGraphTaskGraph.Links, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraph) GetLinks() []TaskGraphTaskGraphLinksTaskLink { return v.Links }
+
+// TaskGraphTaskGraphLinksTaskLink includes the requested fields of the GraphQL type TaskLink.
+type TaskGraphTaskGraphLinksTaskLink struct {
+	From string       `json:"from"`
+	To   string       `json:"to"`
+	Kind TaskLinkKind `json:"kind"`
+}
+
+// GetFrom returns TaskGraphTaskGraphLinksTaskLink.From, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphLinksTaskLink) GetFrom() string { return v.From }
+
+// GetTo returns TaskGraphTaskGraphLinksTaskLink.To, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphLinksTaskLink) GetTo() string { return v.To }
+
+// GetKind returns TaskGraphTaskGraphLinksTaskLink.Kind, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphLinksTaskLink) GetKind() TaskLinkKind { return v.Kind }
+
+// TaskGraphTaskGraphRootTask includes the requested fields of the GraphQL type Task.
+type TaskGraphTaskGraphRootTask struct {
+	Id string `json:"id"`
+}
+
+// GetId returns TaskGraphTaskGraphRootTask.Id, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphRootTask) GetId() string { return v.Id }
+
+// TaskGraphTaskGraphTasksTask includes the requested fields of the GraphQL type Task.
+type TaskGraphTaskGraphTasksTask struct {
+	Id        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	ParentId  string   `json:"parentId"`
+	BlockedBy []string `json:"blockedBy"`
+}
+
+// GetId returns TaskGraphTaskGraphTasksTask.Id, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphTasksTask) GetId() string { return v.Id }
+
+// GetTitle returns TaskGraphTaskGraphTasksTask.Title, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphTasksTask) GetTitle() string { return v.Title }
+
+// GetStatus returns TaskGraphTaskGraphTasksTask.Status, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphTasksTask) GetStatus() string { return v.Status }
+
+// GetParentId returns TaskGraphTaskGraphTasksTask.ParentId, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphTasksTask) GetParentId() string { return v.ParentId }
+
+// GetBlockedBy returns TaskGraphTaskGraphTasksTask.BlockedBy, and is useful for accessing the field via an interface.
+func (v *TaskGraphTaskGraphTasksTask) GetBlockedBy() []string { return v.BlockedBy }
+
+type TaskLinkKind string
+
+const (
+	TaskLinkKindSubtask TaskLinkKind = "SUBTASK"
+	TaskLinkKindBlocks  TaskLinkKind = "BLOCKS"
+)
+
+var AllTaskLinkKind = []TaskLinkKind{
+	TaskLinkKindSubtask,
+	TaskLinkKindBlocks,
+}
+
+type TickerStatsInput struct {
+	Symbol            string `json:"Symbol"`
+	PriceChange       string `json:"PriceChange"`
+	PriceChangePct    string `json:"PriceChangePct"`
+	QuoteVolume       string `json:"QuoteVolume"`
+	Volume            string `json:"Volume"`
+	TradeCount        int    `json:"TradeCount"`
+	HighPrice         string `json:"HighPrice"`
 	LowPrice          string `json:"LowPrice"`
 	LastPrice         string `json:"LastPrice"`
 	LiquidityEstimate string `json:"LiquidityEstimate"`
@@ -1104,6 +2401,161 @@ func (v *TickerStatsInput) GetLastPrice() string { return v.LastPrice }
 // GetLiquidityEstimate returns TickerStatsInput.LiquidityEstimate, and is useful for accessing the field via an interface.
 func (v *TickerStatsInput) GetLiquidityEstimate() string { return v.LiquidityEstimate }
 
+// TimeReportResponse is returned by TimeReport on success.
+type TimeReportResponse struct {
+	// Get the time tracked between two dates, RFC 3339 or YYYY-MM-DD; a date alone for to includes the whole day
+	TimeReport TimeReportTimeReport `json:"timeReport"`
+}
+
+// GetTimeReport returns TimeReportResponse.TimeReport, and is useful for accessing the field via an interface.
+func (v *TimeReportResponse) GetTimeReport() TimeReportTimeReport { return v.TimeReport }
+
+// TimeReportTimeReport includes the requested fields of the GraphQL type TimeReport.
+type TimeReportTimeReport struct {
+	From         string                                      `json:"from"`
+	To           string                                      `json:"to"`
+	TotalMinutes int                                         `json:"totalMinutes"`
+	ByDepartment []TimeReportTimeReportByDepartmentTimeTotal `json:"byDepartment"`
+	ByAssignee   []TimeReportTimeReportByAssigneeTimeTotal   `json:"byAssignee"`
+	ByUser       []TimeReportTimeReportByUserTimeTotal       `json:"byUser"`
+	ByProject    []TimeReportTimeReportByProjectTimeTotal    `json:"byProject"`
+}
+
+// GetFrom returns TimeReportTimeReport.From, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReport) GetFrom() string { return v.From }
+
+// GetTo returns TimeReportTimeReport.To, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReport) GetTo() string { return v.To }
+
+// GetTotalMinutes returns TimeReportTimeReport.TotalMinutes, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReport) GetTotalMinutes() int { return v.TotalMinutes }
+
+// GetByDepartment returns TimeReportTimeReport.ByDepartment, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReport) GetByDepartment() []TimeReportTimeReportByDepartmentTimeTotal {
+	return v.ByDepartment
+}
+
+// GetByAssignee returns TimeReportTimeReport.ByAssignee, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReport) GetByAssignee() []TimeReportTimeReportByAssigneeTimeTotal {
+	return v.ByAssignee
+}
+
+// GetByUser returns TimeReportTimeReport.ByUser, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReport) GetByUser() []TimeReportTimeReportByUserTimeTotal { return v.ByUser }
+
+// GetByProject returns TimeReportTimeReport.ByProject, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReport) GetByProject() []TimeReportTimeReportByProjectTimeTotal {
+	return v.ByProject
+}
+
+// TimeReportTimeReportByAssigneeTimeTotal includes the requested fields of the GraphQL type TimeTotal.
+type TimeReportTimeReportByAssigneeTimeTotal struct {
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+// GetKey returns TimeReportTimeReportByAssigneeTimeTotal.Key, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByAssigneeTimeTotal) GetKey() string { return v.Key }
+
+// GetName returns TimeReportTimeReportByAssigneeTimeTotal.Name, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByAssigneeTimeTotal) GetName() string { return v.Name }
+
+// GetMinutes returns TimeReportTimeReportByAssigneeTimeTotal.Minutes, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByAssigneeTimeTotal) GetMinutes() int { return v.Minutes }
+
+// TimeReportTimeReportByDepartmentTimeTotal includes the requested fields of the GraphQL type TimeTotal.
+type TimeReportTimeReportByDepartmentTimeTotal struct {
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+// GetKey returns TimeReportTimeReportByDepartmentTimeTotal.Key, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByDepartmentTimeTotal) GetKey() string { return v.Key }
+
+// GetName returns TimeReportTimeReportByDepartmentTimeTotal.Name, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByDepartmentTimeTotal) GetName() string { return v.Name }
+
+// GetMinutes returns TimeReportTimeReportByDepartmentTimeTotal.Minutes, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByDepartmentTimeTotal) GetMinutes() int { return v.Minutes }
+
+// TimeReportTimeReportByProjectTimeTotal includes the requested fields of the GraphQL type TimeTotal.
+type TimeReportTimeReportByProjectTimeTotal struct {
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+// GetKey returns TimeReportTimeReportByProjectTimeTotal.Key, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByProjectTimeTotal) GetKey() string { return v.Key }
+
+// GetName returns TimeReportTimeReportByProjectTimeTotal.Name, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByProjectTimeTotal) GetName() string { return v.Name }
+
+// GetMinutes returns TimeReportTimeReportByProjectTimeTotal.Minutes, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByProjectTimeTotal) GetMinutes() int { return v.Minutes }
+
+// TimeReportTimeReportByUserTimeTotal includes the requested fields of the GraphQL type TimeTotal.
+type TimeReportTimeReportByUserTimeTotal struct {
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+// GetKey returns TimeReportTimeReportByUserTimeTotal.Key, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByUserTimeTotal) GetKey() string { return v.Key }
+
+// GetName returns TimeReportTimeReportByUserTimeTotal.Name, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByUserTimeTotal) GetName() string { return v.Name }
+
+// GetMinutes returns TimeReportTimeReportByUserTimeTotal.Minutes, and is useful for accessing the field via an interface.
+func (v *TimeReportTimeReportByUserTimeTotal) GetMinutes() int { return v.Minutes }
+
+type TopMoverInput struct {
+	Symbol   string  `json:"Symbol"`
+	Position int     `json:"Position"`
+	Gain     float64 `json:"Gain"`
+}
+
+// GetSymbol returns TopMoverInput.Symbol, and is useful for accessing the field via an interface.
+func (v *TopMoverInput) GetSymbol() string { return v.Symbol }
+
+// GetPosition returns TopMoverInput.Position, and is useful for accessing the field via an interface.
+func (v *TopMoverInput) GetPosition() int { return v.Position }
+
+// GetGain returns TopMoverInput.Gain, and is useful for accessing the field via an interface.
+func (v *TopMoverInput) GetGain() float64 { return v.Gain }
+
+// TriggerJobResponse is returned by TriggerJob on success.
+type TriggerJobResponse struct {
+	// Queues a manual run of the job for the scheduler to pick up, or returns the run already queued
+	TriggerJob TriggerJobTriggerJobJobRun `json:"triggerJob"`
+}
+
+// GetTriggerJob returns TriggerJobResponse.TriggerJob, and is useful for accessing the field via an interface.
+func (v *TriggerJobResponse) GetTriggerJob() TriggerJobTriggerJobJobRun { return v.TriggerJob }
+
+// TriggerJobTriggerJobJobRun includes the requested fields of the GraphQL type JobRun.
+type TriggerJobTriggerJobJobRun struct {
+	RunID    string    `json:"RunID"`
+	Job      string    `json:"Job"`
+	Status   JobStatus `json:"Status"`
+	QueuedAt int       `json:"QueuedAt"`
+}
+
+// GetRunID returns TriggerJobTriggerJobJobRun.RunID, and is useful for accessing the field via an interface.
+func (v *TriggerJobTriggerJobJobRun) GetRunID() string { return v.RunID }
+
+// GetJob returns TriggerJobTriggerJobJobRun.Job, and is useful for accessing the field via an interface.
+func (v *TriggerJobTriggerJobJobRun) GetJob() string { return v.Job }
+
+// GetStatus returns TriggerJobTriggerJobJobRun.Status, and is useful for accessing the field via an interface.
+func (v *TriggerJobTriggerJobJobRun) GetStatus() JobStatus { return v.Status }
+
+// GetQueuedAt returns TriggerJobTriggerJobJobRun.QueuedAt, and is useful for accessing the field via an interface.
+func (v *TriggerJobTriggerJobJobRun) GetQueuedAt() int { return v.QueuedAt }
+
 type UpdateCountersInput struct {
 	BotInstanceName    string  `json:"BotInstanceName"`
 	WINCounter         bool    `json:"WINCounter"`
@@ -1152,6 +2604,206 @@ type UpdateCountersResponse struct {
 // GetUpdateCounters returns UpdateCountersResponse.UpdateCounters, and is useful for accessing the field via an interface.
 func (v *UpdateCountersResponse) GetUpdateCounters() bool { return v.UpdateCounters }
 
+type UpdateLifecycleInput struct {
+	BotInstanceName string            `json:"BotInstanceName"`
+	Lifecycle       StrategyLifecycle `json:"Lifecycle"`
+}
+
+// GetBotInstanceName returns UpdateLifecycleInput.BotInstanceName, and is useful for accessing the field via an interface.
+func (v *UpdateLifecycleInput) GetBotInstanceName() string { return v.BotInstanceName }
+
+// GetLifecycle returns UpdateLifecycleInput.Lifecycle, and is useful for accessing the field via an interface.
+func (v *UpdateLifecycleInput) GetLifecycle() StrategyLifecycle { return v.Lifecycle }
+
+type UpdateProjectInput struct {
+	Id          string   `json:"id"`
+	Title       *string  `json:"title"`
+	Sop         *bool    `json:"sop"`
+	Description *string  `json:"description"`
+	Labels      []string `json:"labels"`
+	AssignedTo  *string  `json:"assignedTo"`
+	DueDate     *string  `json:"dueDate"`
+	Status      *string  `json:"status"`
+}
+
+// GetId returns UpdateProjectInput.Id, and is useful for accessing the field via an interface.
+func (v *UpdateProjectInput) GetId() string { return v.Id }
+
+// GetTitle returns UpdateProjectInput.Title, and is useful for accessing the field via an interface.
+func (v *UpdateProjectInput) GetTitle() *string { return v.Title }
+
+// GetSop returns UpdateProjectInput.Sop, and is useful for accessing the field via an interface.
+func (v *UpdateProjectInput) GetSop() *bool { return v.Sop }
+
+// GetDescription returns UpdateProjectInput.Description, and is useful for accessing the field via an interface.
+func (v *UpdateProjectInput) GetDescription() *string { return v.Description }
+
+// GetLabels returns UpdateProjectInput.Labels, and is useful for accessing the field via an interface.
+func (v *UpdateProjectInput) GetLabels() []string { return v.Labels }
+
+// GetAssignedTo returns UpdateProjectInput.AssignedTo, and is useful for accessing the field via an interface.
+func (v *UpdateProjectInput) GetAssignedTo() *string { return v.AssignedTo }
+
+// GetDueDate returns UpdateProjectInput.DueDate, and is useful for accessing the field via an interface.
+func (v *UpdateProjectInput) GetDueDate() *string { return v.DueDate }
+
+// GetStatus returns UpdateProjectInput.Status, and is useful for accessing the field via an interface.
+func (v *UpdateProjectInput) GetStatus() *string { return v.Status }
+
+// UpdateProjectResponse is returned by UpdateProject on success.
+type UpdateProjectResponse struct {
+	// Update an existing project
+	UpdateProject UpdateProjectUpdateProject `json:"updateProject"`
+}
+
+// GetUpdateProject returns UpdateProjectResponse.UpdateProject, and is useful for accessing the field via an interface.
+func (v *UpdateProjectResponse) GetUpdateProject() UpdateProjectUpdateProject { return v.UpdateProject }
+
+// UpdateProjectUpdateProject includes the requested fields of the GraphQL type Project.
+type UpdateProjectUpdateProject struct {
+	Id        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// GetId returns UpdateProjectUpdateProject.Id, and is useful for accessing the field via an interface.
+func (v *UpdateProjectUpdateProject) GetId() string { return v.Id }
+
+// GetTitle returns UpdateProjectUpdateProject.Title, and is useful for accessing the field via an interface.
+func (v *UpdateProjectUpdateProject) GetTitle() string { return v.Title }
+
+// GetStatus returns UpdateProjectUpdateProject.Status, and is useful for accessing the field via an interface.
+func (v *UpdateProjectUpdateProject) GetStatus() string { return v.Status }
+
+// GetUpdatedAt returns UpdateProjectUpdateProject.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *UpdateProjectUpdateProject) GetUpdatedAt() string { return v.UpdatedAt }
+
+// UpdateStrategyLifecycleResponse is returned by UpdateStrategyLifecycle on success.
+type UpdateStrategyLifecycleResponse struct {
+	// Moves the strategy to a new lifecycle state if the transition is allowed, keeping Tested in step
+	UpdateStrategyLifecycle UpdateStrategyLifecycleUpdateStrategyLifecycleStrategy `json:"updateStrategyLifecycle"`
+}
+
+// GetUpdateStrategyLifecycle returns UpdateStrategyLifecycleResponse.UpdateStrategyLifecycle, and is useful for accessing the field via an interface.
+func (v *UpdateStrategyLifecycleResponse) GetUpdateStrategyLifecycle() UpdateStrategyLifecycleUpdateStrategyLifecycleStrategy {
+	return v.UpdateStrategyLifecycle
+}
+
+// UpdateStrategyLifecycleUpdateStrategyLifecycleStrategy includes the requested fields of the GraphQL type Strategy.
+type UpdateStrategyLifecycleUpdateStrategyLifecycleStrategy struct {
+	BotInstanceName string            `json:"BotInstanceName"`
+	Lifecycle       StrategyLifecycle `json:"Lifecycle"`
+}
+
+// GetBotInstanceName returns UpdateStrategyLifecycleUpdateStrategyLifecycleStrategy.BotInstanceName, and is useful for accessing the field via an interface.
+func (v *UpdateStrategyLifecycleUpdateStrategyLifecycleStrategy) GetBotInstanceName() string {
+	return v.BotInstanceName
+}
+
+// GetLifecycle returns UpdateStrategyLifecycleUpdateStrategyLifecycleStrategy.Lifecycle, and is useful for accessing the field via an interface.
+func (v *UpdateStrategyLifecycleUpdateStrategyLifecycleStrategy) GetLifecycle() StrategyLifecycle {
+	return v.Lifecycle
+}
+
+type UpdateTaskInput struct {
+	Id          string   `json:"id"`
+	Title       *string  `json:"title"`
+	Description *string  `json:"description"`
+	Status      *string  `json:"status"`
+	Labels      []string `json:"labels"`
+	AssignedTo  *string  `json:"assignedTo"`
+	DueDate     *string  `json:"dueDate"`
+	DeferDate   *string  `json:"deferDate"`
+	Department  *string  `json:"department"`
+	ProjectId   *string  `json:"projectId"`
+	Duration    *int     `json:"duration"`
+	Estimate    *int     `json:"estimate"`
+	ParentId    *string  `json:"parentId"`
+	BlockedBy   []string `json:"blockedBy"`
+	Recurrence  *string  `json:"recurrence"`
+	Imported    bool     `json:"imported"`
+}
+
+// GetId returns UpdateTaskInput.Id, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetId() string { return v.Id }
+
+// GetTitle returns UpdateTaskInput.Title, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetTitle() *string { return v.Title }
+
+// GetDescription returns UpdateTaskInput.Description, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetDescription() *string { return v.Description }
+
+// GetStatus returns UpdateTaskInput.Status, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetStatus() *string { return v.Status }
+
+// GetLabels returns UpdateTaskInput.Labels, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetLabels() []string { return v.Labels }
+
+// GetAssignedTo returns UpdateTaskInput.AssignedTo, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetAssignedTo() *string { return v.AssignedTo }
+
+// GetDueDate returns UpdateTaskInput.DueDate, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetDueDate() *string { return v.DueDate }
+
+// GetDeferDate returns UpdateTaskInput.DeferDate, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetDeferDate() *string { return v.DeferDate }
+
+// GetDepartment returns UpdateTaskInput.Department, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetDepartment() *string { return v.Department }
+
+// GetProjectId returns UpdateTaskInput.ProjectId, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetProjectId() *string { return v.ProjectId }
+
+// GetDuration returns UpdateTaskInput.Duration, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetDuration() *int { return v.Duration }
+
+// GetEstimate returns UpdateTaskInput.Estimate, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetEstimate() *int { return v.Estimate }
+
+// GetParentId returns UpdateTaskInput.ParentId, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetParentId() *string { return v.ParentId }
+
+// GetBlockedBy returns UpdateTaskInput.BlockedBy, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetBlockedBy() []string { return v.BlockedBy }
+
+// GetRecurrence returns UpdateTaskInput.Recurrence, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetRecurrence() *string { return v.Recurrence }
+
+// GetImported returns UpdateTaskInput.Imported, and is useful for accessing the field via an interface.
+func (v *UpdateTaskInput) GetImported() bool { return v.Imported }
+
+// UpdateTaskResponse is returned by UpdateTask on success.
+type UpdateTaskResponse struct {
+	// Update an existing task. Status changes must follow the workflow in
+	// taskStatuses, and a task cannot become a nextAction while its blockers are
+	// open. Completing a recurring task spawns its next occurrence.
+	UpdateTask UpdateTaskUpdateTask `json:"updateTask"`
+}
+
+// GetUpdateTask returns UpdateTaskResponse.UpdateTask, and is useful for accessing the field via an interface.
+func (v *UpdateTaskResponse) GetUpdateTask() UpdateTaskUpdateTask { return v.UpdateTask }
+
+// UpdateTaskUpdateTask includes the requested fields of the GraphQL type Task.
+type UpdateTaskUpdateTask struct {
+	Id        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// GetId returns UpdateTaskUpdateTask.Id, and is useful for accessing the field via an interface.
+func (v *UpdateTaskUpdateTask) GetId() string { return v.Id }
+
+// GetTitle returns UpdateTaskUpdateTask.Title, and is useful for accessing the field via an interface.
+func (v *UpdateTaskUpdateTask) GetTitle() string { return v.Title }
+
+// GetStatus returns UpdateTaskUpdateTask.Status, and is useful for accessing the field via an interface.
+func (v *UpdateTaskUpdateTask) GetStatus() string { return v.Status }
+
+// GetUpdatedAt returns UpdateTaskUpdateTask.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *UpdateTaskUpdateTask) GetUpdatedAt() string { return v.UpdatedAt }
+
 // UpsertFearAndGreedIndexResponse is returned by UpsertFearAndGreedIndex on success.
 type UpsertFearAndGreedIndexResponse struct {
 	// Creates or updates the index value for a specific timestamp
@@ -1218,31 +2870,75 @@ func (v *UpsertLiquidityEstimateUpsertSymbolStats) GetMinLiquidityEstimate() flo
 	return v.MinLiquidityEstimate
 }
 
-// UpsertPositionCountsResponse is returned by UpsertPositionCounts on success.
-type UpsertPositionCountsResponse struct {
-	// If the symbol exists, update it. If not, create it
-	UpsertSymbolStats UpsertPositionCountsUpsertSymbolStats `json:"upsertSymbolStats"`
+// WorkloadResponse is returned by Workload on success.
+type WorkloadResponse struct {
+	// Get the open estimated effort of each assignee, against due dates up to days ahead
+	Workload []WorkloadWorkloadAssigneeWorkload `json:"workload"`
 }
 
-// GetUpsertSymbolStats returns UpsertPositionCountsResponse.UpsertSymbolStats, and is useful for accessing the field via an interface.
-func (v *UpsertPositionCountsResponse) GetUpsertSymbolStats() UpsertPositionCountsUpsertSymbolStats {
-	return v.UpsertSymbolStats
+// GetWorkload returns WorkloadResponse.Workload, and is useful for accessing the field via an interface.
+func (v *WorkloadResponse) GetWorkload() []WorkloadWorkloadAssigneeWorkload { return v.Workload }
+
+// WorkloadWorkloadAssigneeWorkload includes the requested fields of the GraphQL type AssigneeWorkload.
+type WorkloadWorkloadAssigneeWorkload struct {
+	AssignedTo       string `json:"assignedTo"`
+	OpenTasks        int    `json:"openTasks"`
+	UnestimatedTasks int    `json:"unestimatedTasks"`
+	RemainingMinutes int    `json:"remainingMinutes"`
+	OverdueMinutes   int    `json:"overdueMinutes"`
+	DueSoonMinutes   int    `json:"dueSoonMinutes"`
+	LaterMinutes     int    `json:"laterMinutes"`
+	NextDueDate      string `json:"nextDueDate"`
 }
 
-// UpsertPositionCountsUpsertSymbolStats includes the requested fields of the GraphQL type SymbolStats.
-type UpsertPositionCountsUpsertSymbolStats struct {
-	Symbol         string       `json:"Symbol"`
-	PositionCounts []model.Mean `json:"PositionCounts"`
+// GetAssignedTo returns WorkloadWorkloadAssigneeWorkload.AssignedTo, and is useful for accessing the field via an interface.
+func (v *WorkloadWorkloadAssigneeWorkload) GetAssignedTo() string { return v.AssignedTo }
+
+// GetOpenTasks returns WorkloadWorkloadAssigneeWorkload.OpenTasks, and is useful for accessing the field via an interface.
+func (v *WorkloadWorkloadAssigneeWorkload) GetOpenTasks() int { return v.OpenTasks }
+
+// GetUnestimatedTasks returns WorkloadWorkloadAssigneeWorkload.UnestimatedTasks, and is useful for accessing the field via an interface.
+func (v *WorkloadWorkloadAssigneeWorkload) GetUnestimatedTasks() int { return v.UnestimatedTasks }
+
+// GetRemainingMinutes returns WorkloadWorkloadAssigneeWorkload.RemainingMinutes, and is useful for accessing the field via an interface.
+func (v *WorkloadWorkloadAssigneeWorkload) GetRemainingMinutes() int { return v.RemainingMinutes }
+
+// GetOverdueMinutes returns WorkloadWorkloadAssigneeWorkload.OverdueMinutes, and is useful for accessing the field via an interface.
+func (v *WorkloadWorkloadAssigneeWorkload) GetOverdueMinutes() int { return v.OverdueMinutes }
+
+// GetDueSoonMinutes returns WorkloadWorkloadAssigneeWorkload.DueSoonMinutes, and is useful for accessing the field via an interface.
+func (v *WorkloadWorkloadAssigneeWorkload) GetDueSoonMinutes() int { return v.DueSoonMinutes }
+
+// GetLaterMinutes returns WorkloadWorkloadAssigneeWorkload.LaterMinutes, and is useful for accessing the field via an interface.
+func (v *WorkloadWorkloadAssigneeWorkload) GetLaterMinutes() int { return v.LaterMinutes }
+
+// GetNextDueDate returns WorkloadWorkloadAssigneeWorkload.NextDueDate, and is useful for accessing the field via an interface.
+func (v *WorkloadWorkloadAssigneeWorkload) GetNextDueDate() string { return v.NextDueDate }
+
+// __CalendarFeedInput is used internally by genqlient
+type __CalendarFeedInput struct {
+	Labels        []string `json:"labels"`
+	Departments   []string `json:"departments"`
+	TasksAsEvents *bool    `json:"tasksAsEvents"`
 }
 
-// GetSymbol returns UpsertPositionCountsUpsertSymbolStats.Symbol, and is useful for accessing the field via an interface.
-func (v *UpsertPositionCountsUpsertSymbolStats) GetSymbol() string { return v.Symbol }
+// GetLabels returns __CalendarFeedInput.Labels, and is useful for accessing the field via an interface.
+func (v *__CalendarFeedInput) GetLabels() []string { return v.Labels }
 
-// GetPositionCounts returns UpsertPositionCountsUpsertSymbolStats.PositionCounts, and is useful for accessing the field via an interface.
-func (v *UpsertPositionCountsUpsertSymbolStats) GetPositionCounts() []model.Mean {
-	return v.PositionCounts
+// GetDepartments returns __CalendarFeedInput.Departments, and is useful for accessing the field via an interface.
+func (v *__CalendarFeedInput) GetDepartments() []string { return v.Departments }
+
+// GetTasksAsEvents returns __CalendarFeedInput.TasksAsEvents, and is useful for accessing the field via an interface.
+func (v *__CalendarFeedInput) GetTasksAsEvents() *bool { return v.TasksAsEvents }
+
+// __ClaimJobRunInput is used internally by genqlient
+type __ClaimJobRunInput struct {
+	Job string `json:"Job"`
 }
 
+// GetJob returns __ClaimJobRunInput.Job, and is useful for accessing the field via an interface.
+func (v *__ClaimJobRunInput) GetJob() string { return v.Job }
+
 // __CreateActivityReportInput is used internally by genqlient
 type __CreateActivityReportInput struct {
 	TimeStamp      int     `json:"timeStamp"`
@@ -1252,6 +2948,8 @@ type __CreateActivityReportInput struct {
 	TopBGain       float64 `json:"topBGain"`
 	TopCGain       float64 `json:"topCGain"`
 	FearGreedIndex int     `json:"fearGreedIndex"`
+	Breadth        float64 `json:"breadth"`
+	MarketStatus   string  `json:"marketStatus"`
 }
 
 // GetTimeStamp returns __CreateActivityReportInput.TimeStamp, and is useful for accessing the field via an interface.
@@ -1275,6 +2973,20 @@ func (v *__CreateActivityReportInput) GetTopCGain() float64 { return v.TopCGain
 // GetFearGreedIndex returns __CreateActivityReportInput.FearGreedIndex, and is useful for accessing the field via an interface.
 func (v *__CreateActivityReportInput) GetFearGreedIndex() int { return v.FearGreedIndex }
 
+// GetBreadth returns __CreateActivityReportInput.Breadth, and is useful for accessing the field via an interface.
+func (v *__CreateActivityReportInput) GetBreadth() float64 { return v.Breadth }
+
+// GetMarketStatus returns __CreateActivityReportInput.MarketStatus, and is useful for accessing the field via an interface.
+func (v *__CreateActivityReportInput) GetMarketStatus() string { return v.MarketStatus }
+
+// __CreateBacktestRunInput is used internally by genqlient
+type __CreateBacktestRunInput struct {
+	Input BacktestRunInput `json:"input"`
+}
+
+// GetInput returns __CreateBacktestRunInput.Input, and is useful for accessing the field via an interface.
+func (v *__CreateBacktestRunInput) GetInput() BacktestRunInput { return v.Input }
+
 // __CreateHistoricPricesInput is used internally by genqlient
 type __CreateHistoricPricesInput struct {
 	Input NewHistoricPriceInput `json:"input"`
@@ -1291,6 +3003,22 @@ type __CreateHistoricTickerStatsInput struct {
 // GetInput returns __CreateHistoricTickerStatsInput.Input, and is useful for accessing the field via an interface.
 func (v *__CreateHistoricTickerStatsInput) GetInput() NewHistoricTickerStatsInput { return v.Input }
 
+// __CreateJobRunInput is used internally by genqlient
+type __CreateJobRunInput struct {
+	Input JobRunInput `json:"input"`
+}
+
+// GetInput returns __CreateJobRunInput.Input, and is useful for accessing the field via an interface.
+func (v *__CreateJobRunInput) GetInput() JobRunInput { return v.Input }
+
+// __CreateMarketBreadthInput is used internally by genqlient
+type __CreateMarketBreadthInput struct {
+	Input MarketBreadthInput `json:"input"`
+}
+
+// GetInput returns __CreateMarketBreadthInput.Input, and is useful for accessing the field via an interface.
+func (v *__CreateMarketBreadthInput) GetInput() MarketBreadthInput { return v.Input }
+
 // __CreateProjectInput is used internally by genqlient
 type __CreateProjectInput struct {
 	Input CreateProjectInput `json:"input"`
@@ -1299,6 +3027,22 @@ type __CreateProjectInput struct {
 // GetInput returns __CreateProjectInput.Input, and is useful for accessing the field via an interface.
 func (v *__CreateProjectInput) GetInput() CreateProjectInput { return v.Input }
 
+// __CreateStrategyInput is used internally by genqlient
+type __CreateStrategyInput struct {
+	Input StrategyInput `json:"input"`
+}
+
+// GetInput returns __CreateStrategyInput.Input, and is useful for accessing the field via an interface.
+func (v *__CreateStrategyInput) GetInput() StrategyInput { return v.Input }
+
+// __CreateStrategySweepInput is used internally by genqlient
+type __CreateStrategySweepInput struct {
+	Input StrategySweepInput `json:"input"`
+}
+
+// GetInput returns __CreateStrategySweepInput.Input, and is useful for accessing the field via an interface.
+func (v *__CreateStrategySweepInput) GetInput() StrategySweepInput { return v.Input }
+
 // __CreateTaskInput is used internally by genqlient
 type __CreateTaskInput struct {
 	Input CreateTaskInput `json:"input"`
@@ -1307,6 +3051,14 @@ type __CreateTaskInput struct {
 // GetInput returns __CreateTaskInput.Input, and is useful for accessing the field via an interface.
 func (v *__CreateTaskInput) GetInput() CreateTaskInput { return v.Input }
 
+// __CreateTradeOpenedInput is used internally by genqlient
+type __CreateTradeOpenedInput struct {
+	Input NewTradeOpened `json:"input"`
+}
+
+// GetInput returns __CreateTradeOpenedInput.Input, and is useful for accessing the field via an interface.
+func (v *__CreateTradeOpenedInput) GetInput() NewTradeOpened { return v.Input }
+
 // __CreateTradeOutcomeReportInput is used internally by genqlient
 type __CreateTradeOutcomeReportInput struct {
 	TimeStamp        int     `json:"timeStamp"`
@@ -1320,6 +3072,7 @@ type __CreateTradeOutcomeReportInput struct {
 	Volume           float64 `json:"volume"`
 	FearGreedIndex   int     `json:"fearGreedIndex"`
 	MarketStatus     string  `json:"marketStatus"`
+	VersionID        string  `json:"versionID,omitempty"`
 }
 
 // GetTimeStamp returns __CreateTradeOutcomeReportInput.TimeStamp, and is useful for accessing the field via an interface.
@@ -1355,6 +3108,9 @@ func (v *__CreateTradeOutcomeReportInput) GetFearGreedIndex() int { return v.Fea
 // GetMarketStatus returns __CreateTradeOutcomeReportInput.MarketStatus, and is useful for accessing the field via an interface.
 func (v *__CreateTradeOutcomeReportInput) GetMarketStatus() string { return v.MarketStatus }
 
+// GetVersionID returns __CreateTradeOutcomeReportInput.VersionID, and is useful for accessing the field via an interface.
+func (v *__CreateTradeOutcomeReportInput) GetVersionID() string { return v.VersionID }
+
 // __CreateUserInput is used internally by genqlient
 type __CreateUserInput struct {
 	Input CreateUserInput `json:"input"`
@@ -1363,6 +3119,84 @@ type __CreateUserInput struct {
 // GetInput returns __CreateUserInput.Input, and is useful for accessing the field via an interface.
 func (v *__CreateUserInput) GetInput() CreateUserInput { return v.Input }
 
+// __FailStaleJobRunsInput is used internally by genqlient
+type __FailStaleJobRunsInput struct {
+	OlderThan int `json:"olderThan"`
+}
+
+// GetOlderThan returns __FailStaleJobRunsInput.OlderThan, and is useful for accessing the field via an interface.
+func (v *__FailStaleJobRunsInput) GetOlderThan() int { return v.OlderThan }
+
+// __FinishJobRunInput is used internally by genqlient
+type __FinishJobRunInput struct {
+	RunID string       `json:"RunID"`
+	Input JobRunResult `json:"input"`
+}
+
+// GetRunID returns __FinishJobRunInput.RunID, and is useful for accessing the field via an interface.
+func (v *__FinishJobRunInput) GetRunID() string { return v.RunID }
+
+// GetInput returns __FinishJobRunInput.Input, and is useful for accessing the field via an interface.
+func (v *__FinishJobRunInput) GetInput() JobRunResult { return v.Input }
+
+// __InstantiateSopInput is used internally by genqlient
+type __InstantiateSopInput struct {
+	SopId               string                    `json:"sopId"`
+	Title               string                    `json:"title"`
+	AssignedTo          *string                   `json:"assignedTo"`
+	StartDate           string                    `json:"startDate"`
+	DepartmentAssignees []DepartmentAssigneeInput `json:"departmentAssignees"`
+}
+
+// GetSopId returns __InstantiateSopInput.SopId, and is useful for accessing the field via an interface.
+func (v *__InstantiateSopInput) GetSopId() string { return v.SopId }
+
+// GetTitle returns __InstantiateSopInput.Title, and is useful for accessing the field via an interface.
+func (v *__InstantiateSopInput) GetTitle() string { return v.Title }
+
+// GetAssignedTo returns __InstantiateSopInput.AssignedTo, and is useful for accessing the field via an interface.
+func (v *__InstantiateSopInput) GetAssignedTo() *string { return v.AssignedTo }
+
+// GetStartDate returns __InstantiateSopInput.StartDate, and is useful for accessing the field via an interface.
+func (v *__InstantiateSopInput) GetStartDate() string { return v.StartDate }
+
+// GetDepartmentAssignees returns __InstantiateSopInput.DepartmentAssignees, and is useful for accessing the field via an interface.
+func (v *__InstantiateSopInput) GetDepartmentAssignees() []DepartmentAssigneeInput {
+	return v.DepartmentAssignees
+}
+
+// __ReadActivityReportAtInput is used internally by genqlient
+type __ReadActivityReportAtInput struct {
+	Timestamp int `json:"timestamp"`
+}
+
+// GetTimestamp returns __ReadActivityReportAtInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *__ReadActivityReportAtInput) GetTimestamp() int { return v.Timestamp }
+
+// __ReadFearAndGreedIndexForTimeInput is used internally by genqlient
+type __ReadFearAndGreedIndexForTimeInput struct {
+	Timestamp int `json:"Timestamp"`
+}
+
+// GetTimestamp returns __ReadFearAndGreedIndexForTimeInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *__ReadFearAndGreedIndexForTimeInput) GetTimestamp() int { return v.Timestamp }
+
+// __ReadHistoricPriceBeforeInput is used internally by genqlient
+type __ReadHistoricPriceBeforeInput struct {
+	Symbol    string `json:"symbol"`
+	Timestamp int    `json:"timestamp"`
+	Limit     int    `json:"limit"`
+}
+
+// GetSymbol returns __ReadHistoricPriceBeforeInput.Symbol, and is useful for accessing the field via an interface.
+func (v *__ReadHistoricPriceBeforeInput) GetSymbol() string { return v.Symbol }
+
+// GetTimestamp returns __ReadHistoricPriceBeforeInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *__ReadHistoricPriceBeforeInput) GetTimestamp() int { return v.Timestamp }
+
+// GetLimit returns __ReadHistoricPriceBeforeInput.Limit, and is useful for accessing the field via an interface.
+func (v *__ReadHistoricPriceBeforeInput) GetLimit() int { return v.Limit }
+
 // __ReadHistoricPriceInput is used internally by genqlient
 type __ReadHistoricPriceInput struct {
 	Symbol string `json:"symbol"`
@@ -1391,6 +3225,14 @@ type __ReadHistoricTickerStatsAtTimestampInput struct {
 // GetDatetime returns __ReadHistoricTickerStatsAtTimestampInput.Datetime, and is useful for accessing the field via an interface.
 func (v *__ReadHistoricTickerStatsAtTimestampInput) GetDatetime() int { return v.Datetime }
 
+// __ReadLastJobSlotInput is used internally by genqlient
+type __ReadLastJobSlotInput struct {
+	Job string `json:"Job"`
+}
+
+// GetJob returns __ReadLastJobSlotInput.Job, and is useful for accessing the field via an interface.
+func (v *__ReadLastJobSlotInput) GetJob() string { return v.Job }
+
 // __ReadProjectsFilterInput is used internally by genqlient
 type __ReadProjectsFilterInput struct {
 	IsSop bool `json:"isSop"`
@@ -1427,6 +3269,50 @@ type __ReadUserByEmailInput struct {
 // GetEmail returns __ReadUserByEmailInput.Email, and is useful for accessing the field via an interface.
 func (v *__ReadUserByEmailInput) GetEmail() string { return v.Email }
 
+// __RecordTopMoversInput is used internally by genqlient
+type __RecordTopMoversInput struct {
+	Timestamp int             `json:"timestamp"`
+	Movers    []TopMoverInput `json:"movers"`
+}
+
+// GetTimestamp returns __RecordTopMoversInput.Timestamp, and is useful for accessing the field via an interface.
+func (v *__RecordTopMoversInput) GetTimestamp() int { return v.Timestamp }
+
+// GetMovers returns __RecordTopMoversInput.Movers, and is useful for accessing the field via an interface.
+func (v *__RecordTopMoversInput) GetMovers() []TopMoverInput { return v.Movers }
+
+// __TaskGraphInput is used internally by genqlient
+type __TaskGraphInput struct {
+	Id    string `json:"id"`
+	Depth *int   `json:"depth"`
+}
+
+// GetId returns __TaskGraphInput.Id, and is useful for accessing the field via an interface.
+func (v *__TaskGraphInput) GetId() string { return v.Id }
+
+// GetDepth returns __TaskGraphInput.Depth, and is useful for accessing the field via an interface.
+func (v *__TaskGraphInput) GetDepth() *int { return v.Depth }
+
+// __TimeReportInput is used internally by genqlient
+type __TimeReportInput struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GetFrom returns __TimeReportInput.From, and is useful for accessing the field via an interface.
+func (v *__TimeReportInput) GetFrom() string { return v.From }
+
+// GetTo returns __TimeReportInput.To, and is useful for accessing the field via an interface.
+func (v *__TimeReportInput) GetTo() string { return v.To }
+
+// __TriggerJobInput is used internally by genqlient
+type __TriggerJobInput struct {
+	Name string `json:"name"`
+}
+
+// GetName returns __TriggerJobInput.Name, and is useful for accessing the field via an interface.
+func (v *__TriggerJobInput) GetName() string { return v.Name }
+
 // __UpdateCountersInput is used internally by genqlient
 type __UpdateCountersInput struct {
 	Input UpdateCountersInput `json:"input"`
@@ -1435,6 +3321,30 @@ type __UpdateCountersInput struct {
 // GetInput returns __UpdateCountersInput.Input, and is useful for accessing the field via an interface.
 func (v *__UpdateCountersInput) GetInput() UpdateCountersInput { return v.Input }
 
+// __UpdateProjectInput is used internally by genqlient
+type __UpdateProjectInput struct {
+	Input UpdateProjectInput `json:"input"`
+}
+
+// GetInput returns __UpdateProjectInput.Input, and is useful for accessing the field via an interface.
+func (v *__UpdateProjectInput) GetInput() UpdateProjectInput { return v.Input }
+
+// __UpdateStrategyLifecycleInput is used internally by genqlient
+type __UpdateStrategyLifecycleInput struct {
+	Input UpdateLifecycleInput `json:"input"`
+}
+
+// GetInput returns __UpdateStrategyLifecycleInput.Input, and is useful for accessing the field via an interface.
+func (v *__UpdateStrategyLifecycleInput) GetInput() UpdateLifecycleInput { return v.Input }
+
+// __UpdateTaskInput is used internally by genqlient
+type __UpdateTaskInput struct {
+	Input UpdateTaskInput `json:"input"`
+}
+
+// GetInput returns __UpdateTaskInput.Input, and is useful for accessing the field via an interface.
+func (v *__UpdateTaskInput) GetInput() UpdateTaskInput { return v.Input }
+
 // __UpsertFearAndGreedIndexInput is used internally by genqlient
 type __UpsertFearAndGreedIndexInput struct {
 	Timestamp           int    `json:"Timestamp"`
@@ -1479,22 +3389,93 @@ func (v *__UpsertLiquidityEstimateInput) GetMinLiquidityEstimate() float64 {
 	return v.MinLiquidityEstimate
 }
 
-// __UpsertPositionCountsInput is used internally by genqlient
-type __UpsertPositionCountsInput struct {
-	Symbol         string            `json:"symbol"`
-	PositionCounts []model.MeanInput `json:"positionCounts"`
+// __WorkloadInput is used internally by genqlient
+type __WorkloadInput struct {
+	Days *int `json:"days"`
+}
+
+// GetDays returns __WorkloadInput.Days, and is useful for accessing the field via an interface.
+func (v *__WorkloadInput) GetDays() *int { return v.Days }
+
+// The mutation executed by CalendarFeed.
+const CalendarFeed_Operation = `
+mutation CalendarFeed ($labels: [String!], $departments: [String!], $tasksAsEvents: Boolean) {
+	calendarFeed(labels: $labels, departments: $departments, tasksAsEvents: $tasksAsEvents) {
+		url
+	}
 }
+`
 
-// GetSymbol returns __UpsertPositionCountsInput.Symbol, and is useful for accessing the field via an interface.
-func (v *__UpsertPositionCountsInput) GetSymbol() string { return v.Symbol }
+func CalendarFeed(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	labels []string,
+	departments []string,
+	tasksAsEvents *bool,
+) (data_ *CalendarFeedResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "CalendarFeed",
+		Query:  CalendarFeed_Operation,
+		Variables: &__CalendarFeedInput{
+			Labels:        labels,
+			Departments:   departments,
+			TasksAsEvents: tasksAsEvents,
+		},
+	}
 
-// GetPositionCounts returns __UpsertPositionCountsInput.PositionCounts, and is useful for accessing the field via an interface.
-func (v *__UpsertPositionCountsInput) GetPositionCounts() []model.MeanInput { return v.PositionCounts }
+	data_ = &CalendarFeedResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by ClaimJobRun.
+const ClaimJobRun_Operation = `
+mutation ClaimJobRun ($Job: String!) {
+	claimJobRun(Job: $Job) {
+		RunID
+		Job
+		Trigger
+		Status
+	}
+}
+`
+
+func ClaimJobRun(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	Job string,
+) (data_ *ClaimJobRunResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ClaimJobRun",
+		Query:  ClaimJobRun_Operation,
+		Variables: &__ClaimJobRunInput{
+			Job: Job,
+		},
+	}
+
+	data_ = &ClaimJobRunResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
 
 // The mutation executed by CreateActivityReport.
 const CreateActivityReport_Operation = `
-mutation CreateActivityReport ($timeStamp: Int!, $qty: Int!, $avgGain: Float!, $topAGain: Float, $topBGain: Float, $topCGain: Float, $fearGreedIndex: Int!) {
-	createActivityReport(input: {Timestamp:$timeStamp,Qty:$qty,AvgGain:$avgGain,TopAGain:$topAGain,TopBGain:$topBGain,TopCGain:$topCGain,FearGreedIndex:$fearGreedIndex}) {
+mutation CreateActivityReport ($timeStamp: Int!, $qty: Int!, $avgGain: Float!, $topAGain: Float, $topBGain: Float, $topCGain: Float, $fearGreedIndex: Int!, $breadth: Float, $marketStatus: String) {
+	createActivityReport(input: {Timestamp:$timeStamp,Qty:$qty,AvgGain:$avgGain,TopAGain:$topAGain,TopBGain:$topBGain,TopCGain:$topCGain,FearGreedIndex:$fearGreedIndex,Breadth:$breadth,MarketStatus:$marketStatus}) {
 		_id
 		Timestamp
 		Qty
@@ -1503,6 +3484,8 @@ mutation CreateActivityReport ($timeStamp: Int!, $qty: Int!, $avgGain: Float!, $
 		TopBGain
 		TopCGain
 		FearGreedIndex
+		Breadth
+		MarketStatus
 	}
 }
 `
@@ -1517,6 +3500,8 @@ func CreateActivityReport(
 	topBGain float64,
 	topCGain float64,
 	fearGreedIndex int,
+	breadth float64,
+	marketStatus string,
 ) (data_ *CreateActivityReportResponse, err_ error) {
 	req_ := &graphql.Request{
 		OpName: "CreateActivityReport",
@@ -1529,10 +3514,130 @@ func CreateActivityReport(
 			TopBGain:       topBGain,
 			TopCGain:       topCGain,
 			FearGreedIndex: fearGreedIndex,
+			Breadth:        breadth,
+			MarketStatus:   marketStatus,
+		},
+	}
+
+	data_ = &CreateActivityReportResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by CreateBacktestRun.
+const CreateBacktestRun_Operation = `
+mutation CreateBacktestRun ($input: BacktestRunInput!) {
+	createBacktestRun(input: $input) {
+		RunID
+	}
+}
+`
+
+func CreateBacktestRun(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input BacktestRunInput,
+) (data_ *CreateBacktestRunResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "CreateBacktestRun",
+		Query:  CreateBacktestRun_Operation,
+		Variables: &__CreateBacktestRunInput{
+			Input: input,
+		},
+	}
+
+	data_ = &CreateBacktestRunResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by CreateHistoricPrices.
+const CreateHistoricPrices_Operation = `
+mutation CreateHistoricPrices ($input: NewHistoricPriceInput!) {
+	createHistoricPrices(input: $input) {
+		Pair {
+			Symbol
+			Price
+		}
+	}
+}
+`
+
+func CreateHistoricPrices(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input NewHistoricPriceInput,
+) (data_ *CreateHistoricPricesResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "CreateHistoricPrices",
+		Query:  CreateHistoricPrices_Operation,
+		Variables: &__CreateHistoricPricesInput{
+			Input: input,
+		},
+	}
+
+	data_ = &CreateHistoricPricesResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by CreateHistoricTickerStats.
+const CreateHistoricTickerStats_Operation = `
+mutation CreateHistoricTickerStats ($input: NewHistoricTickerStatsInput!) {
+	createHistoricTickerStats(input: $input) {
+		Timestamp
+		Stats {
+			Symbol
+			PriceChange
+			PriceChangePct
+			QuoteVolume
+			Volume
+			TradeCount
+			HighPrice
+			LowPrice
+			LastPrice
+			LiquidityEstimate
+		}
+		CreatedAt
+	}
+}
+`
+
+func CreateHistoricTickerStats(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input NewHistoricTickerStatsInput,
+) (data_ *CreateHistoricTickerStatsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "CreateHistoricTickerStats",
+		Query:  CreateHistoricTickerStats_Operation,
+		Variables: &__CreateHistoricTickerStatsInput{
+			Input: input,
 		},
 	}
 
-	data_ = &CreateActivityReportResponse{}
+	data_ = &CreateHistoricTickerStatsResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
@@ -1544,32 +3649,33 @@ func CreateActivityReport(
 	return data_, err_
 }
 
-// The mutation executed by CreateHistoricPrices.
-const CreateHistoricPrices_Operation = `
-mutation CreateHistoricPrices ($input: NewHistoricPriceInput!) {
-	createHistoricPrices(input: $input) {
-		Pair {
-			Symbol
-			Price
-		}
+// The mutation executed by CreateJobRun.
+const CreateJobRun_Operation = `
+mutation CreateJobRun ($input: JobRunInput!) {
+	createJobRun(input: $input) {
+		RunID
+		Job
+		Slot
+		Trigger
+		Status
 	}
 }
 `
 
-func CreateHistoricPrices(
+func CreateJobRun(
 	ctx_ context.Context,
 	client_ graphql.Client,
-	input NewHistoricPriceInput,
-) (data_ *CreateHistoricPricesResponse, err_ error) {
+	input JobRunInput,
+) (data_ *CreateJobRunResponse, err_ error) {
 	req_ := &graphql.Request{
-		OpName: "CreateHistoricPrices",
-		Query:  CreateHistoricPrices_Operation,
-		Variables: &__CreateHistoricPricesInput{
+		OpName: "CreateJobRun",
+		Query:  CreateJobRun_Operation,
+		Variables: &__CreateJobRunInput{
 			Input: input,
 		},
 	}
 
-	data_ = &CreateHistoricPricesResponse{}
+	data_ = &CreateJobRunResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
@@ -1581,42 +3687,32 @@ func CreateHistoricPrices(
 	return data_, err_
 }
 
-// The mutation executed by CreateHistoricTickerStats.
-const CreateHistoricTickerStats_Operation = `
-mutation CreateHistoricTickerStats ($input: NewHistoricTickerStatsInput!) {
-	createHistoricTickerStats(input: $input) {
+// The mutation executed by CreateMarketBreadth.
+const CreateMarketBreadth_Operation = `
+mutation CreateMarketBreadth ($input: MarketBreadthInput!) {
+	createMarketBreadth(input: $input) {
 		Timestamp
-		Stats {
-			Symbol
-			PriceChange
-			PriceChangePct
-			QuoteVolume
-			Volume
-			TradeCount
-			HighPrice
-			LowPrice
-			LastPrice
-			LiquidityEstimate
-		}
-		CreatedAt
+		Pairs
+		AdvancePercent
+		MedianChange
 	}
 }
 `
 
-func CreateHistoricTickerStats(
+func CreateMarketBreadth(
 	ctx_ context.Context,
 	client_ graphql.Client,
-	input NewHistoricTickerStatsInput,
-) (data_ *CreateHistoricTickerStatsResponse, err_ error) {
+	input MarketBreadthInput,
+) (data_ *CreateMarketBreadthResponse, err_ error) {
 	req_ := &graphql.Request{
-		OpName: "CreateHistoricTickerStats",
-		Query:  CreateHistoricTickerStats_Operation,
-		Variables: &__CreateHistoricTickerStatsInput{
+		OpName: "CreateMarketBreadth",
+		Query:  CreateMarketBreadth_Operation,
+		Variables: &__CreateMarketBreadthInput{
 			Input: input,
 		},
 	}
 
-	data_ = &CreateHistoricTickerStatsResponse{}
+	data_ = &CreateMarketBreadthResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
@@ -1671,6 +3767,75 @@ func CreateProject(
 	return data_, err_
 }
 
+// The mutation executed by CreateStrategy.
+const CreateStrategy_Operation = `
+mutation CreateStrategy ($input: StrategyInput!) {
+	createStrategy(input: $input) {
+		BotInstanceName
+	}
+}
+`
+
+func CreateStrategy(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input StrategyInput,
+) (data_ *CreateStrategyResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "CreateStrategy",
+		Query:  CreateStrategy_Operation,
+		Variables: &__CreateStrategyInput{
+			Input: input,
+		},
+	}
+
+	data_ = &CreateStrategyResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by CreateStrategySweep.
+const CreateStrategySweep_Operation = `
+mutation CreateStrategySweep ($input: StrategySweepInput!) {
+	createStrategySweep(input: $input) {
+		SweepID
+		Permutations
+	}
+}
+`
+
+func CreateStrategySweep(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input StrategySweepInput,
+) (data_ *CreateStrategySweepResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "CreateStrategySweep",
+		Query:  CreateStrategySweep_Operation,
+		Variables: &__CreateStrategySweepInput{
+			Input: input,
+		},
+	}
+
+	data_ = &CreateStrategySweepResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The mutation executed by CreateTask.
 const CreateTask_Operation = `
 mutation CreateTask ($input: CreateTaskInput!) {
@@ -1686,6 +3851,10 @@ mutation CreateTask ($input: CreateTaskInput!) {
 		department
 		projectId
 		duration
+		estimate
+		parentId
+		blockedBy
+		recurrence
 		createdAt
 		updatedAt
 	}
@@ -1717,10 +3886,47 @@ func CreateTask(
 	return data_, err_
 }
 
+// The mutation executed by CreateTradeOpened.
+const CreateTradeOpened_Operation = `
+mutation CreateTradeOpened ($input: NewTradeOpened!) {
+	createTradeOpened(input: $input) {
+		_id
+		Timestamp
+		BotName
+		Symbol
+	}
+}
+`
+
+func CreateTradeOpened(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input NewTradeOpened,
+) (data_ *CreateTradeOpenedResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "CreateTradeOpened",
+		Query:  CreateTradeOpened_Operation,
+		Variables: &__CreateTradeOpenedInput{
+			Input: input,
+		},
+	}
+
+	data_ = &CreateTradeOpenedResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The mutation executed by CreateTradeOutcomeReport.
 const CreateTradeOutcomeReport_Operation = `
-mutation CreateTradeOutcomeReport ($timeStamp: Int!, $botName: String!, $percentageChange: Float!, $balance: Float!, $symbol: String!, $outcome: String!, $Fee: Float, $elapsedTime: Int!, $volume: Float!, $fearGreedIndex: Int!, $marketStatus: String!) {
-	createTradeOutcomeReport(input: {Timestamp:$timeStamp,BotName:$botName,PercentageChange:$percentageChange,Balance:$balance,Symbol:$symbol,Outcome:$outcome,Fee:$Fee,ElapsedTime:$elapsedTime,Volume:$volume,FearGreedIndex:$fearGreedIndex,MarketStatus:$marketStatus}) {
+mutation CreateTradeOutcomeReport ($timeStamp: Int!, $botName: String!, $percentageChange: Float!, $balance: Float!, $symbol: String!, $outcome: String!, $Fee: Float, $elapsedTime: Int!, $volume: Float!, $fearGreedIndex: Int!, $marketStatus: String!, $versionID: String) {
+	createTradeOutcomeReport(input: {Timestamp:$timeStamp,BotName:$botName,PercentageChange:$percentageChange,Balance:$balance,Symbol:$symbol,Outcome:$outcome,Fee:$Fee,ElapsedTime:$elapsedTime,Volume:$volume,FearGreedIndex:$fearGreedIndex,MarketStatus:$marketStatus,VersionID:$versionID}) {
 		_id
 		Timestamp
 		BotName
@@ -1733,6 +3939,7 @@ mutation CreateTradeOutcomeReport ($timeStamp: Int!, $botName: String!, $percent
 		Volume
 		FearGreedIndex
 		MarketStatus
+		VersionID
 	}
 }
 `
@@ -1751,6 +3958,7 @@ func CreateTradeOutcomeReport(
 	volume float64,
 	fearGreedIndex int,
 	marketStatus string,
+	versionID string,
 ) (data_ *CreateTradeOutcomeReportResponse, err_ error) {
 	req_ := &graphql.Request{
 		OpName: "CreateTradeOutcomeReport",
@@ -1767,6 +3975,7 @@ func CreateTradeOutcomeReport(
 			Volume:           volume,
 			FearGreedIndex:   fearGreedIndex,
 			MarketStatus:     marketStatus,
+			VersionID:        versionID,
 		},
 	}
 
@@ -1820,6 +4029,201 @@ func CreateUser(
 	return data_, err_
 }
 
+// The mutation executed by FailStaleJobRuns.
+const FailStaleJobRuns_Operation = `
+mutation FailStaleJobRuns ($olderThan: Int!) {
+	failStaleJobRuns(olderThan: $olderThan)
+}
+`
+
+func FailStaleJobRuns(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	olderThan int,
+) (data_ *FailStaleJobRunsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "FailStaleJobRuns",
+		Query:  FailStaleJobRuns_Operation,
+		Variables: &__FailStaleJobRunsInput{
+			OlderThan: olderThan,
+		},
+	}
+
+	data_ = &FailStaleJobRunsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by FinishJobRun.
+const FinishJobRun_Operation = `
+mutation FinishJobRun ($RunID: String!, $input: JobRunResult!) {
+	finishJobRun(RunID: $RunID, input: $input) {
+		RunID
+		Status
+		DurationMs
+	}
+}
+`
+
+func FinishJobRun(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	RunID string,
+	input JobRunResult,
+) (data_ *FinishJobRunResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "FinishJobRun",
+		Query:  FinishJobRun_Operation,
+		Variables: &__FinishJobRunInput{
+			RunID: RunID,
+			Input: input,
+		},
+	}
+
+	data_ = &FinishJobRunResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by InstantiateSop.
+const InstantiateSop_Operation = `
+mutation InstantiateSop ($sopId: ID!, $title: String!, $assignedTo: String, $startDate: String!, $departmentAssignees: [DepartmentAssigneeInput!]) {
+	instantiateSop(sopId: $sopId, title: $title, assignedTo: $assignedTo, startDate: $startDate, departmentAssignees: $departmentAssignees) {
+		id
+		title
+		assignedTo
+		dueDate
+		status
+		tasks {
+			id
+			title
+			assignedTo
+			dueDate
+			deferDate
+			department
+		}
+	}
+}
+`
+
+func InstantiateSop(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	sopId string,
+	title string,
+	assignedTo *string,
+	startDate string,
+	departmentAssignees []DepartmentAssigneeInput,
+) (data_ *InstantiateSopResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "InstantiateSop",
+		Query:  InstantiateSop_Operation,
+		Variables: &__InstantiateSopInput{
+			SopId:               sopId,
+			Title:               title,
+			AssignedTo:          assignedTo,
+			StartDate:           startDate,
+			DepartmentAssignees: departmentAssignees,
+		},
+	}
+
+	data_ = &InstantiateSopResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by PromoteDeferredTasks.
+const PromoteDeferredTasks_Operation = `
+mutation PromoteDeferredTasks {
+	promoteDeferredTasks {
+		id
+		title
+		status
+		deferDate
+	}
+}
+`
+
+func PromoteDeferredTasks(
+	ctx_ context.Context,
+	client_ graphql.Client,
+) (data_ *PromoteDeferredTasksResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "PromoteDeferredTasks",
+		Query:  PromoteDeferredTasks_Operation,
+	}
+
+	data_ = &PromoteDeferredTasksResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by ReadActivityReportAt.
+const ReadActivityReportAt_Operation = `
+query ReadActivityReportAt ($timestamp: Int!) {
+	readActivityReportAt(Timestamp: $timestamp) {
+		Timestamp
+		Qty
+		FearGreedIndex
+		Breadth
+		MarketStatus
+	}
+}
+`
+
+func ReadActivityReportAt(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	timestamp int,
+) (data_ *ReadActivityReportAtResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ReadActivityReportAt",
+		Query:  ReadActivityReportAt_Operation,
+		Variables: &__ReadActivityReportAtInput{
+			Timestamp: timestamp,
+		},
+	}
+
+	data_ = &ReadActivityReportAtResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by ReadAllStrategies.
 const ReadAllStrategies_Operation = `
 query ReadAllStrategies {
@@ -1840,8 +4244,13 @@ query ReadAllStrategies {
 		TakeProfitPercentage
 		StopLossPercentage
 		ATRtollerance
+		MinFearGreed
+		MaxFearGreed
+		AllowedSentiments
 		FeesTotal
 		Tested
+		Lifecycle
+		VersionID
 		Owner
 		CreatedOn
 	}
@@ -1884,22 +4293,62 @@ query ReadAllTasks {
 		department
 		projectId
 		duration
+		estimate
+		parentId
+		blockedBy
+		recurrence
 		createdAt
 		updatedAt
 	}
 }
 `
 
-func ReadAllTasks(
+func ReadAllTasks(
+	ctx_ context.Context,
+	client_ graphql.Client,
+) (data_ *ReadAllTasksResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ReadAllTasks",
+		Query:  ReadAllTasks_Operation,
+	}
+
+	data_ = &ReadAllTasksResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by ReadFearAndGreedIndexForTime.
+const ReadFearAndGreedIndexForTime_Operation = `
+query ReadFearAndGreedIndexForTime ($Timestamp: Int!) {
+	readFearAndGreedIndexForTime(Timestamp: $Timestamp) {
+		Timestamp
+		Value
+		ValueClassification
+	}
+}
+`
+
+func ReadFearAndGreedIndexForTime(
 	ctx_ context.Context,
 	client_ graphql.Client,
-) (data_ *ReadAllTasksResponse, err_ error) {
+	Timestamp int,
+) (data_ *ReadFearAndGreedIndexForTimeResponse, err_ error) {
 	req_ := &graphql.Request{
-		OpName: "ReadAllTasks",
-		Query:  ReadAllTasks_Operation,
+		OpName: "ReadFearAndGreedIndexForTime",
+		Query:  ReadFearAndGreedIndexForTime_Operation,
+		Variables: &__ReadFearAndGreedIndexForTimeInput{
+			Timestamp: Timestamp,
+		},
 	}
 
-	data_ = &ReadAllTasksResponse{}
+	data_ = &ReadFearAndGreedIndexForTimeResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
@@ -1951,6 +4400,48 @@ func ReadHistoricPrice(
 	return data_, err_
 }
 
+// The query executed by ReadHistoricPriceBefore.
+const ReadHistoricPriceBefore_Operation = `
+query ReadHistoricPriceBefore ($symbol: String!, $timestamp: Int!, $limit: Int!) {
+	readHistoricPriceBefore(symbol: $symbol, Timestamp: $timestamp, limit: $limit) {
+		Pair {
+			Symbol
+			Price
+		}
+		Timestamp
+	}
+}
+`
+
+func ReadHistoricPriceBefore(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	symbol string,
+	timestamp int,
+	limit int,
+) (data_ *ReadHistoricPriceBeforeResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ReadHistoricPriceBefore",
+		Query:  ReadHistoricPriceBefore_Operation,
+		Variables: &__ReadHistoricPriceBeforeInput{
+			Symbol:    symbol,
+			Timestamp: timestamp,
+			Limit:     limit,
+		},
+	}
+
+	data_ = &ReadHistoricPriceBeforeResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by ReadHistoricPricesAtTimestamp.
 const ReadHistoricPricesAtTimestamp_Operation = `
 query ReadHistoricPricesAtTimestamp ($datetime: Int!) {
@@ -2035,6 +4526,38 @@ func ReadHistoricTickerStatsAtTimestamp(
 	return data_, err_
 }
 
+// The query executed by ReadLastJobSlot.
+const ReadLastJobSlot_Operation = `
+query ReadLastJobSlot ($Job: String!) {
+	readLastJobSlot(Job: $Job)
+}
+`
+
+func ReadLastJobSlot(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	Job string,
+) (data_ *ReadLastJobSlotResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ReadLastJobSlot",
+		Query:  ReadLastJobSlot_Operation,
+		Variables: &__ReadLastJobSlotInput{
+			Job: Job,
+		},
+	}
+
+	data_ = &ReadLastJobSlotResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by ReadProjectsFilter.
 const ReadProjectsFilter_Operation = `
 query ReadProjectsFilter ($isSop: Boolean) {
@@ -2196,6 +4719,213 @@ func ReadUserByEmail(
 	return data_, err_
 }
 
+// The mutation executed by RecordTopMovers.
+const RecordTopMovers_Operation = `
+mutation RecordTopMovers ($timestamp: Int!, $movers: [TopMoverInput!]!) {
+	recordTopMovers(input: {Timestamp:$timestamp,Movers:$movers})
+}
+`
+
+func RecordTopMovers(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	timestamp int,
+	movers []TopMoverInput,
+) (data_ *RecordTopMoversResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "RecordTopMovers",
+		Query:  RecordTopMovers_Operation,
+		Variables: &__RecordTopMoversInput{
+			Timestamp: timestamp,
+			Movers:    movers,
+		},
+	}
+
+	data_ = &RecordTopMoversResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by ResetCalendarFeed.
+const ResetCalendarFeed_Operation = `
+mutation ResetCalendarFeed {
+	resetCalendarFeed
+}
+`
+
+func ResetCalendarFeed(
+	ctx_ context.Context,
+	client_ graphql.Client,
+) (data_ *ResetCalendarFeedResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ResetCalendarFeed",
+		Query:  ResetCalendarFeed_Operation,
+	}
+
+	data_ = &ResetCalendarFeedResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by TaskGraph.
+const TaskGraph_Operation = `
+query TaskGraph ($id: ID!, $depth: Int) {
+	taskGraph(id: $id, depth: $depth) {
+		root {
+			id
+		}
+		tasks {
+			id
+			title
+			status
+			parentId
+			blockedBy
+		}
+		links {
+			from
+			to
+			kind
+		}
+	}
+}
+`
+
+func TaskGraph(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	depth *int,
+) (data_ *TaskGraphResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "TaskGraph",
+		Query:  TaskGraph_Operation,
+		Variables: &__TaskGraphInput{
+			Id:    id,
+			Depth: depth,
+		},
+	}
+
+	data_ = &TaskGraphResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by TimeReport.
+const TimeReport_Operation = `
+query TimeReport ($from: String!, $to: String!) {
+	timeReport(from: $from, to: $to) {
+		from
+		to
+		totalMinutes
+		byDepartment {
+			key
+			name
+			minutes
+		}
+		byAssignee {
+			key
+			name
+			minutes
+		}
+		byUser {
+			key
+			name
+			minutes
+		}
+		byProject {
+			key
+			name
+			minutes
+		}
+	}
+}
+`
+
+func TimeReport(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	from string,
+	to string,
+) (data_ *TimeReportResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "TimeReport",
+		Query:  TimeReport_Operation,
+		Variables: &__TimeReportInput{
+			From: from,
+			To:   to,
+		},
+	}
+
+	data_ = &TimeReportResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by TriggerJob.
+const TriggerJob_Operation = `
+mutation TriggerJob ($name: String!) {
+	triggerJob(name: $name) {
+		RunID
+		Job
+		Status
+		QueuedAt
+	}
+}
+`
+
+func TriggerJob(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	name string,
+) (data_ *TriggerJobResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "TriggerJob",
+		Query:  TriggerJob_Operation,
+		Variables: &__TriggerJobInput{
+			Name: name,
+		},
+	}
+
+	data_ = &TriggerJobResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The mutation executed by UpdateCounters.
 const UpdateCounters_Operation = `
 mutation UpdateCounters ($input: UpdateCountersInput!) {
@@ -2228,6 +4958,115 @@ func UpdateCounters(
 	return data_, err_
 }
 
+// The mutation executed by UpdateProject.
+const UpdateProject_Operation = `
+mutation UpdateProject ($input: UpdateProjectInput!) {
+	updateProject(input: $input) {
+		id
+		title
+		status
+		updatedAt
+	}
+}
+`
+
+func UpdateProject(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input UpdateProjectInput,
+) (data_ *UpdateProjectResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "UpdateProject",
+		Query:  UpdateProject_Operation,
+		Variables: &__UpdateProjectInput{
+			Input: input,
+		},
+	}
+
+	data_ = &UpdateProjectResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by UpdateStrategyLifecycle.
+const UpdateStrategyLifecycle_Operation = `
+mutation UpdateStrategyLifecycle ($input: UpdateLifecycleInput!) {
+	updateStrategyLifecycle(input: $input) {
+		BotInstanceName
+		Lifecycle
+	}
+}
+`
+
+func UpdateStrategyLifecycle(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input UpdateLifecycleInput,
+) (data_ *UpdateStrategyLifecycleResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "UpdateStrategyLifecycle",
+		Query:  UpdateStrategyLifecycle_Operation,
+		Variables: &__UpdateStrategyLifecycleInput{
+			Input: input,
+		},
+	}
+
+	data_ = &UpdateStrategyLifecycleResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by UpdateTask.
+const UpdateTask_Operation = `
+mutation UpdateTask ($input: UpdateTaskInput!) {
+	updateTask(input: $input) {
+		id
+		title
+		status
+		updatedAt
+	}
+}
+`
+
+func UpdateTask(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input UpdateTaskInput,
+) (data_ *UpdateTaskResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "UpdateTask",
+		Query:  UpdateTask_Operation,
+		Variables: &__UpdateTaskInput{
+			Input: input,
+		},
+	}
+
+	data_ = &UpdateTaskResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The mutation executed by UpsertFearAndGreedIndex.
 const UpsertFearAndGreedIndex_Operation = `
 mutation UpsertFearAndGreedIndex ($Timestamp: Int!, $Value: String!, $ValueClassification: String!) {
@@ -2314,35 +5153,36 @@ func UpsertLiquidityEstimate(
 	return data_, err_
 }
 
-// The mutation executed by UpsertPositionCounts.
-const UpsertPositionCounts_Operation = `
-mutation UpsertPositionCounts ($symbol: String!, $positionCounts: [MeanInput]) {
-	upsertSymbolStats(input: {Symbol:$symbol,PositionCounts:$positionCounts}) {
-		Symbol
-		PositionCounts {
-			Avg
-			Count
-		}
+// The query executed by Workload.
+const Workload_Operation = `
+query Workload ($days: Int) {
+	workload(days: $days) {
+		assignedTo
+		openTasks
+		unestimatedTasks
+		remainingMinutes
+		overdueMinutes
+		dueSoonMinutes
+		laterMinutes
+		nextDueDate
 	}
 }
 `
 
-func UpsertPositionCounts(
+func Workload(
 	ctx_ context.Context,
 	client_ graphql.Client,
-	symbol string,
-	positionCounts []model.MeanInput,
-) (data_ *UpsertPositionCountsResponse, err_ error) {
+	days *int,
+) (data_ *WorkloadResponse, err_ error) {
 	req_ := &graphql.Request{
-		OpName: "UpsertPositionCounts",
-		Query:  UpsertPositionCounts_Operation,
-		Variables: &__UpsertPositionCountsInput{
-			Symbol:         symbol,
-			PositionCounts: positionCounts,
+		OpName: "Workload",
+		Query:  Workload_Operation,
+		Variables: &__WorkloadInput{
+			Days: days,
 		},
 	}
 
-	data_ = &UpsertPositionCountsResponse{}
+	data_ = &WorkloadResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
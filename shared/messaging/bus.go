@@ -0,0 +1,186 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog/log"
+)
+
+// EmbeddedURL, given as the NATS URL, starts an embedded server on the default
+// port instead of connecting to an external one.
+const EmbeddedURL = "embedded"
+
+// Tick is how often prices are snapshotted, and so how long a trading
+// consumer acts on an event for.
+const Tick = 5 * time.Minute
+
+// Consumers have until the next tick to handle an event before it is redelivered.
+const (
+	ackWait    = Tick
+	maxDeliver = 3
+	nakDelay   = 10 * time.Second
+)
+
+// Bus publishes and consumes events on the CBM JetStream stream. A nil *Bus
+// publishes nothing, so services can run without NATS.
+type Bus struct {
+	nc       *nats.Conn
+	js       jetstream.JetStream
+	embedded *server.Server
+}
+
+// Connect connects to the NATS server at the URL, or starts an embedded one if
+// the URL is EmbeddedURL, and makes sure the CBM stream exists.
+func Connect(ctx context.Context, url, name string) (*Bus, error) {
+	bus := &Bus{}
+
+	if url == EmbeddedURL {
+		srv, err := RunEmbeddedServer(EmbeddedOptions{
+			Port:     nats.DefaultPort,
+			StoreDir: filepath.Join(os.TempDir(), "cbm-nats"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		bus.embedded = srv
+		url = srv.ClientURL()
+	}
+
+	nc, err := nats.Connect(url, nats.Name(name), nats.MaxReconnects(-1))
+	if err != nil {
+		bus.shutdownEmbedded()
+		return nil, fmt.Errorf("connecting to NATS at %s: %w", url, err)
+	}
+	bus.nc = nc
+
+	bus.js, err = jetstream.New(nc)
+	if err != nil {
+		bus.Close()
+		return nil, fmt.Errorf("creating JetStream context: %w", err)
+	}
+
+	if _, err := bus.js.CreateOrUpdateStream(ctx, StreamConfig()); err != nil {
+		bus.Close()
+		return nil, fmt.Errorf("creating stream %s: %w", StreamName, err)
+	}
+
+	log.Info().Str("URL", url).Str("Name", name).Msg("Connected to NATS")
+	return bus, nil
+}
+
+// ConnectFromEnv connects to the server in NATS_URL. Without one it returns a
+// nil Bus and no error, leaving the service to run without events.
+func ConnectFromEnv(ctx context.Context, name string) (*Bus, error) {
+	url := os.Getenv("NATS_URL")
+	if url == "" {
+		return nil, nil
+	}
+	return Connect(ctx, url, name)
+}
+
+// Conn returns the underlying NATS connection, e.g. for SetupSignalHandlers.
+func (b *Bus) Conn() *nats.Conn {
+	return b.nc
+}
+
+// Close drains the connection, letting in-flight handlers finish, and shuts
+// down the embedded server if this bus started one.
+func (b *Bus) Close() {
+	if b == nil {
+		return
+	}
+	if b.nc != nil {
+		if err := b.nc.Drain(); err != nil {
+			log.Error().Err(err).Msg("Failed to drain NATS connection")
+		}
+	}
+	b.shutdownEmbedded()
+}
+
+func (b *Bus) shutdownEmbedded() {
+	if b.embedded != nil {
+		b.embedded.Shutdown()
+		b.embedded.WaitForShutdown()
+	}
+}
+
+// Publish sends the event as JSON on the subject. The ID deduplicates
+// republished events, e.g. a tick's snapshot sent twice. It does nothing on
+// a nil Bus.
+func (b *Bus) Publish(ctx context.Context, subject, id string, event any) error {
+	if b == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", subject, err)
+	}
+
+	if _, err := b.js.Publish(ctx, subject, data, jetstream.WithMsgID(id)); err != nil {
+		return fmt.Errorf("publishing %s event: %w", subject, err)
+	}
+	return nil
+}
+
+// Subscribe consumes the subject's events with a durable consumer, so events
+// published while the service is down are delivered once it is back. With a
+// maxAge, events published longer ago than that are dropped instead, so that a
+// consumer acting on the market does not act on a backlog, or on an event
+// retried past its moment; zero handles every event. An event is acknowledged
+// when handle returns nil and redelivered after a delay when it returns an
+// error; events that cannot be decoded are dropped. Stop the returned context
+// to stop consuming.
+func Subscribe[T any](ctx context.Context, b *Bus, durable, subject string, maxAge time.Duration, handle func(context.Context, T) error) (jetstream.ConsumeContext, error) {
+	consumer, err := b.js.CreateOrUpdateConsumer(ctx, StreamName, jetstream.ConsumerConfig{
+		Durable:       durable,
+		FilterSubject: subject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       ackWait,
+		MaxDeliver:    maxDeliver,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating consumer %s on %s: %w", durable, subject, err)
+	}
+
+	return consumer.Consume(func(msg jetstream.Msg) {
+		if maxAge > 0 {
+			if meta, err := msg.Metadata(); err == nil && time.Since(meta.Timestamp) > maxAge {
+				log.Warn().Str("Subject", msg.Subject()).Str("Consumer", durable).Time("Published", meta.Timestamp).Msg("Dropping event too old to act on")
+				if err := msg.Term(); err != nil {
+					log.Error().Err(err).Msg("Failed to terminate event")
+				}
+				return
+			}
+		}
+
+		var event T
+		if err := json.Unmarshal(msg.Data(), &event); err != nil {
+			log.Error().Err(err).Str("Subject", msg.Subject()).Str("Consumer", durable).Msg("Dropping event that cannot be decoded")
+			if err := msg.Term(); err != nil {
+				log.Error().Err(err).Msg("Failed to terminate event")
+			}
+			return
+		}
+
+		if err := handle(ctx, event); err != nil {
+			log.Error().Err(err).Str("Subject", msg.Subject()).Str("Consumer", durable).Msg("Failed to handle event, will retry")
+			if err := msg.NakWithDelay(nakDelay); err != nil {
+				log.Error().Err(err).Msg("Failed to nak event")
+			}
+			return
+		}
+
+		if err := msg.Ack(); err != nil {
+			log.Error().Err(err).Str("Subject", msg.Subject()).Msg("Failed to ack event")
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package messaging
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// EmbeddedOptions configure an in-process NATS server.
+type EmbeddedOptions struct {
+	Port     int    // -1 picks a free port
+	StoreDir string // JetStream storage, a temporary directory if empty
+}
+
+// RunEmbeddedServer starts an in-process NATS server with JetStream enabled,
+// for local runs and tests without the docker-compose service. Shut it down
+// with Shutdown when done.
+func RunEmbeddedServer(opts EmbeddedOptions) (*server.Server, error) {
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      opts.Port,
+		JetStream: true,
+		StoreDir:  opts.StoreDir,
+		NoSigs:    true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating embedded NATS server: %w", err)
+	}
+
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		srv.Shutdown()
+		return nil, fmt.Errorf("embedded NATS server not ready")
+	}
+	return srv, nil
+}
@@ -0,0 +1,82 @@
+package messaging
+
+import (
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// StreamName is the JetStream stream holding every event the services exchange.
+const StreamName = "CBM"
+
+// Event subjects. Each carries the event type of the same name as JSON.
+const (
+	SubjectPricesSnapshot   = "prices.snapshot"   // PricesSnapshot, once per five minute tick
+	SubjectTickerStatsDaily = "tickerstats.daily" // TickerStatsDaily, once a day
+	SubjectTradeSignal      = "trade.signal"      // TradeSignal, a strategy has chosen a symbol to trade
+	SubjectTradeOpened      = "trade.opened"      // TradeOpened, the paper trader has entered a trade
+	SubjectTradeClosed      = "trade.closed"      // TradeClosed, the paper trader has exited a trade
+)
+
+// StreamConfig returns the configuration of the CBM stream. Events are kept
+// for a week so a consumer that has been down can catch up, and duplicates
+// published within the window are dropped by their message ID.
+func StreamConfig() jetstream.StreamConfig {
+	return jetstream.StreamConfig{
+		Name:       StreamName,
+		Subjects:   []string{"prices.>", "tickerstats.>", "trade.>"},
+		Storage:    jetstream.FileStorage,
+		MaxAge:     7 * 24 * time.Hour,
+		Duplicates: 10 * time.Minute,
+	}
+}
+
+// PricesSnapshot is the whole market's prices at a tick, enriched with the %
+// change since the previous tick. Timestamp is in seconds.
+type PricesSnapshot struct {
+	Timestamp int          `json:"timestamp"`
+	Pairs     []model.Pair `json:"pairs"`
+}
+
+// TickerStatsDaily is the 24h ticker stats and liquidity estimates saved at the
+// start of the day. Timestamp is in seconds.
+type TickerStatsDaily struct {
+	Timestamp int                      `json:"timestamp"`
+	Stats     []model.TickerStatsInput `json:"stats"`
+}
+
+// TradeSignal asks the paper trader to trade the symbol with the strategy.
+// Timestamp is the tick, in seconds, the signal was raised on.
+type TradeSignal struct {
+	Timestamp int                 `json:"timestamp"`
+	Symbol    string              `json:"symbol"`
+	Strategy  model.StrategyInput `json:"strategy"`
+}
+
+// TradeOpened is published when the paper trader enters a trade. Times are in
+// milliseconds.
+type TradeOpened struct {
+	Timestamp  int     `json:"timestamp"`
+	BotName    string  `json:"botName"`
+	VersionID  string  `json:"versionID,omitempty"`
+	Symbol     string  `json:"symbol"`
+	EntryPrice float64 `json:"entryPrice"`
+	TakeProfit float64 `json:"takeProfit"`
+	StopLoss   float64 `json:"stopLoss"`
+	TimesOutAt int     `json:"timesOutAt"`
+}
+
+// TradeClosed is published when the paper trader exits a trade. Times are in
+// milliseconds.
+type TradeClosed struct {
+	Timestamp        int     `json:"timestamp"`
+	BotName          string  `json:"botName"`
+	VersionID        string  `json:"versionID,omitempty"`
+	Symbol           string  `json:"symbol"`
+	Outcome          string  `json:"outcome"`
+	PercentageChange float64 `json:"percentageChange"`
+	Balance          float64 `json:"balance"`
+	Fee              float64 `json:"fee"`
+	ElapsedTime      int     `json:"elapsedTime"`
+}
@@ -0,0 +1,296 @@
+// Package scheduler runs jobs in process on cron schedules, recording every
+// run in the JobRuns collection. A job never overlaps itself: a slot that
+// comes round while the previous run is still going is recorded as skipped.
+// Slots missed while the service was down are caught up when it starts, or
+// recorded as skipped for jobs that cannot catch up, and manual runs queued
+// with the triggerJob mutation are picked up as they come.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// pollInterval is how often queued manual runs are looked for.
+const pollInterval = 15 * time.Second
+
+// maxMissed is the most missed slots of a job looked at on start, a day of
+// five minute slots.
+const maxMissed = 288
+
+// staleAfter is how long a run can be recorded as running before it is taken
+// to have been left so by a scheduler that died, longer than any job takes.
+const staleAfter = time.Hour
+
+// Job is a task run on a cron schedule.
+type Job struct {
+	Name     string // as recorded in JobRuns and given to triggerJob
+	Schedule string // standard five field cron expression, in UTC
+	CatchUp  int    // most missed slots to run on start; the rest are recorded as skipped
+
+	// Run does the work for the slot. Manual runs are given the time they
+	// were started.
+	Run func(ctx context.Context, slot time.Time, trigger graph.JobTrigger) error
+}
+
+type entry struct {
+	Job
+	schedule cron.Schedule
+	running  atomic.Bool
+}
+
+// Scheduler runs its jobs until the context given to Run is done.
+type Scheduler struct {
+	client graphql.Client
+	jobs   []*entry
+	runs   sync.WaitGroup
+}
+
+// New returns a scheduler for the jobs, recording their runs through the
+// client. It fails if a schedule cannot be parsed.
+func New(client graphql.Client, jobs ...Job) (*Scheduler, error) {
+	s := &Scheduler{client: client}
+	for _, job := range jobs {
+		schedule, err := Parse(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		s.jobs = append(s.jobs, &entry{Job: job, schedule: schedule})
+	}
+	return s, nil
+}
+
+// Parse parses a standard five field cron expression.
+func Parse(expr string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("parsing schedule %q: %w", expr, err)
+	}
+	return schedule, nil
+}
+
+// MissedSlots returns the schedule's slots after last up to and including
+// now, oldest first, keeping only the most recent max of them.
+func MissedSlots(schedule cron.Schedule, last, now time.Time, max int) []time.Time {
+	if max <= 0 {
+		return nil
+	}
+
+	var slots []time.Time
+	for slot := schedule.Next(last); !slot.After(now); slot = schedule.Next(slot) {
+		slots = append(slots, slot)
+		if len(slots) > max {
+			slots = slots[1:]
+		}
+	}
+	return slots
+}
+
+// SplitMissed splits missed slots, oldest first, into those recorded as
+// skipped and the most recent catchUp of them, which are run.
+func SplitMissed(slots []time.Time, catchUp int) (skip, run []time.Time) {
+	split := max(len(slots)-max(catchUp, 0), 0)
+	return slots[:split], slots[split:]
+}
+
+// Run fails the runs a dead scheduler left running and catches up each job's
+// missed slots, then runs the jobs on their schedules and as they are
+// triggered. It returns once the context is done and the runs in progress
+// have finished.
+func (s *Scheduler) Run(ctx context.Context) {
+	// A job runs once at a time, so its stale run would hold it up for good.
+	resp, err := graph.FailStaleJobRuns(ctx, s.client, int(staleAfter.Seconds()))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fail stale job runs")
+	} else if resp.FailStaleJobRuns > 0 {
+		log.Warn().Int("Runs", resp.FailStaleJobRuns).Msg("Failed runs left running by a stopped scheduler")
+	}
+
+	var loops sync.WaitGroup
+	for _, e := range s.jobs {
+		loops.Add(1)
+		go func() {
+			defer loops.Done()
+			s.schedule(ctx, e)
+		}()
+	}
+
+	loops.Add(1)
+	go func() {
+		defer loops.Done()
+		s.pollTriggers(ctx)
+	}()
+
+	loops.Wait()
+	s.runs.Wait()
+}
+
+// schedule catches up the job, then starts it at each slot. Catching up holds
+// the job's running flag, so slots reached meanwhile are skipped, not lost.
+func (s *Scheduler) schedule(ctx context.Context, e *entry) {
+	if e.running.CompareAndSwap(false, true) {
+		s.runs.Add(1)
+		go func() {
+			defer s.runs.Done()
+			defer e.running.Store(false)
+			s.catchUp(ctx, e)
+		}()
+	}
+
+	for {
+		slot := e.schedule.Next(time.Now().UTC())
+		timer := time.NewTimer(time.Until(slot))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !e.running.CompareAndSwap(false, true) {
+			log.Warn().Str("Job", e.Name).Time("Slot", slot).Msg("Previous run still going, skipping slot")
+			s.record(ctx, e, slot, graph.JobTriggerSchedule, graph.JobStatusSkipped, "previous run still going")
+			continue
+		}
+
+		s.runs.Add(1)
+		go func() {
+			defer s.runs.Done()
+			defer e.running.Store(false)
+			runID := s.record(ctx, e, slot, graph.JobTriggerSchedule, graph.JobStatusRunning, "")
+			s.execute(ctx, e, runID, slot, graph.JobTriggerSchedule)
+		}()
+	}
+}
+
+// catchUp runs the slots missed since the last one recorded, in order, and
+// records those beyond the job's CatchUp as skipped, so the gap shows in its
+// history. A job that has never been scheduled has nothing to catch up.
+func (s *Scheduler) catchUp(ctx context.Context, e *entry) {
+	resp, err := graph.ReadLastJobSlot(ctx, s.client, e.Name)
+	if err != nil {
+		log.Error().Err(err).Str("Job", e.Name).Msg("Failed to read last slot, not catching up")
+		return
+	}
+	if resp.ReadLastJobSlot == nil {
+		return
+	}
+
+	last := time.Unix(int64(*resp.ReadLastJobSlot), 0).UTC()
+	skip, slots := SplitMissed(MissedSlots(e.schedule, last, time.Now().UTC(), maxMissed), e.CatchUp)
+	if len(skip) > 0 {
+		log.Warn().Str("Job", e.Name).Int("Slots", len(skip)).Time("Since", last).Msg("Skipping missed slots")
+	}
+	for _, slot := range skip {
+		s.record(ctx, e, slot, graph.JobTriggerCatchUp, graph.JobStatusSkipped, "missed while the scheduler was down")
+	}
+
+	if len(slots) > 0 {
+		log.Info().Str("Job", e.Name).Int("Slots", len(slots)).Time("Since", last).Msg("Catching up missed slots")
+	}
+	for _, slot := range slots {
+		if ctx.Err() != nil {
+			return
+		}
+		runID := s.record(ctx, e, slot, graph.JobTriggerCatchUp, graph.JobStatusRunning, "")
+		s.execute(ctx, e, runID, slot, graph.JobTriggerCatchUp)
+	}
+}
+
+// pollTriggers starts queued manual runs of jobs that are not running. A job
+// that is running keeps its manual run queued until it has finished.
+func (s *Scheduler) pollTriggers(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, e := range s.jobs {
+			if !e.running.CompareAndSwap(false, true) {
+				continue
+			}
+
+			resp, err := graph.ClaimJobRun(ctx, s.client, e.Name)
+			if err != nil || resp.ClaimJobRun == nil {
+				if err != nil {
+					log.Error().Err(err).Str("Job", e.Name).Msg("Failed to claim queued run")
+				}
+				e.running.Store(false)
+				continue
+			}
+
+			runID := resp.ClaimJobRun.RunID
+			log.Info().Str("Job", e.Name).Str("RunID", runID).Msg("Starting manual run")
+			s.runs.Add(1)
+			go func() {
+				defer s.runs.Done()
+				defer e.running.Store(false)
+				s.execute(ctx, e, runID, time.Now().UTC(), graph.JobTriggerManual)
+			}()
+		}
+	}
+}
+
+// execute runs the job and records how it finished. The job is not cancelled
+// when the scheduler stops, so a run in progress is never left half done.
+func (s *Scheduler) execute(ctx context.Context, e *entry, runID string, slot time.Time, trigger graph.JobTrigger) {
+	ctx = context.WithoutCancel(ctx)
+
+	started := time.Now()
+	err := e.Run(ctx, slot, trigger)
+	duration := time.Since(started)
+
+	result := graph.JobRunResult{
+		Status:     graph.JobStatusSucceeded,
+		DurationMs: int(duration.Milliseconds()),
+	}
+	if err != nil {
+		log.Error().Err(err).Str("Job", e.Name).Time("Slot", slot).Msg("Job run failed")
+		message := err.Error()
+		result.Status, result.Error = graph.JobStatusFailed, &message
+	} else {
+		log.Info().Str("Job", e.Name).Time("Slot", slot).Dur("Duration", duration).Msg("Job run succeeded")
+	}
+
+	if runID == "" {
+		return
+	}
+	if _, err := graph.FinishJobRun(ctx, s.client, runID, result); err != nil {
+		log.Error().Err(err).Str("Job", e.Name).Str("RunID", runID).Msg("Failed to record job run result")
+	}
+}
+
+// record adds a run of the job to JobRuns and returns its ID, or an empty ID
+// if it could not be recorded; the job still runs.
+func (s *Scheduler) record(ctx context.Context, e *entry, slot time.Time, trigger graph.JobTrigger, status graph.JobStatus, reason string) string {
+	unix := int(slot.Unix())
+	input := graph.JobRunInput{
+		Job:     e.Name,
+		Slot:    &unix,
+		Trigger: trigger,
+		Status:  status,
+	}
+	if reason != "" {
+		input.Error = &reason
+	}
+
+	resp, err := graph.CreateJobRun(context.WithoutCancel(ctx), s.client, input)
+	if err != nil {
+		log.Error().Err(err).Str("Job", e.Name).Time("Slot", slot).Msg("Failed to record job run")
+		return ""
+	}
+	return resp.CreateJobRun.RunID
+}
@@ -0,0 +1,117 @@
+// Package stats provides summary statistics over a sample of values, sorted
+// once so that top-N means, percentiles and histograms are cheap to repeat.
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"cryptobotmanager.com/cbm-backend/shared"
+)
+
+// Sample is a set of values sorted highest first.
+type Sample struct {
+	values []float64
+	sum    float64
+}
+
+// New returns a sample of the values. The slice is copied, not reordered.
+func New(values []float64) Sample {
+	sorted := append([]float64(nil), values...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	return Sample{values: sorted, sum: sum}
+}
+
+// FromGainers returns a sample of the gainers' IncrementPriceGain.
+func FromGainers(gainers []shared.Gainers) Sample {
+	values := make([]float64, len(gainers))
+	for i, g := range gainers {
+		values[i] = g.IncrementPriceGain
+	}
+	return New(values)
+}
+
+// Len returns the number of values in the sample.
+func (s Sample) Len() int {
+	return len(s.values)
+}
+
+// Mean returns the mean of the sample, or zero if it is empty.
+func (s Sample) Mean() float64 {
+	if len(s.values) == 0 {
+		return 0
+	}
+	return s.sum / float64(len(s.values))
+}
+
+// TopMean returns the mean of the n highest values. An n larger than the
+// sample uses the whole sample; an empty sample or n below one gives zero.
+func (s Sample) TopMean(n int) float64 {
+	n = min(n, len(s.values))
+	if n <= 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range s.values[:n] {
+		sum += v
+	}
+	return sum / float64(n)
+}
+
+// Percentile returns the p-th percentile (0-100), interpolating between the
+// closest ranks. p is clamped to the range; an empty sample gives zero.
+func (s Sample) Percentile(p float64) float64 {
+	if len(s.values) == 0 {
+		return 0
+	}
+	p = math.Max(0, math.Min(100, p))
+
+	// Values are held highest first, so rank from the end.
+	rank := p / 100 * float64(len(s.values)-1)
+	lower, upper := int(math.Floor(rank)), int(math.Ceil(rank))
+	last := len(s.values) - 1
+	low, high := s.values[last-lower], s.values[last-upper]
+	return low + (high-low)*(rank-float64(lower))
+}
+
+// Median returns the 50th percentile.
+func (s Sample) Median() float64 {
+	return s.Percentile(50)
+}
+
+// StdDev returns the population standard deviation of the sample, or zero if
+// it has fewer than two values.
+func (s Sample) StdDev() float64 {
+	if len(s.values) < 2 {
+		return 0
+	}
+
+	mean := s.Mean()
+	var squares float64
+	for _, v := range s.values {
+		squares += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(squares / float64(len(s.values)))
+}
+
+// Histogram counts the values between ascending edges. It returns one more
+// count than there are edges: below the first edge, each [edge, next edge),
+// and at or above the last edge.
+func (s Sample) Histogram(edges []float64) []int {
+	counts := make([]int, len(edges)+1)
+	for _, v := range s.values {
+		counts[Bin(v, edges)]++
+	}
+	return counts
+}
+
+// Bin returns the index of the histogram count the value falls in.
+func Bin(value float64, edges []float64) int {
+	return sort.Search(len(edges), func(i int) bool { return value < edges[i] })
+}
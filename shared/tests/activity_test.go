@@ -0,0 +1,38 @@
+package shared_test
+
+import (
+	"reflect"
+	"testing"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+func TestResolveMentions(t *testing.T) {
+	users := []*model.User{
+		{ID: "u1", FirstName: "Mark", LastName: "Smith", Email: "mark@example.com"},
+		{ID: "u2", FirstName: "Ana", LastName: "Lopez", Email: "ana.lopez@example.com"},
+		{ID: "u3", FirstName: "Sam", LastName: "Reed", Email: "sreed@example.com"},
+		{ID: "u4", FirstName: "Sam", LastName: "Cole", Email: "scole@example.com"},
+		{ID: "u5", FirstName: "Old", LastName: "Account", Email: "old@example.com", IsDeleted: true},
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"first name", "@mark can you look?", []string{"u1"}},
+		{"first.last and email", "cc @Ana.Lopez and @sreed@example.com.", []string{"u2", "u3"}},
+		{"email local part", "(@scole)", []string{"u4"}},
+		{"shared first name is ambiguous", "@sam please", []string{}},
+		{"repeated mention", "@mark @mark.smith @mark", []string{"u1"}},
+		{"email address is not a mention", "mail mark@example.com", []string{}},
+		{"unknown and deleted users", "@nobody @old", []string{}},
+	}
+	for _, tt := range tests {
+		if got := database.ResolveMentions(tt.body, users); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ResolveMentions(%q) = %v, want %v", tt.name, tt.body, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package shared_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/auth"
+)
+
+func TestTokenRoundTrip(t *testing.T) {
+	if err := auth.SetSecret(""); err == nil {
+		t.Error("SetSecret of an empty key expected an error")
+	}
+	if err := auth.SetSecret("test-secret"); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+
+	token, err := auth.IssueToken("u1", "admin", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	user, err := auth.ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if user.ID != "u1" || user.Role != "admin" {
+		t.Errorf("ParseToken = %+v, want u1 admin", user)
+	}
+
+	expired, err := auth.IssueToken("u1", "admin", -time.Minute)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+	for name, token := range map[string]string{
+		"expired":  expired,
+		"tampered": token[:len(token)-2] + "xx",
+		"garbage":  "not-a-token",
+	} {
+		if _, err := auth.ParseToken(token); err == nil {
+			t.Errorf("ParseToken of a %s token expected an error", name)
+		}
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name string
+		user *auth.User
+		code string
+	}{
+		{"admin", &auth.User{ID: "u1", Role: auth.RoleAdmin}, ""},
+		{"member", &auth.User{ID: "u2", Role: "member"}, auth.CodeForbidden},
+		{"no user", nil, auth.CodeUnauthenticated},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.user != nil {
+			ctx = auth.WithUser(ctx, tt.user)
+		}
+
+		_, err := auth.RequireRole(ctx, auth.RoleAdmin)
+		code := ""
+		if authErr, ok := err.(*auth.Error); ok {
+			code = authErr.Code
+		} else if err != nil {
+			t.Fatalf("%s: RequireRole error %v is not an auth error", tt.name, err)
+		}
+		if code != tt.code {
+			t.Errorf("%s: RequireRole code = %q, want %q", tt.name, code, tt.code)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	if err := auth.SetSecret("test-secret"); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+	valid, err := auth.IssueToken("u1", "admin", time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+	expired, err := auth.IssueToken("u1", "admin", -time.Minute)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		user   string
+	}{
+		{"valid", "Bearer " + valid, "u1"},
+		{"no token", "", ""},
+		{"expired", "Bearer " + expired, ""},
+		{"not bearer", "Basic dTE6cGFzcw==", ""},
+	}
+	for _, tt := range tests {
+		var user string
+		handler := auth.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if u, ok := auth.UserFrom(r.Context()); ok {
+				user = u.ID
+			}
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, http.StatusOK)
+		}
+		if user != tt.user {
+			t.Errorf("%s: user = %q, want %q", tt.name, user, tt.user)
+		}
+	}
+}
@@ -0,0 +1,450 @@
+package shared_test
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/microservices/backTesting/functions"
+	"cryptobotmanager.com/cbm-backend/shared"
+)
+
+func TestRangeValues(t *testing.T) {
+	if got := (shared.IntRange{Min: 5, Max: 15, Step: 5}).Values(); !reflect.DeepEqual(got, []int{5, 10, 15}) {
+		t.Errorf("IntRange.Values() = %v", got)
+	}
+	if got := (shared.IntRange{Min: 7, Max: 7}).Values(); !reflect.DeepEqual(got, []int{7}) {
+		t.Errorf("IntRange.Values() with no step = %v", got)
+	}
+	if got := (shared.FloatRange{Min: 0.1, Max: 0.5, Step: 0.2}).Values(); !reflect.DeepEqual(got, []float64{0.1, 0.3, 0.5}) {
+		t.Errorf("FloatRange.Values() = %v", got)
+	}
+}
+
+func TestGeneratePermutations(t *testing.T) {
+	ranges := shared.ParameterRanges{
+		TradeDuration:        shared.IntRange{Min: 5, Max: 10, Step: 5},
+		ShortSMADuration:     shared.IntRange{Min: 2, Max: 4, Step: 2},
+		LongSMADuration:      shared.IntRange{Min: 4, Max: 4},
+		MovingAveMomentum:    shared.FloatRange{Min: 0.1, Max: 0.1},
+		TakeProfitPercentage: shared.FloatRange{Min: 1, Max: 2, Step: 1},
+		StopLossPercentage:   shared.FloatRange{Min: 1, Max: 1},
+		IncrementsATR:        shared.IntRange{Min: 10, Max: 10},
+		ATRtollerance:        shared.FloatRange{},
+	}
+
+	strategies := functions.GeneratePermutations(ranges, 1000, 1)
+
+	// short SMA 4 is not shorter than long SMA 4 so only short SMA 2 survives
+	if len(strategies) != 4 {
+		t.Fatalf("expected 4 permutations, got %d", len(strategies))
+	}
+	names := map[string]bool{}
+	for _, s := range strategies {
+		if s.ShortSMADuration >= s.LongSMADuration {
+			t.Errorf("%s has short SMA %d >= long SMA %d", s.BotInstanceName, s.ShortSMADuration, s.LongSMADuration)
+		}
+		if s.AccountBalance != 1000 {
+			t.Errorf("%s has balance %v", s.BotInstanceName, s.AccountBalance)
+		}
+		names[s.BotInstanceName] = true
+	}
+	if len(names) != len(strategies) {
+		t.Errorf("expected unique bot names, got %v", names)
+	}
+}
+
+func TestSamplePermutations(t *testing.T) {
+	var strategies []model.StrategyInput
+	for i := 0; i < 10; i++ {
+		strategies = append(strategies, model.StrategyInput{BotInstanceName: fmt.Sprint(i)})
+	}
+
+	tests := []struct {
+		name     string
+		sampling string
+		size     int
+		want     int
+		wantErr  bool
+	}{
+		{"all ignores size", "all", 3, 10, false},
+		{"grid", "grid", 5, 5, false},
+		{"random", "random", 4, 4, false},
+		{"size larger than permutations", "random", 20, 10, false},
+		{"unknown sampling", "best", 3, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := functions.SamplePermutations(strategies, tt.sampling, tt.size, 42)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("got %d strategies, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	grid, _ := functions.SamplePermutations(strategies, "grid", 5, 0)
+	var gridNames []string
+	for _, s := range grid {
+		gridNames = append(gridNames, s.BotInstanceName)
+	}
+	if !reflect.DeepEqual(gridNames, []string{"0", "2", "4", "6", "8"}) {
+		t.Errorf("grid sample = %v", gridNames)
+	}
+
+	first, _ := functions.SamplePermutations(strategies, "random", 4, 42)
+	second, _ := functions.SamplePermutations(strategies, "random", 4, 42)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("random sampling with the same seed should repeat")
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		balances []float64
+		want     float64
+	}{
+		{"no drawdown", []float64{100, 110, 120}, 0},
+		{"single dip", []float64{100, 80, 120}, 20},
+		{"deepest after new peak", []float64{100, 90, 200, 150}, 25},
+		{"empty", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := functions.MaxDrawdown(tt.balances); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("MaxDrawdown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharpeRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		returns []float64
+		want    float64
+	}{
+		{"too few returns", []float64{1}, 0},
+		{"no spread", []float64{1, 1, 1}, 0},
+		{"mean over std dev", []float64{1, 3}, 2 / math.Sqrt2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := functions.SharpeRatio(tt.returns); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("SharpeRatio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// snapshots builds five minute snapshots from a price path per symbol.
+func snapshots(start int, paths map[string][]string) []model.NewHistoricPriceInput {
+	var length int
+	for _, path := range paths {
+		length = len(path)
+	}
+	var out []model.NewHistoricPriceInput
+	for i := 0; i < length; i++ {
+		snapshot := model.NewHistoricPriceInput{Timestamp: start + i*300}
+		for symbol, path := range paths {
+			snapshot.Pairs = append(snapshot.Pairs, &model.PairInput{Symbol: symbol, Price: path[i]})
+		}
+		out = append(out, snapshot)
+	}
+	return out
+}
+
+func TestRunBacktest(t *testing.T) {
+	details := model.StrategyInput{
+		BotInstanceName:      "test",
+		TradeDuration:        30,
+		ShortSMADuration:     2,
+		LongSMADuration:      3,
+		MovingAveMomentum:    0.1,
+		TakeProfitPercentage: 2,
+		StopLossPercentage:   2,
+		AccountBalance:       1000,
+	}
+
+	tests := []struct {
+		name        string
+		paths       map[string][]string
+		wantOutcome []string
+	}{
+		{
+			name:        "take profit",
+			paths:       map[string][]string{"AAAUSDT": {"100", "100", "101", "101", "104"}},
+			wantOutcome: []string{"WIN"},
+		},
+		{
+			name:        "stop loss",
+			paths:       map[string][]string{"AAAUSDT": {"100", "100", "101", "101", "98"}},
+			wantOutcome: []string{"LOSS"},
+		},
+		{
+			name:        "closed when data runs out",
+			paths:       map[string][]string{"AAAUSDT": {"100", "100", "101", "101", "101.5"}},
+			wantOutcome: []string{"TIMED OUT"},
+		},
+		{
+			name:        "no momentum",
+			paths:       map[string][]string{"AAAUSDT": {"100", "100", "100", "100", "100"}},
+			wantOutcome: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := functions.NewPriceSeries(snapshots(1000, tt.paths), 0.1)
+			result := functions.RunBacktest(series, details, 0.06)
+
+			var outcomes []string
+			for _, trade := range result.Trades {
+				outcomes = append(outcomes, trade.Outcome)
+			}
+			if !reflect.DeepEqual(outcomes, tt.wantOutcome) {
+				t.Fatalf("outcomes = %v, want %v", outcomes, tt.wantOutcome)
+			}
+
+			metrics := result.Metrics()
+			if metrics.Trades != len(tt.wantOutcome) {
+				t.Errorf("metrics trades = %d", metrics.Trades)
+			}
+			if got := result.EndingBalance - result.StartingBalance; got != metrics.NetPnL {
+				t.Errorf("NetPnL = %v, want %v", metrics.NetPnL, got)
+			}
+		})
+	}
+}
+
+func TestRunBacktestSentimentGate(t *testing.T) {
+	minFearGreed := 50
+	details := model.StrategyInput{
+		BotInstanceName:      "gated",
+		TradeDuration:        30,
+		ShortSMADuration:     2,
+		LongSMADuration:      3,
+		MovingAveMomentum:    0.1,
+		TakeProfitPercentage: 2,
+		StopLossPercentage:   2,
+		AccountBalance:       1000,
+		MinFearGreed:         &minFearGreed,
+	}
+	paths := map[string][]string{"AAAUSDT": {"100", "100", "101", "101", "104"}}
+
+	tests := []struct {
+		name       string
+		reading    *functions.FearGreedReading
+		wantTrades int
+	}{
+		{"greedy enough", &functions.FearGreedReading{Index: 70, Classification: "Greed"}, 1},
+		{"too fearful", &functions.FearGreedReading{Index: 20, Classification: "Extreme Fear"}, 0},
+		{"no reading", nil, 0},
+	}
+	for _, tt := range tests {
+		series := functions.NewPriceSeries(snapshots(1000, paths), 0.1)
+		if tt.reading != nil {
+			series.SetFearGreed(1000, *tt.reading)
+		}
+		if got := len(functions.RunBacktest(series, details, 0.06).Trades); got != tt.wantTrades {
+			t.Errorf("%s: %d trades, want %d", tt.name, got, tt.wantTrades)
+		}
+	}
+
+	// Strategies without bounds trade whatever the sentiment
+	details.MinFearGreed = nil
+	series := functions.NewPriceSeries(snapshots(1000, paths), 0.1)
+	if got := len(functions.RunBacktest(series, details, 0.06).Trades); got != 1 {
+		t.Errorf("ungated: %d trades, want 1", got)
+	}
+}
+
+func TestRankResults(t *testing.T) {
+	results := []functions.BacktestResult{
+		{Strategy: model.StrategyInput{BotInstanceName: "small"}, StartingBalance: 100, EndingBalance: 105},
+		{Strategy: model.StrategyInput{BotInstanceName: "big"}, StartingBalance: 100, EndingBalance: 120},
+		{Strategy: model.StrategyInput{BotInstanceName: "loss"}, StartingBalance: 100, EndingBalance: 90},
+	}
+
+	ranked, err := functions.RankResults(results, "pnl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, r := range ranked {
+		order = append(order, fmt.Sprintf("%d:%s", r.Rank, r.Strategy.BotInstanceName))
+	}
+	if !reflect.DeepEqual(order, []string{"1:big", "2:small", "3:loss"}) {
+		t.Errorf("ranked order = %v", order)
+	}
+
+	if _, err := functions.RankResults(results, "luck"); err == nil {
+		t.Errorf("expected an error for an unknown ranking")
+	}
+}
+
+func TestBacktestMetrics(t *testing.T) {
+	result := functions.BacktestResult{
+		From:            0,
+		To:              4000,
+		StartingBalance: 100,
+		EndingBalance:   105,
+		Trades: []functions.BacktestTrade{
+			{OpenTime: 0, CloseTime: 600, Balance: 110},
+			{OpenTime: 1000, CloseTime: 1600, Balance: 99},
+			{OpenTime: 2000, CloseTime: 2800, Balance: 105},
+		},
+	}
+
+	metrics := result.Metrics()
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"net pnl", metrics.NetPnL, 5},
+		{"win rate", metrics.WinRate, 200.0 / 3},
+		{"max drawdown", metrics.MaxDrawdown, 10},
+		{"profit factor", metrics.ProfitFactor, 16.0 / 11},
+		{"expectancy", metrics.Expectancy, 5.0 / 3},
+		{"average hold time", metrics.AvgHoldTime, 2000.0 / 3},
+		{"exposure", metrics.Exposure, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if metrics.Sortino <= 0 || metrics.Sharpe <= 0 {
+		t.Errorf("expected positive Sharpe and Sortino for a profitable run, got %+v", metrics)
+	}
+	if metrics.CAGR != 0 {
+		t.Errorf("CAGR of a run too short to annualise = %v, want 0", metrics.CAGR)
+	}
+
+	curve := result.EquityCurve()
+	if len(curve) != 4 || curve[0].Balance != 100 || curve[3].Timestamp != 2800 {
+		t.Errorf("unexpected equity curve %+v", curve)
+	}
+}
+
+func TestCAGR(t *testing.T) {
+	year := int(365.25 * 24 * 60 * 60)
+	day := 24 * 60 * 60
+	tests := []struct {
+		name    string
+		start   float64
+		end     float64
+		seconds int
+		want    float64
+	}{
+		{"one year", 100, 110, year, 10},
+		{"two years", 100, 121, 2 * year, 10},
+		{"no time", 100, 110, 0, 0},
+		{"shorter than 30 days", 100, 110, 29 * day, 0},
+		{"overflowing growth", 1, 1e30, 30 * day, 0},
+		{"no starting balance", 0, 110, year, 0},
+	}
+	for _, tt := range tests {
+		if got := functions.CAGR(tt.start, tt.end, tt.seconds); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: CAGR = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPriceSeriesDatasetID(t *testing.T) {
+	paths := map[string][]string{"AAAUSDT": {"1", "2"}, "BBBUSDT": {"3", "4"}}
+	first := functions.NewPriceSeries(snapshots(0, paths), 0.1)
+	second := functions.NewPriceSeries(snapshots(0, paths), 0.1)
+	changed := functions.NewPriceSeries(snapshots(0, map[string][]string{"AAAUSDT": {"1", "2.5"}, "BBBUSDT": {"3", "4"}}), 0.1)
+
+	if first.DatasetID == "" || first.DatasetID != second.DatasetID {
+		t.Errorf("same snapshots should share a dataset ID: %q vs %q", first.DatasetID, second.DatasetID)
+	}
+	if first.DatasetID == changed.DatasetID {
+		t.Errorf("different prices should change the dataset ID")
+	}
+}
+
+func TestSplitWalkForward(t *testing.T) {
+	// A snapshot every six hours for just under ten days
+	var data []model.NewHistoricPriceInput
+	for i := 0; i < 40; i++ {
+		data = append(data, model.NewHistoricPriceInput{
+			Timestamp: i * 6 * 60 * 60,
+			Pairs:     []*model.PairInput{{Symbol: "AAAUSDT", Price: "1"}},
+		})
+	}
+	series := functions.NewPriceSeries(data, 0.1)
+
+	windows := functions.SplitWalkForward(series, 4, 2)
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 windows, got %d", len(windows))
+	}
+
+	day := 24 * 60 * 60
+	for i, w := range windows {
+		if got := w.InSample.Timestamps[0]; got != i*2*day {
+			t.Errorf("window %d in-sample starts at %d", i, got)
+		}
+		if got := w.OutOfSample.Timestamps[0]; got != (i*2+4)*day {
+			t.Errorf("window %d out-of-sample starts at %d", i, got)
+		}
+		if last := w.InSample.Timestamps[len(w.InSample.Timestamps)-1]; last >= w.OutOfSample.Timestamps[0] {
+			t.Errorf("window %d in-sample overlaps its out-of-sample period", i)
+		}
+	}
+
+	if got := functions.SplitWalkForward(series, 20, 2); got != nil {
+		t.Errorf("expected no windows when the in-sample period is longer than the data, got %d", len(got))
+	}
+}
+
+func TestCombineResults(t *testing.T) {
+	combined := functions.CombineResults([]functions.BacktestResult{
+		{From: 0, To: 100, StartingBalance: 100, EndingBalance: 110, Trades: []functions.BacktestTrade{{Balance: 110}}},
+		{From: 100, To: 200, StartingBalance: 110, EndingBalance: 99, Trades: []functions.BacktestTrade{{Balance: 99}}},
+	})
+
+	if combined.From != 0 || combined.To != 200 || combined.StartingBalance != 100 || combined.EndingBalance != 99 || len(combined.Trades) != 2 {
+		t.Errorf("unexpected combined result %+v", combined)
+	}
+}
+
+func TestMeetsThresholds(t *testing.T) {
+	thresholds := shared.PromotionThresholds{MinTrades: 10, MinWinRate: 50, MinSharpe: 0.1, MaxDrawdown: 10, MinProfitableWindows: 50}
+	good := functions.BacktestMetrics{Trades: 12, WinRate: 60, Sharpe: 0.3, MaxDrawdown: 5}
+
+	tests := []struct {
+		name       string
+		metrics    functions.BacktestMetrics
+		profitable float64
+		want       bool
+	}{
+		{"meets every threshold", good, 75, true},
+		{"too few trades", functions.BacktestMetrics{Trades: 5, WinRate: 60, Sharpe: 0.3, MaxDrawdown: 5}, 75, false},
+		{"drawdown too deep", functions.BacktestMetrics{Trades: 12, WinRate: 60, Sharpe: 0.3, MaxDrawdown: 12}, 75, false},
+		{"too few profitable windows", good, 25, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := functions.MeetsThresholds(tt.metrics, tt.profitable, thresholds); got != tt.want {
+				t.Errorf("MeetsThresholds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package shared_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/backup"
+	"cryptobotmanager.com/cbm-backend/microservices/dataManager/functions"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// writeArchive writes an archive of the collections' documents.
+func writeArchive(t *testing.T, collections map[string][]bson.M, order []string) []byte {
+	t.Helper()
+	started := time.Date(2025, 5, 20, 9, 0, 0, 0, time.UTC)
+
+	var b bytes.Buffer
+	w := backup.NewWriter(&b, "go_trading_db", started)
+	for _, name := range order {
+		index, _ := bson.Marshal(bson.D{{Key: "v", Value: 2}, {Key: "key", Value: bson.D{{Key: "id", Value: 1}}}, {Key: "name", Value: "id_1"}})
+		out, err := w.Collection(name, []bson.Raw{index})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, doc := range collections[name] {
+			raw, _ := bson.Marshal(doc)
+			if err := out.Write(raw); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := w.Close(started.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestBackupArchiveRoundTrip(t *testing.T) {
+	collections := map[string][]bson.M{
+		"Tasks":          {{"id": "t1", "title": "Call"}, {"id": "t2", "title": "Write", "duration": int32(5)}},
+		"CalendarTokens": {},
+	}
+	data := writeArchive(t, collections, []string{"Tasks", "CalendarTokens"})
+
+	archive, err := backup.Open(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := archive.Manifest
+	if manifest.FormatVersion != backup.FormatVersion || manifest.Database != "go_trading_db" || manifest.StartedAt != "2025-05-20T09:00:00Z" || manifest.FinishedAt != "2025-05-20T09:01:00Z" {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	if len(manifest.Collections) != 2 || manifest.Collections[0].Documents != 2 || manifest.Collections[1].Documents != 0 || len(manifest.Collections[0].Indexes) != 1 {
+		t.Fatalf("manifest collections = %+v", manifest.Collections)
+	}
+	if err := archive.Verify(); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	var got []bson.M
+	err = archive.Documents(manifest.Collections[0], func(doc bson.Raw) error {
+		var m bson.M
+		if err := bson.Unmarshal(doc, &m); err != nil {
+			return err
+		}
+		delete(m, "_id")
+		got = append(got, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, collections["Tasks"]) {
+		t.Errorf("documents = %v, want %v", got, collections["Tasks"])
+	}
+}
+
+func TestBackupArchiveTampered(t *testing.T) {
+	data := writeArchive(t, map[string][]bson.M{"Tasks": {{"id": "t1", "title": "Call"}}}, []string{"Tasks"})
+
+	// Rewrite the archive with a document changed but the manifest kept.
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for _, file := range reader.File {
+		in, _ := file.Open()
+		content, _ := io.ReadAll(in)
+		in.Close()
+		if strings.HasSuffix(file.Name, ".bson") {
+			content = bytes.Replace(content, []byte("Call"), []byte("Cull"), 1)
+		}
+		out, _ := w.Create(file.Name)
+		out.Write(content)
+	}
+	w.Close()
+
+	archive, err := backup.Open(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Verify(); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("Verify = %v, want a checksum error", err)
+	}
+
+	// A backup cut short has no manifest.
+	if _, err := backup.Open(bytes.NewReader(data[:len(data)/2]), int64(len(data)/2)); err == nil {
+		t.Error("Open of a truncated archive succeeded")
+	}
+}
+
+func TestBackupFilter(t *testing.T) {
+	filter := backup.Filter{Include: backup.ParseList("Tasks, Projects,,HistoricPrices"), Exclude: backup.ParseList("HistoricPrices")}
+	if want := []string{"Tasks", "Projects", "HistoricPrices"}; !reflect.DeepEqual(filter.Include, want) {
+		t.Errorf("ParseList = %q, want %q", filter.Include, want)
+	}
+
+	tests := []struct {
+		filter backup.Filter
+		name   string
+		want   bool
+	}{
+		{backup.Filter{}, "Customers", true},
+		{filter, "Tasks", true},
+		{filter, "Customers", false},
+		{filter, "HistoricPrices", false},
+		{backup.Filter{Exclude: []string{"HistoricPrices"}}, "Customers", true},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Match(tt.name); got != tt.want {
+			t.Errorf("%+v Match(%s) = %v, want %v", tt.filter, tt.name, got, tt.want)
+		}
+	}
+
+	if got, want := functions.BackupURL("http://cbm-api:8080/", "backup", backup.Filter{Exclude: []string{"HistoricPrices", "HistoricKlineData"}}), "http://cbm-api:8080/backup?exclude=HistoricPrices%2CHistoricKlineData"; got != want {
+		t.Errorf("BackupURL = %s, want %s", got, want)
+	}
+}
+
+func TestBackupManifestSnapshot(t *testing.T) {
+	for _, snapshot := range []bool{false, true} {
+		var b bytes.Buffer
+		w := backup.NewWriter(&b, "go_trading_db", time.Date(2025, 5, 20, 9, 0, 0, 0, time.UTC))
+		if snapshot {
+			w.MarkSnapshot()
+		}
+		if _, err := w.Close(time.Date(2025, 5, 20, 9, 1, 0, 0, time.UTC)); err != nil {
+			t.Fatal(err)
+		}
+
+		archive, err := backup.Open(bytes.NewReader(b.Bytes()), int64(b.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if archive.Manifest.Snapshot != snapshot {
+			t.Errorf("manifest Snapshot = %v, want %v", archive.Manifest.Snapshot, snapshot)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package shared_test
+
+import (
+	"testing"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	reports "cryptobotmanager.com/cbm-backend/microservices/reports/functions"
+)
+
+func TestQuoteAsset(t *testing.T) {
+	tests := []struct {
+		symbol string
+		want   string
+	}{
+		{"BTCUSDT", "USDT"},
+		{"ETHBTC", "BTC"},
+		{"SOLFDUSD", "FDUSD"},
+		{"BNBEUR", "EUR"},
+		{"USDT", reports.OtherQuoteAsset},
+		{"ABCXYZ", reports.OtherQuoteAsset},
+	}
+	for _, tt := range tests {
+		if got := reports.QuoteAsset(tt.symbol); got != tt.want {
+			t.Errorf("QuoteAsset(%q) = %q, want %q", tt.symbol, got, tt.want)
+		}
+	}
+}
+
+func TestBuildMarketBreadth(t *testing.T) {
+	pair := func(symbol, change string) model.Pair {
+		return model.Pair{Symbol: symbol, Price: "1", PercentageChange: &change}
+	}
+
+	tests := []struct {
+		name          string
+		market        []model.Pair
+		volumes       map[string]float64
+		wantPairs     int
+		wantAdvancers int
+		wantDecliners int
+		wantUnchanged int
+		wantOnTheMove int
+		wantMedian    float64
+		wantMean      float64
+		wantVolume    *float64
+		wantQuotes    int
+	}{
+		{
+			name:   "empty market",
+			market: nil,
+		},
+		{
+			name:          "mixed market without volumes",
+			market:        []model.Pair{pair("AUSDT", "1.5"), pair("BUSDT", "-0.5"), pair("CBTC", "0"), pair("DBTC", "3")},
+			wantPairs:     4,
+			wantAdvancers: 2,
+			wantDecliners: 1,
+			wantUnchanged: 1,
+			wantOnTheMove: 2,
+			wantMedian:    0.75,
+			wantMean:      1,
+			wantQuotes:    2,
+		},
+		{
+			name:          "volume share and unparsable changes",
+			market:        []model.Pair{pair("AUSDT", "2"), pair("BUSDT", "-1"), pair("CUSDT", "n/a"), {Symbol: "DUSDT", Price: "1"}},
+			volumes:       map[string]float64{"AUSDT": 300, "BUSDT": 100},
+			wantPairs:     2,
+			wantAdvancers: 1,
+			wantDecliners: 1,
+			wantOnTheMove: 1,
+			wantMedian:    0.5,
+			wantMean:      0.5,
+			wantVolume:    func() *float64 { v := 75.0; return &v }(),
+			wantQuotes:    1,
+		},
+	}
+	for _, tt := range tests {
+		got := reports.BuildMarketBreadth(tt.market, tt.volumes, 1, 100)
+
+		if got.Pairs != tt.wantPairs || got.Advancers != tt.wantAdvancers || got.Decliners != tt.wantDecliners || got.Unchanged != tt.wantUnchanged || got.OnTheMove != tt.wantOnTheMove {
+			t.Errorf("%s: counts = %d/%d/%d/%d/%d, want %d/%d/%d/%d/%d", tt.name,
+				got.Pairs, got.Advancers, got.Decliners, got.Unchanged, got.OnTheMove,
+				tt.wantPairs, tt.wantAdvancers, tt.wantDecliners, tt.wantUnchanged, tt.wantOnTheMove)
+		}
+		if got.MedianChange != tt.wantMedian || got.MeanChange != tt.wantMean {
+			t.Errorf("%s: median/mean = %v/%v, want %v/%v", tt.name, got.MedianChange, got.MeanChange, tt.wantMedian, tt.wantMean)
+		}
+		if (got.VolumeShareMoving == nil) != (tt.wantVolume == nil) || (got.VolumeShareMoving != nil && *got.VolumeShareMoving != *tt.wantVolume) {
+			t.Errorf("%s: VolumeShareMoving = %v, want %v", tt.name, got.VolumeShareMoving, tt.wantVolume)
+		}
+		if len(got.QuoteAssets) != tt.wantQuotes {
+			t.Errorf("%s: %d quote assets, want %d", tt.name, len(got.QuoteAssets), tt.wantQuotes)
+		}
+
+		var bucketed int
+		for _, bucket := range got.Buckets {
+			bucketed += bucket.Count
+		}
+		if bucketed != tt.wantPairs {
+			t.Errorf("%s: %d pairs bucketed, want %d", tt.name, bucketed, tt.wantPairs)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package shared_test
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/calendar"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+)
+
+func TestCalendarFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  calendar.Filter
+	}{
+		{"no filter", "", calendar.Filter{}},
+		{"comma separated", "labels=urgent,%20ops&departments=Finance", calendar.Filter{Labels: []string{"urgent", "ops"}, Departments: []string{"Finance"}}},
+		{"repeated", "labels=urgent&labels=ops,&tasksAsEvents=true", calendar.Filter{Labels: []string{"urgent", "ops"}, TasksAsEvents: true}},
+	}
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		got := calendar.ParseFilter(query)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ParseFilter = %+v, want %+v", tt.name, got, tt.want)
+		}
+		if again := calendar.ParseFilter(mustParseQuery(t, got.Encode())); !reflect.DeepEqual(again, got) {
+			t.Errorf("%s: filter does not survive encoding, got %+v", tt.name, again)
+		}
+	}
+
+	str := func(s string) *string { return &s }
+	task := &model.Task{Labels: []*string{str("ops")}, Department: str("Finance")}
+	matches := []struct {
+		filter calendar.Filter
+		want   bool
+	}{
+		{calendar.Filter{}, true},
+		{calendar.Filter{Labels: []string{"urgent", "ops"}}, true},
+		{calendar.Filter{Labels: []string{"urgent"}}, false},
+		{calendar.Filter{Departments: []string{"Finance"}}, true},
+		{calendar.Filter{Departments: []string{"Legal"}, Labels: []string{"ops"}}, false},
+	}
+	for _, tt := range matches {
+		if got := tt.filter.MatchTask(task); got != tt.want {
+			t.Errorf("MatchTask with %+v = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func mustParseQuery(t *testing.T, query string) url.Values {
+	t.Helper()
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return values
+}
+
+func TestCalendarRender(t *testing.T) {
+	now := time.Date(2025, 5, 20, 9, 30, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+
+	feed := calendar.Feed{
+		Name: "Ada Lovelace tasks",
+		Tasks: []*model.Task{
+			{ID: "t1", Title: "File VAT; Q2, draft", Status: gtd.NextAction, DueDate: str("2025-05-23"), DeferDate: str("2025-05-21"), Labels: []*string{str("tax")}},
+			{ID: "t2", Title: "Call broker", Status: gtd.WaitingFor, DueDate: str("2025-05-22T14:00:00+02:00"), Description: str("line one\nline two")},
+			{ID: "t3", Title: "Undated", Status: gtd.Inbox},
+		},
+		Projects: []*model.Project{
+			{ID: "p1", Title: "Audit", DueDate: str("2025-06-30")},
+			{ID: "p2", Title: "Undated project"},
+		},
+	}
+
+	var b strings.Builder
+	if err := calendar.Render(&b, feed, now); err != nil {
+		t.Fatal(err)
+	}
+	ics := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Ada Lovelace tasks\r\n",
+		"BEGIN:VTODO\r\nUID:task-t1@cbm-api\r\nDTSTAMP:20250520T093000Z\r\nDTSTART;VALUE=DATE:20250521\r\nDUE;VALUE=DATE:20250523\r\nSTATUS:NEEDS-ACTION\r\nSUMMARY:File VAT\\; Q2\\, draft\r\nCATEGORIES:tax\r\nEND:VTODO\r\n",
+		"DUE:20250522T120000Z\r\n",
+		"DESCRIPTION:line one\\nline two\r\n",
+		"BEGIN:VEVENT\r\nUID:project-p1@cbm-api\r\nDTSTAMP:20250520T093000Z\r\nDTSTART;VALUE=DATE:20250630\r\nSUMMARY:Audit due\r\nEND:VEVENT\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("feed is missing %q:\n%s", want, ics)
+		}
+	}
+	for _, unwanted := range []string{"task-t3", "project-p2"} {
+		if strings.Contains(ics, unwanted) {
+			t.Errorf("feed includes undated %s", unwanted)
+		}
+	}
+
+	feed.Filter.TasksAsEvents = true
+	b.Reset()
+	if err := calendar.Render(&b, feed, now); err != nil {
+		t.Fatal(err)
+	}
+	if want := "BEGIN:VEVENT\r\nUID:task-t1@cbm-api\r\nDTSTAMP:20250520T093000Z\r\nDTSTART;VALUE=DATE:20250523\r\n"; !strings.Contains(b.String(), want) {
+		t.Errorf("tasks as events is missing %q:\n%s", want, b.String())
+	}
+	if strings.Contains(b.String(), "VTODO") {
+		t.Errorf("tasks as events still has to-dos:\n%s", b.String())
+	}
+}
+
+func TestCalendarLineFolding(t *testing.T) {
+	str := func(s string) *string { return &s }
+	title := strings.Repeat("é", 100)
+	feed := calendar.Feed{Tasks: []*model.Task{{ID: "t1", Title: title, Status: gtd.Inbox, DueDate: str("2025-05-23")}}}
+
+	var b strings.Builder
+	if err := calendar.Render(&b, feed, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("fold split a character: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(b.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+title+"\r\n") {
+		t.Errorf("unfolded feed lost the summary:\n%s", unfolded)
+	}
+}
@@ -0,0 +1,147 @@
+package shared_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/microservices/dataManager/functions"
+	"cryptobotmanager.com/cbm-backend/shared/graph"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	estimate := 30
+	export := functions.NewExport(
+		[]functions.Project{{ID: "p1", Title: "Onboarding", Sop: true, Labels: []string{"call"}, Status: "active"}},
+		[]functions.Task{{ID: "t1", Title: "Call", Status: "inbox", ProjectID: "p1", Estimate: &estimate}},
+		time.Date(2025, 5, 20, 9, 0, 0, 0, time.UTC),
+	)
+
+	var b bytes.Buffer
+	if err := functions.WriteExport(&b, export); err != nil {
+		t.Fatal(err)
+	}
+	got, err := functions.ReadExport(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, export) {
+		t.Errorf("ReadExport = %+v, want %+v", got, export)
+	}
+}
+
+func TestReadExportRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"unversioned", `{"projects": [], "tasks": []}`},
+		{"newer version", `{"version": 99, "projects": [], "tasks": []}`},
+		{"bare list", `[{"id": "t1", "title": "Call"}]`},
+		{"task without ID", `{"version": 1, "tasks": [{"title": "Call"}]}`},
+		{"duplicate project", `{"version": 1, "projects": [{"id": "p1", "title": "A"}, {"id": "p1", "title": "B"}]}`},
+	}
+	for _, tt := range tests {
+		if _, err := functions.ReadExport(strings.NewReader(tt.json)); err == nil {
+			t.Errorf("%s: ReadExport succeeded, want an error", tt.name)
+		}
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	one, two := 1, 2
+	export := &functions.Export{
+		Version: functions.Version,
+		Projects: []functions.Project{
+			{ID: "p1", Title: "Onboarding", Status: "active"},
+			{ID: "p2", Title: "Audit", Status: "active", Labels: []string{"q2"}},
+			{ID: "p3", Title: "New", Status: "active"},
+		},
+		Tasks: []functions.Task{
+			{ID: "t3", Title: "Blocked", Status: "inbox", BlockedBy: []string{"t2"}},
+			{ID: "t2", Title: "Subtask", Status: "inbox", ParentID: "t1"},
+			{ID: "t1", Title: "Parent", Status: "nextAction", Estimate: &two},
+			{ID: "t4", Title: "Same", Status: "inbox", Labels: []string{}},
+		},
+	}
+	existingProjects := []functions.Project{
+		{ID: "p1", Title: "Onboarding", Status: "active"},
+		{ID: "p2", Title: "Audit", Status: "completed"},
+	}
+	existingTasks := []functions.Task{
+		{ID: "t1", Title: "Parent", Status: "nextAction", Estimate: &one},
+		{ID: "t4", Title: "Same", Status: "inbox", Duration: &one},
+	}
+
+	plan := functions.PlanImport(export, existingProjects, existingTasks)
+
+	var projects []string
+	for _, step := range plan.Projects {
+		projects = append(projects, step.Project.ID+" "+string(step.Action)+" "+strings.Join(step.Changes, ","))
+	}
+	wantProjects := []string{"p1 skip ", "p2 update labels,status", "p3 create "}
+	if !reflect.DeepEqual(projects, wantProjects) {
+		t.Errorf("project steps = %q, want %q", projects, wantProjects)
+	}
+
+	var tasks []string
+	for _, step := range plan.Tasks {
+		tasks = append(tasks, step.Task.ID+" "+string(step.Action)+" "+strings.Join(step.Changes, ","))
+	}
+	wantTasks := []string{"t1 update estimate", "t2 create ", "t3 create ", "t4 skip "}
+	if !reflect.DeepEqual(tasks, wantTasks) {
+		t.Errorf("task steps = %q, want %q", tasks, wantTasks)
+	}
+
+	projectCounts, taskCounts := plan.Counts()
+	if want := (functions.Counts{Created: 1, Updated: 1, Skipped: 1}); projectCounts != want {
+		t.Errorf("project counts = %+v, want %+v", projectCounts, want)
+	}
+	if want := (functions.Counts{Created: 2, Updated: 1, Skipped: 1}); taskCounts != want {
+		t.Errorf("task counts = %+v, want %+v", taskCounts, want)
+	}
+
+	// Importing what the API now holds changes nothing.
+	again := functions.PlanImport(export, export.Projects, export.Tasks)
+	if projectCounts, taskCounts := again.Counts(); projectCounts.Skipped != 3 || taskCounts.Skipped != 4 {
+		t.Errorf("second import counts = %+v, %+v, want everything skipped", projectCounts, taskCounts)
+	}
+}
+
+func TestImportInputs(t *testing.T) {
+	task := functions.Task{ID: "t2", Title: "Call", Status: "inbox", ProjectID: "p1", ParentID: "t1", BlockedBy: []string{"t1", "t3"}}
+	create := functions.CreateTaskInput(task)
+	if create.Id == nil || *create.Id != "t2" || create.Description != nil || create.DueDate != nil || !create.Imported {
+		t.Errorf("CreateTaskInput = %+v, want the ID kept and unset fields nil", create)
+	}
+
+	update := functions.UpdateTaskInput(functions.Task{ID: "t2", Title: "Call", Status: "complete"}, []string{"status", "labels", "description"})
+	if update.Status == nil || *update.Status != "complete" || update.Title != nil || !update.Imported {
+		t.Errorf("UpdateTaskInput = %+v, want only the changed fields", update)
+	}
+	if update.Labels == nil || len(update.Labels) != 0 || update.Description == nil || *update.Description != "" {
+		t.Errorf("UpdateTaskInput = %+v, want cleared labels and description", update)
+	}
+}
+
+func TestAPIClientSendsToken(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"readAllTasks":[]}}`))
+	}))
+	defer srv.Close()
+
+	if _, err := graph.ReadAllTasks(context.Background(), functions.APIClient(srv.URL, "secret")); err != nil {
+		t.Fatal(err)
+	}
+	if got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+	}
+}
@@ -0,0 +1,104 @@
+package shared_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		wantCode string
+	}{
+		{"clarify inbox", gtd.Inbox, gtd.NextAction, ""},
+		{"same status", gtd.WaitingFor, gtd.WaitingFor, ""},
+		{"reopen complete", gtd.Complete, gtd.NextAction, ""},
+		{"activate someday", gtd.SomedayMaybe, gtd.Scheduled, ""},
+		{"legacy status moves anywhere", "todo", gtd.Complete, ""},
+		{"complete back to inbox", gtd.Complete, gtd.Inbox, gtd.CodeInvalidTransition},
+		{"next action back to inbox", gtd.NextAction, gtd.Inbox, gtd.CodeInvalidTransition},
+		{"complete someday", gtd.SomedayMaybe, gtd.Complete, gtd.CodeInvalidTransition},
+		{"unknown target", gtd.Inbox, "done", gtd.CodeUnknownStatus},
+	}
+	for _, tt := range tests {
+		err := gtd.ValidateTransition(tt.from, tt.to)
+		if tt.wantCode == "" {
+			if err != nil {
+				t.Errorf("%s: ValidateTransition(%s, %s) = %v, want nil", tt.name, tt.from, tt.to, err)
+			}
+			continue
+		}
+
+		var gtdErr *gtd.Error
+		if !errors.As(err, &gtdErr) {
+			t.Errorf("%s: ValidateTransition(%s, %s) = %v, want a *gtd.Error", tt.name, tt.from, tt.to, err)
+			continue
+		}
+		if gtdErr.Code != tt.wantCode || gtdErr.Extensions()["code"] != tt.wantCode {
+			t.Errorf("%s: code = %s, want %s", tt.name, gtdErr.Code, tt.wantCode)
+		}
+		if gtdErr.From != tt.from || gtdErr.To != tt.to {
+			t.Errorf("%s: from/to = %s/%s, want %s/%s", tt.name, gtdErr.From, gtdErr.To, tt.from, tt.to)
+		}
+	}
+}
+
+func TestTaskDates(t *testing.T) {
+	now := time.Date(2025, 5, 23, 12, 0, 0, 0, time.UTC)
+	date := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		date        *string
+		wantDue     bool
+		wantOverdue bool
+	}{
+		{"no date", nil, false, false},
+		{"empty date", date(""), false, false},
+		{"unparsable date", date("friday"), false, false},
+		{"earlier day", date("2025-05-22"), true, true},
+		{"today", date("2025-05-23"), true, false},
+		{"tomorrow", date("2025-05-24"), false, false},
+		{"earlier today with time", date("2025-05-23T09:00:00Z"), true, true},
+		{"later today with time", date("2025-05-23T15:00:00Z"), false, false},
+		{"fractional seconds", date("2025-05-16T00:00:00.000Z"), true, true},
+	}
+	for _, tt := range tests {
+		if got := gtd.Due(tt.date, now); got != tt.wantDue {
+			t.Errorf("%s: Due = %v, want %v", tt.name, got, tt.wantDue)
+		}
+		if got := gtd.Overdue(tt.date, now); got != tt.wantOverdue {
+			t.Errorf("%s: Overdue = %v, want %v", tt.name, got, tt.wantOverdue)
+		}
+	}
+}
+
+func TestValidateUnblocked(t *testing.T) {
+	tests := []struct {
+		name         string
+		from, to     string
+		openBlockers []string
+		wantErr      bool
+	}{
+		{"no blockers", gtd.Inbox, gtd.NextAction, nil, false},
+		{"blocked", gtd.WaitingFor, gtd.NextAction, []string{"t1"}, true},
+		{"blocked task may wait", gtd.Inbox, gtd.WaitingFor, []string{"t1"}, false},
+		{"already a next action", gtd.NextAction, gtd.NextAction, []string{"t1"}, false},
+	}
+	for _, tt := range tests {
+		err := gtd.ValidateUnblocked(tt.from, tt.to, tt.openBlockers)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ValidateUnblocked = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+
+		var gtdErr *gtd.Error
+		if tt.wantErr && (!errors.As(err, &gtdErr) || gtdErr.Code != gtd.CodeBlocked) {
+			t.Errorf("%s: ValidateUnblocked = %v, want a %s error", tt.name, err, gtd.CodeBlocked)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package shared_test
+
+import (
+	"math"
+	"testing"
+
+	reports "cryptobotmanager.com/cbm-backend/microservices/reports/functions"
+	"cryptobotmanager.com/cbm-backend/shared"
+)
+
+func TestBreadth(t *testing.T) {
+	tests := []struct {
+		moving, market int
+		want           float64
+	}{
+		{25, 100, 25},
+		{0, 100, 0},
+		{5, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := reports.Breadth(tt.moving, tt.market); got != tt.want {
+			t.Errorf("Breadth(%d, %d) = %v, want %v", tt.moving, tt.market, got, tt.want)
+		}
+	}
+}
+
+func TestTrend(t *testing.T) {
+	tests := []struct {
+		name           string
+		prices         []float64
+		change, spread float64
+	}{
+		{"too few prices", []float64{100}, 0, 0},
+		{"steady climb", []float64{100, 101, 102.01}, 2.01, 0},
+		{"round trip", []float64{100, 110, 99}, -1, 10},
+	}
+	for _, tt := range tests {
+		change, volatility := reports.Trend(tt.prices)
+		if math.Abs(change-tt.change) > 1e-9 || math.Abs(volatility-tt.spread) > 1e-9 {
+			t.Errorf("%s: Trend() = %v, %v, want %v, %v", tt.name, change, volatility, tt.change, tt.spread)
+		}
+	}
+}
+
+func TestClassifyMarket(t *testing.T) {
+	cfg := shared.GetDefaultCfg().Regime
+	tests := []struct {
+		name                        string
+		breadth, change, volatility float64
+		want                        string
+	}{
+		{"uptrend with broad market", 30, 2, 0.1, reports.MarketBull},
+		{"uptrend with narrow market", 10, 2, 0.1, reports.MarketSideways},
+		{"downtrend with few movers", 2, -2, 0.1, reports.MarketBear},
+		{"downtrend with broad market", 30, -2, 0.1, reports.MarketSideways},
+		{"flat", 15, 0.2, 0.1, reports.MarketSideways},
+		{"volatile beats trend", 30, 2, 0.8, reports.MarketVolatile},
+	}
+	for _, tt := range tests {
+		if got := reports.ClassifyMarket(tt.breadth, tt.change, tt.volatility, cfg); got != tt.want {
+			t.Errorf("%s: ClassifyMarket() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/shared/messaging"
+)
+
+func TestNilBusPublish(t *testing.T) {
+	var bus *messaging.Bus
+	if err := bus.Publish(context.Background(), messaging.SubjectPricesSnapshot, "id", messaging.PricesSnapshot{}); err != nil {
+		t.Errorf("Publish on a nil bus = %v, want nil", err)
+	}
+	bus.Close()
+}
+
+func TestBusRoundTrip(t *testing.T) {
+	srv, err := messaging.RunEmbeddedServer(messaging.EmbeddedOptions{Port: -1, StoreDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("RunEmbeddedServer: %v", err)
+	}
+	defer srv.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	bus, err := messaging.Connect(ctx, srv.ClientURL(), "test")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer bus.Close()
+
+	change := "1.5"
+	snapshots := []struct {
+		id       string
+		snapshot messaging.PricesSnapshot
+	}{
+		{"prices.snapshot.300", messaging.PricesSnapshot{Timestamp: 300, Pairs: []model.Pair{{Symbol: "BTCUSDT", Price: "1", PercentageChange: &change}}}},
+		{"prices.snapshot.300", messaging.PricesSnapshot{Timestamp: 300}}, // duplicate, dropped
+		{"prices.snapshot.600", messaging.PricesSnapshot{Timestamp: 600}},
+	}
+	for _, s := range snapshots {
+		if err := bus.Publish(ctx, messaging.SubjectPricesSnapshot, s.id, s.snapshot); err != nil {
+			t.Fatalf("Publish %s: %v", s.id, err)
+		}
+	}
+	// A trade event must not reach the price consumer.
+	if err := bus.Publish(ctx, messaging.SubjectTradeOpened, "trade", messaging.TradeOpened{BotName: "bot"}); err != nil {
+		t.Fatalf("Publish trade: %v", err)
+	}
+
+	received := make(chan messaging.PricesSnapshot, 10)
+	consuming, err := messaging.Subscribe(ctx, bus, "test-prices", messaging.SubjectPricesSnapshot, 0, func(ctx context.Context, snapshot messaging.PricesSnapshot) error {
+		received <- snapshot
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer consuming.Stop()
+
+	want := []int{300, 600}
+	for i, timestamp := range want {
+		select {
+		case got := <-received:
+			if got.Timestamp != timestamp {
+				t.Errorf("event %d has timestamp %d, want %d", i, got.Timestamp, timestamp)
+			}
+			if i == 0 && (len(got.Pairs) != 1 || got.Pairs[0].PercentageChange == nil || *got.Pairs[0].PercentageChange != change) {
+				t.Errorf("event %d pairs = %+v, want the BTCUSDT pair", i, got.Pairs)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+
+	select {
+	case got := <-received:
+		t.Errorf("unexpected extra event %+v", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestBusDropsStaleEvents(t *testing.T) {
+	srv, err := messaging.RunEmbeddedServer(messaging.EmbeddedOptions{Port: -1, StoreDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("RunEmbeddedServer: %v", err)
+	}
+	defer srv.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	bus, err := messaging.Connect(ctx, srv.ClientURL(), "test")
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer bus.Close()
+
+	signal := messaging.TradeSignal{Timestamp: 300, Symbol: "BTCUSDT"}
+	if err := bus.Publish(ctx, messaging.SubjectTradeSignal, "stale", signal); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	received := make(chan messaging.TradeSignal, 10)
+	consuming, err := messaging.Subscribe(ctx, bus, "test-signals", messaging.SubjectTradeSignal, 50*time.Millisecond, func(ctx context.Context, signal messaging.TradeSignal) error {
+		received <- signal
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer consuming.Stop()
+
+	signal.Timestamp = 600
+	if err := bus.Publish(ctx, messaging.SubjectTradeSignal, "fresh", signal); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got.Timestamp != 600 {
+			t.Errorf("received the signal for %d, want only the fresh one for 600", got.Timestamp)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the fresh signal")
+	}
+	select {
+	case got := <-received:
+		t.Errorf("unexpected extra signal %+v", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,137 @@
+package shared_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"cryptobotmanager.com/cbm-backend/cbm-api/migrate"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func step(context.Context, *mongo.Database) error { return nil }
+
+// migrations returns migrations 1 to n, each reversible unless listed.
+func migrations(n int, irreversible ...int) []migrate.Migration {
+	var list []migrate.Migration
+	for version := 1; version <= n; version++ {
+		migration := migrate.Migration{Version: version, Description: "step", Up: step, Down: step}
+		for _, v := range irreversible {
+			if v == version {
+				migration.Down = nil
+			}
+		}
+		list = append(list, migration)
+	}
+	return list
+}
+
+func versionsOf(list []migrate.Migration) []int {
+	var versions []int
+	for _, migration := range list {
+		versions = append(versions, migration.Version)
+	}
+	return versions
+}
+
+func TestMigrationsValidate(t *testing.T) {
+	if err := migrate.Validate(database.Migrations); err != nil {
+		t.Errorf("database.Migrations: %v", err)
+	}
+
+	gap := migrations(3)
+	gap[2].Version = 4
+	noUp := migrations(2)
+	noUp[1].Up = nil
+	tests := []struct {
+		name       string
+		migrations []migrate.Migration
+		wantErr    bool
+	}{
+		{"none", nil, false},
+		{"in order", migrations(3), false},
+		{"gap", gap, true},
+		{"from 0", []migrate.Migration{{Version: 0, Up: step}}, true},
+		{"no up", noUp, true},
+	}
+	for _, tt := range tests {
+		if err := migrate.Validate(tt.migrations); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMigrationsPending(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied map[int]bool
+		target  int
+		want    []int
+	}{
+		{"fresh", nil, 4, []int{1, 2, 3, 4}},
+		{"to target", nil, 2, []int{1, 2}},
+		{"partly applied", map[int]bool{1: true, 2: true}, 4, []int{3, 4}},
+		{"missing one", map[int]bool{1: true, 3: true}, 4, []int{2, 4}},
+		{"up to date", map[int]bool{1: true, 2: true, 3: true, 4: true}, 4, nil},
+	}
+	for _, tt := range tests {
+		got := versionsOf(migrate.Pending(migrations(4), tt.applied, tt.target))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Pending = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMigrationsRollback(t *testing.T) {
+	all := map[int]bool{1: true, 2: true, 3: true, 4: true}
+	tests := []struct {
+		name         string
+		irreversible []int
+		applied      map[int]bool
+		target       int
+		want         []int
+		wantErr      bool
+	}{
+		{"to 2", nil, all, 2, []int{4, 3}, false},
+		{"all", nil, all, 0, []int{4, 3, 2, 1}, false},
+		{"only applied", nil, map[int]bool{1: true, 2: true}, 0, []int{2, 1}, false},
+		{"at target", nil, all, 4, nil, false},
+		{"irreversible below target", []int{1}, all, 1, []int{4, 3, 2}, false},
+		{"irreversible above target", []int{3}, all, 1, nil, true},
+		{"below 0", nil, all, -1, nil, true},
+	}
+	for _, tt := range tests {
+		steps, err := migrate.Rollback(migrations(4, tt.irreversible...), tt.applied, tt.target)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Rollback error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got := versionsOf(steps); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Rollback = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMigrationsCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied map[int]bool
+		wantErr string
+	}{
+		{"current", map[int]bool{1: true, 2: true, 3: true}, ""},
+		{"fresh", nil, "missing migrations 1, 2, 3"},
+		{"behind", map[int]bool{1: true}, "missing migrations 2, 3"},
+		{"newer build", map[int]bool{1: true, 2: true, 3: true, 5: true, 4: true}, "migrations 4, 5 that this build does not know"},
+	}
+	for _, tt := range tests {
+		err := migrate.Check(migrations(3), tt.applied)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: Check error = %v, want none", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: Check error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/pubsub"
+)
+
+func TestBrokerPublish(t *testing.T) {
+	tests := []struct {
+		name    string
+		keep    func(string) bool
+		publish []string
+		want    []string
+	}{
+		{"no filter", nil, []string{"a", "b"}, []string{"a", "b"}},
+		{"filtered", func(s string) bool { return s == "b" }, []string{"a", "b", "c"}, []string{"b"}},
+		{"nothing published", nil, nil, nil},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		broker := pubsub.NewBroker[string]("test")
+		events := broker.Subscribe(ctx, tt.keep)
+
+		for _, event := range tt.publish {
+			broker.Publish(event)
+		}
+		cancel()
+
+		var got []string
+		for event := range events {
+			got = append(got, event)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestBrokerDropsForSlowSubscribers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	broker := pubsub.NewBroker[int]("test")
+	events := broker.Subscribe(ctx, nil)
+
+	// Publishing far more than the subscriber buffers must not block.
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			broker.Publish(i)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a slow subscriber")
+	}
+
+	cancel()
+	received := 0
+	for range events {
+		received++
+	}
+	if received == 0 || received >= 1000 {
+		t.Errorf("received %d events, want some but not all", received)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for broker.Subscribers() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := broker.Subscribers(); n != 0 {
+		t.Errorf("%d subscribers after cancel, want 0", n)
+	}
+}
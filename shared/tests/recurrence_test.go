@@ -0,0 +1,77 @@
+package shared_test
+
+import (
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		from     time.Time
+		dateOnly bool
+		want     time.Time
+		wantErr  bool
+	}{
+		{"daily", gtd.Daily, time.Date(2025, 5, 23, 9, 0, 0, 0, time.UTC), false, time.Date(2025, 5, 24, 9, 0, 0, 0, time.UTC), false},
+		{"weekly", gtd.Weekly, time.Date(2025, 5, 23, 0, 0, 0, 0, time.UTC), true, time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC), false},
+		{"monthly", gtd.Monthly, time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC), true, time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC), false},
+		{"monthly from a longer month", gtd.Monthly, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), true, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{"monthly into a leap February", gtd.Monthly, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), true, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), false},
+		{"cron", "0 9 * * 1", time.Date(2025, 5, 23, 9, 0, 0, 0, time.UTC), false, time.Date(2025, 5, 26, 9, 0, 0, 0, time.UTC), false},
+		{"cron on a date skips the day", "0 9 * * 1", time.Date(2025, 5, 26, 0, 0, 0, 0, time.UTC), true, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), false},
+		{"invalid rule", "fortnightly", time.Date(2025, 5, 23, 0, 0, 0, 0, time.UTC), true, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := gtd.NextOccurrence(tt.rule, tt.from, tt.dateOnly)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: NextOccurrence error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: NextOccurrence = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNextDates(t *testing.T) {
+	now := time.Date(2025, 5, 23, 12, 0, 0, 0, time.UTC)
+	date := func(s string) *string { return &s }
+	value := func(s *string) string {
+		if s == nil {
+			return "<nil>"
+		}
+		return *s
+	}
+
+	tests := []struct {
+		name               string
+		rule               string
+		dueDate, deferDate *string
+		wantDue, wantDefer *string
+	}{
+		{"daily", gtd.Daily, date("2025-05-23"), nil, date("2025-05-24"), nil},
+		{"defer keeps its distance", gtd.Weekly, date("2025-05-23"), date("2025-05-21"), date("2025-05-30"), date("2025-05-28")},
+		{"missed occurrences are skipped", gtd.Daily, date("2025-05-20"), nil, date("2025-05-23"), nil},
+		{"cron keeps the time", "0 9 * * 1", date("2025-05-23T09:00:00Z"), nil, date("2025-05-26T09:00:00Z"), nil},
+		{"defer date alone", gtd.Weekly, nil, date("2025-05-23"), nil, date("2025-05-30")},
+		{"no dates", gtd.Daily, nil, nil, date("2025-05-24"), nil},
+	}
+	for _, tt := range tests {
+		gotDue, gotDefer, err := gtd.NextDates(tt.rule, tt.dueDate, tt.deferDate, now)
+		if err != nil {
+			t.Errorf("%s: NextDates: %v", tt.name, err)
+			continue
+		}
+		if value(gotDue) != value(tt.wantDue) || value(gotDefer) != value(tt.wantDefer) {
+			t.Errorf("%s: NextDates = (%s, %s), want (%s, %s)", tt.name, value(gotDue), value(gotDefer), value(tt.wantDue), value(tt.wantDefer))
+		}
+	}
+
+	if _, _, err := gtd.NextDates("every other day", date("2025-05-23"), nil, now); err == nil {
+		t.Error("NextDates with an invalid rule expected an error")
+	}
+}
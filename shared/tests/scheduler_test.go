@@ -0,0 +1,93 @@
+package shared_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/shared/scheduler"
+)
+
+func utc(hour, minute int) time.Time {
+	return time.Date(2025, 6, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestMissedSlots(t *testing.T) {
+	everyFive, err := scheduler.Parse("*/5 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily, err := scheduler.Parse("3 0 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		last time.Time
+		now  time.Time
+		max  int
+		want []time.Time
+	}{
+		{"no slot missed", "*/5", utc(10, 0), utc(10, 4), 12, nil},
+		{"current slot missed", "*/5", utc(10, 0), utc(10, 5), 12, []time.Time{utc(10, 5)}},
+		{"slots missed mid slot", "*/5", utc(10, 0), utc(10, 17), 12, []time.Time{utc(10, 5), utc(10, 10), utc(10, 15)}},
+		{"capped to most recent", "*/5", utc(9, 0), utc(10, 17), 2, []time.Time{utc(10, 10), utc(10, 15)}},
+		{"no catch up", "*/5", utc(10, 0), utc(10, 17), 0, nil},
+		{"last in the future", "*/5", utc(11, 0), utc(10, 17), 12, nil},
+		{"daily missed once", "daily", utc(0, 3), utc(0, 3).Add(30 * time.Hour), 1, []time.Time{utc(0, 3).Add(24 * time.Hour)}},
+		{"daily not yet due", "daily", utc(0, 3), utc(23, 59), 1, nil},
+	}
+	for _, tt := range tests {
+		schedule := everyFive
+		if tt.expr == "daily" {
+			schedule = daily
+		}
+		if got := scheduler.MissedSlots(schedule, tt.last, tt.now, tt.max); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: MissedSlots = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSplitMissed(t *testing.T) {
+	slots := []time.Time{utc(10, 5), utc(10, 10), utc(10, 15)}
+	tests := []struct {
+		name     string
+		slots    []time.Time
+		catchUp  int
+		wantSkip []time.Time
+		wantRun  []time.Time
+	}{
+		{"none missed", nil, 12, nil, nil},
+		{"all caught up", slots, 12, nil, slots},
+		{"oldest skipped", slots, 2, slots[:1], slots[1:]},
+		{"no catch up", slots, 0, slots, nil},
+	}
+	for _, tt := range tests {
+		skip, run := scheduler.SplitMissed(tt.slots, tt.catchUp)
+		if len(skip) != len(tt.wantSkip) || (len(skip) > 0 && !reflect.DeepEqual(skip, tt.wantSkip)) {
+			t.Errorf("%s: skip = %v, want %v", tt.name, skip, tt.wantSkip)
+		}
+		if len(run) != len(tt.wantRun) || (len(run) > 0 && !reflect.DeepEqual(run, tt.wantRun)) {
+			t.Errorf("%s: run = %v, want %v", tt.name, run, tt.wantRun)
+		}
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{"*/5 * * * *", false},
+		{"5 0 * * *", false},
+		{"not a schedule", true},
+		{"* * * *", true},
+	}
+	for _, tt := range tests {
+		if _, err := scheduler.Parse(tt.expr); (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+		}
+	}
+}
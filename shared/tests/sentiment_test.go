@@ -0,0 +1,34 @@
+package shared_test
+
+import (
+	"testing"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	tradingBots "cryptobotmanager.com/cbm-backend/microservices/tradingBots/functions"
+)
+
+func TestSentimentAllows(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name           string
+		details        model.StrategyInput
+		index          int
+		classification string
+		want           bool
+	}{
+		{"no gate", model.StrategyInput{}, 10, "Extreme Fear", true},
+		{"no gate with unknown index", model.StrategyInput{}, -1, "", true},
+		{"inside bounds", model.StrategyInput{MinFearGreed: intPtr(40), MaxFearGreed: intPtr(70)}, 55, "Greed", true},
+		{"below minimum", model.StrategyInput{MinFearGreed: intPtr(40)}, 30, "Fear", false},
+		{"above maximum", model.StrategyInput{MaxFearGreed: intPtr(70)}, 80, "Extreme Greed", false},
+		{"unknown index", model.StrategyInput{MinFearGreed: intPtr(0)}, -1, "", false},
+		{"allowed classification", model.StrategyInput{AllowedSentiments: []string{"Fear", "Neutral"}}, 45, "neutral", true},
+		{"disallowed classification", model.StrategyInput{AllowedSentiments: []string{"Fear"}}, 60, "Greed", false},
+	}
+	for _, tt := range tests {
+		if got := tradingBots.SentimentAllows(tt.details, tt.index, tt.classification); got != tt.want {
+			t.Errorf("%s: SentimentAllows() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package shared_test
+
+import (
+	"testing"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+)
+
+func TestSopDatesRebase(t *testing.T) {
+	tests := []struct {
+		name          string
+		startDate     string
+		templateDates []string
+		date          string
+		want          string
+		wantErr       bool
+	}{
+		{"empty date", "2025-03-10", nil, "", "", false},
+		{"day offset", "2025-03-10", nil, "+3d", "2025-03-13", false},
+		{"unsigned week offset", "2025-03-10", nil, "2w", "2025-03-24", false},
+		{"negative offset", "2025-03-10", nil, "-1d", "2025-03-09", false},
+		{"hour offset keeps time", "2025-03-10T09:00:00Z", nil, "+12h", "2025-03-10T21:00:00Z", false},
+		{"earliest date lands on start", "2025-03-10", []string{"2024-01-05", "2024-01-01"}, "2024-01-01", "2025-03-10", false},
+		{"absolute date keeps distance", "2025-03-10", []string{"2024-01-05", "2024-01-01"}, "2024-01-05", "2025-03-14", false},
+		{"RFC 3339 template onto date", "2025-03-10", []string{"2024-01-01T00:00:00Z"}, "2024-01-02T00:00:00Z", "2025-03-11", false},
+		{"offsets ignored for anchor", "2025-03-10", []string{"+5d", "2024-01-01"}, "2024-01-01", "2025-03-10", false},
+		{"invalid date", "2025-03-10", nil, "next week", "", true},
+	}
+	for _, tt := range tests {
+		dates, err := database.NewSopDates(tt.startDate, tt.templateDates)
+		if err != nil {
+			t.Fatalf("%s: NewSopDates: %v", tt.name, err)
+		}
+		got, err := dates.Rebase(tt.date)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Rebase(%q) error = %v, wantErr %v", tt.name, tt.date, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: Rebase(%q) = %q, want %q", tt.name, tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestNewSopDatesInvalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		startDate     string
+		templateDates []string
+	}{
+		{"invalid start date", "10/03/2025", nil},
+		{"invalid template date", "2025-03-10", []string{"soon"}},
+	}
+	for _, tt := range tests {
+		if _, err := database.NewSopDates(tt.startDate, tt.templateDates); err == nil {
+			t.Errorf("%s: NewSopDates(%q, %v) expected an error", tt.name, tt.startDate, tt.templateDates)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package shared_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	reports "cryptobotmanager.com/cbm-backend/microservices/reports/functions"
+	"cryptobotmanager.com/cbm-backend/shared"
+	"cryptobotmanager.com/cbm-backend/shared/stats"
+)
+
+func gainers(gains ...float64) []shared.Gainers {
+	out := make([]shared.Gainers, len(gains))
+	for i, g := range gains {
+		out[i] = shared.Gainers{IncrementPriceGain: g}
+	}
+	return out
+}
+
+func TestSampleTopMean(t *testing.T) {
+	tests := []struct {
+		name  string
+		gains []float64
+		topN  int
+		want  float64
+	}{
+		{"empty input", nil, 3, 0},
+		{"zero topN", []float64{1, 2, 3}, 0, 0},
+		{"negative topN", []float64{1, 2, 3}, -1, 0},
+		{"topN within input", []float64{1, 4, 2, 3}, 2, 3.5},
+		{"topN equals input", []float64{1, 4, 2, 3}, 4, 2.5},
+		{"topN larger than input", []float64{1, 4}, 10, 2.5},
+		{"negative gains", []float64{-1, -3, -2}, 2, -1.5},
+	}
+	for _, tt := range tests {
+		if got := stats.FromGainers(gainers(tt.gains...)).TopMean(tt.topN); got != tt.want {
+			t.Errorf("%s: TopMean(%d) = %v, want %v", tt.name, tt.topN, got, tt.want)
+		}
+	}
+}
+
+func TestSamplePercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		p      float64
+		want   float64
+	}{
+		{"empty input", nil, 50, 0},
+		{"single value", []float64{7}, 90, 7},
+		{"median of odd count", []float64{5, 1, 3}, 50, 3},
+		{"median of even count", []float64{4, 1, 3, 2}, 50, 2.5},
+		{"interpolated", []float64{1, 3, 5}, 25, 2},
+		{"minimum", []float64{2, 9, 4}, 0, 2},
+		{"maximum", []float64{2, 9, 4}, 100, 9},
+		{"clamped above", []float64{2, 9, 4}, 150, 9},
+		{"clamped below", []float64{2, 9, 4}, -10, 2},
+	}
+	for _, tt := range tests {
+		if got := stats.New(tt.values).Percentile(tt.p); got != tt.want {
+			t.Errorf("%s: Percentile(%v) = %v, want %v", tt.name, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestSampleStdDev(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty input", nil, 0},
+		{"single value", []float64{3}, 0},
+		{"no spread", []float64{2, 2, 2}, 0},
+		{"spread", []float64{2, 4, 4, 4, 5, 5, 7, 9}, 2},
+	}
+	for _, tt := range tests {
+		if got := stats.New(tt.values).StdDev(); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: StdDev() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSampleHistogram(t *testing.T) {
+	edges := []float64{0, 1, 2}
+
+	tests := []struct {
+		name   string
+		values []float64
+		want   []int
+	}{
+		{"empty input", nil, []int{0, 0, 0, 0}},
+		{"edges are inclusive below", []float64{0, 1, 2}, []int{0, 1, 1, 1}},
+		{"open ended", []float64{-5, 0.5, 1.5, 10, 20}, []int{1, 1, 1, 2}},
+	}
+	for _, tt := range tests {
+		if got := stats.New(tt.values).Histogram(edges); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Histogram() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSummariseGains(t *testing.T) {
+	tests := []struct {
+		name     string
+		gains    []float64
+		tops     []int
+		wantAll  float64
+		wantTops []float64
+	}{
+		{"empty input", nil, []int{3, 5, 10}, 0, []float64{0, 0, 0}},
+		{"fewer pairs than tops", []float64{1, 2, 3, 4}, []int{3, 5, 10}, 2.5, []float64{3, 0, 0}},
+		{"rounded means", []float64{1, 1, 2}, []int{3}, 1.33, []float64{1.33}},
+	}
+	for _, tt := range tests {
+		got := reports.SummariseGains(gainers(tt.gains...), tt.tops)
+		if got.AllMovers != tt.wantAll || !reflect.DeepEqual(got.Tops, tt.wantTops) {
+			t.Errorf("%s: SummariseGains() = %v/%v, want %v/%v", tt.name, got.AllMovers, got.Tops, tt.wantAll, tt.wantTops)
+		}
+	}
+}
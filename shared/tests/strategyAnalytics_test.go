@@ -0,0 +1,255 @@
+package shared_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// outcomes returns a trade outcome per % change, oldest first.
+func outcomes(changes ...float64) []*model.TradeOutcomeReport {
+	var reports []*model.TradeOutcomeReport
+	for _, change := range changes {
+		reports = append(reports, &model.TradeOutcomeReport{PercentageChange: change})
+	}
+	return reports
+}
+
+func TestOutcomeStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []float64
+		want    model.OutcomeStats
+	}{
+		{"none", nil, model.OutcomeStats{}},
+		{
+			"mixed", []float64{10, -5, -5, 20},
+			model.OutcomeStats{
+				Trades: 4, Wins: 2, Losses: 2, WinRate: 50, AvgGain: 15, AvgLoss: -5, Expectancy: 5, ProfitFactor: 3,
+				MaxDrawdown: 9.75, LongestWinStreak: 1, LongestLossStreak: 2, CurrentStreak: 1,
+			},
+		},
+		{
+			"all wins", []float64{1, 2},
+			model.OutcomeStats{Trades: 2, Wins: 2, WinRate: 100, AvgGain: 1.5, Expectancy: 1.5, LongestWinStreak: 2, CurrentStreak: 2},
+		},
+		{
+			"flat counts as a loss", []float64{0},
+			model.OutcomeStats{Trades: 1, Losses: 1, LongestLossStreak: 1, CurrentStreak: -1},
+		},
+	}
+	for _, tt := range tests {
+		got := database.OutcomeStats(outcomes(tt.changes...))
+		counts := [][2]int{
+			{got.Trades, tt.want.Trades}, {got.Wins, tt.want.Wins}, {got.Losses, tt.want.Losses},
+			{got.LongestWinStreak, tt.want.LongestWinStreak}, {got.LongestLossStreak, tt.want.LongestLossStreak},
+			{got.CurrentStreak, tt.want.CurrentStreak},
+		}
+		figures := [][2]float64{
+			{got.WinRate, tt.want.WinRate}, {got.AvgGain, tt.want.AvgGain}, {got.AvgLoss, tt.want.AvgLoss},
+			{got.Expectancy, tt.want.Expectancy}, {got.ProfitFactor, tt.want.ProfitFactor}, {got.MaxDrawdown, tt.want.MaxDrawdown},
+		}
+		match := true
+		for _, c := range counts {
+			match = match && c[0] == c[1]
+		}
+		for _, f := range figures {
+			match = match && math.Abs(f[0]-f[1]) <= 1e-9
+		}
+		if !match {
+			t.Errorf("%s: OutcomeStats = %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+}
+
+func TestBucketKey(t *testing.T) {
+	report := &model.TradeOutcomeReport{Symbol: "BTCUSDT", MarketStatus: "bull", FearGreedIndex: 80}
+	tests := []struct {
+		name        string
+		timestamp   int
+		breakdownBy model.AnalyticsBreakdown
+		want        string
+	}{
+		{"symbol", 1747990800, model.AnalyticsBreakdownSymbol, "BTCUSDT"},
+		{"hour in seconds", 1747990800, model.AnalyticsBreakdownHour, "09"},
+		{"hour in milliseconds", 1748010600000, model.AnalyticsBreakdownHour, "14"},
+		{"weekday", 1747990800, model.AnalyticsBreakdownWeekday, "Friday"},
+		{"market status", 1747990800, model.AnalyticsBreakdownMarketStatus, "bull"},
+		{"fear and greed", 1747990800, model.AnalyticsBreakdownFearGreed, "Extreme Greed"},
+	}
+	for _, tt := range tests {
+		report.Timestamp = tt.timestamp
+		if got := database.BucketKey(report, tt.breakdownBy); got != tt.want {
+			t.Errorf("%s: BucketKey = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFearGreedBucket(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{-1, "Unknown"},
+		{0, "Extreme Fear"},
+		{24, "Extreme Fear"},
+		{25, "Fear"},
+		{44, "Fear"},
+		{45, "Neutral"},
+		{55, "Neutral"},
+		{56, "Greed"},
+		{75, "Greed"},
+		{76, "Extreme Greed"},
+		{100, "Extreme Greed"},
+	}
+	for _, tt := range tests {
+		if got := database.FearGreedBucket(tt.index); got != tt.want {
+			t.Errorf("FearGreedBucket(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestTradeTotals(t *testing.T) {
+	tests := []struct {
+		name         string
+		changes      []float64
+		winRate      float64
+		compounded   float64
+		riskAdjusted float64
+	}{
+		{"none", nil, 0, 0, 0},
+		{"one trade", []float64{5}, 100, 5, 0},
+		{"even out", []float64{10, -10}, 50, -1, 0},
+		{"mean over std dev", []float64{1, 3}, 100, 4.03, 2 / math.Sqrt2},
+		{"no spread", []float64{2, 2, 2}, 100, 6.1208, 0},
+		{"no spread in fractions", []float64{0.1, 0.1, 0.1}, 100, 0.3003001, 0},
+		{"lost everything", []float64{-100}, 0, -100, 0},
+	}
+	for _, tt := range tests {
+		totals := database.TotalTrades(outcomes(tt.changes...))
+		if totals.Trades != len(tt.changes) {
+			t.Errorf("%s: Trades = %d, want %d", tt.name, totals.Trades, len(tt.changes))
+		}
+		if got := totals.WinRate(); math.Abs(got-tt.winRate) > 1e-9 {
+			t.Errorf("%s: WinRate = %v, want %v", tt.name, got, tt.winRate)
+		}
+		if got := totals.CompoundedReturn(); math.Abs(got-tt.compounded) > 1e-9 {
+			t.Errorf("%s: CompoundedReturn = %v, want %v", tt.name, got, tt.compounded)
+		}
+		if got := totals.RiskAdjustedReturn(); math.Abs(got-tt.riskAdjusted) > 1e-9 {
+			t.Errorf("%s: RiskAdjustedReturn = %v, want %v", tt.name, got, tt.riskAdjusted)
+		}
+	}
+}
+
+// matchesTimestamp evaluates a filter on timestamp, built of $or and the
+// comparison operators, against a timestamp as Mongo would.
+func matchesTimestamp(t *testing.T, filter bson.M, timestamp int) bool {
+	t.Helper()
+	if branches, ok := filter["$or"]; ok {
+		for _, branch := range branches.(bson.A) {
+			if matchesTimestamp(t, branch.(bson.M), timestamp) {
+				return true
+			}
+		}
+		return false
+	}
+	conditions, ok := filter["timestamp"]
+	if !ok {
+		return len(filter) == 0
+	}
+	for op, bound := range conditions.(bson.M) {
+		b := bound.(int)
+		switch op {
+		case "$gte":
+			if timestamp < b {
+				return false
+			}
+		case "$gt":
+			if timestamp <= b {
+				return false
+			}
+		case "$lte":
+			if timestamp > b {
+				return false
+			}
+		case "$lt":
+			if timestamp >= b {
+				return false
+			}
+		default:
+			t.Fatalf("unexpected operator %s", op)
+		}
+	}
+	return true
+}
+
+func TestTradeOutcomeTimeFilter(t *testing.T) {
+	at := func(i int) *int { return &i }
+	const (
+		may1  = 1746057600 // 2025-05-01T00:00:00Z
+		may10 = 1746835200 // 2025-05-10T00:00:00Z
+		may20 = 1747699200 // 2025-05-20T00:00:00Z
+	)
+	tests := []struct {
+		name      string
+		from, to  *int
+		timestamp int
+		want      bool
+	}{
+		{"no range, seconds", nil, nil, may1, true},
+		{"no range, milliseconds", nil, nil, may1 * 1000, true},
+		{"from, older seconds", at(may10), nil, may1, false},
+		{"from, older milliseconds", at(may10), nil, may1 * 1000, false},
+		{"from, newer seconds", at(may10), nil, may20, true},
+		{"from, newer milliseconds", at(may10), nil, may20 * 1000, true},
+		{"from, at the start in milliseconds", at(may10), nil, may10 * 1000, true},
+		{"to, older seconds", nil, at(may10), may1, true},
+		{"to, older milliseconds", nil, at(may10), may1 * 1000, true},
+		{"to, newer seconds", nil, at(may10), may20, false},
+		{"to, newer milliseconds", nil, at(may10), may20 * 1000, false},
+		{"to, within its last second in milliseconds", nil, at(may10), may10*1000 + 999, true},
+		{"between, seconds inside", at(may1), at(may20), may10, true},
+		{"between, milliseconds inside", at(may1), at(may20), may10 * 1000, true},
+		{"between, seconds after", at(may1), at(may10), may20, false},
+		{"between, milliseconds before", at(may10), at(may20), may1 * 1000, false},
+	}
+	for _, tt := range tests {
+		filter := database.TradeOutcomeTimeFilter(tt.from, tt.to)
+		if got := matchesTimestamp(t, filter, tt.timestamp); got != tt.want {
+			t.Errorf("%s: filter %v matches %d = %v, want %v", tt.name, filter, tt.timestamp, got, tt.want)
+		}
+	}
+}
+
+func TestLeaderboardWindow(t *testing.T) {
+	now := time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC)
+	days := func(d int) *int { return &d }
+	ago := func(d int) int { return int(now.AddDate(0, 0, -d).Unix()) }
+	tests := []struct {
+		name      string
+		window    *int
+		timestamp int
+		want      bool
+	}{
+		{"default, recent seconds", nil, ago(1), true},
+		{"default, recent milliseconds", nil, ago(1) * 1000, true},
+		{"default, old seconds", nil, ago(8), false},
+		{"default, old milliseconds", nil, ago(8) * 1000, false},
+		{"30 days, old milliseconds", days(30), ago(8) * 1000, true},
+		{"1 day, old milliseconds", days(1), ago(2) * 1000, false},
+		{"not positive, falls back to 7 days", days(0), ago(6) * 1000, true},
+		{"future milliseconds", nil, int(now.Add(time.Hour).Unix()) * 1000, false},
+	}
+	for _, tt := range tests {
+		from, to := database.LeaderboardWindow(now, tt.window)
+		filter := database.TradeOutcomeTimeFilter(&from, &to)
+		if got := matchesTimestamp(t, filter, tt.timestamp); got != tt.want {
+			t.Errorf("%s: window matches %d = %v, want %v", tt.name, tt.timestamp, got, tt.want)
+		}
+	}
+}
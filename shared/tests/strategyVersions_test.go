@@ -0,0 +1,64 @@
+package shared_test
+
+import (
+	"reflect"
+	"testing"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to model.StrategyLifecycle
+		want     bool
+	}{
+		{model.StrategyLifecycleDraft, model.StrategyLifecycleBacktesting, true},
+		{model.StrategyLifecycleBacktesting, model.StrategyLifecycleValidated, true},
+		{model.StrategyLifecycleValidated, model.StrategyLifecyclePaper, true},
+		{model.StrategyLifecyclePaper, model.StrategyLifecycleLive, true},
+		{model.StrategyLifecycleLive, model.StrategyLifecyclePaused, true},
+		{model.StrategyLifecyclePaused, model.StrategyLifecycleLive, true},
+		{model.StrategyLifecycleLive, model.StrategyLifecycleRetired, true},
+		{model.StrategyLifecycleDraft, model.StrategyLifecycleLive, false},
+		{model.StrategyLifecycleDraft, model.StrategyLifecycleValidated, false},
+		{model.StrategyLifecycleBacktesting, model.StrategyLifecyclePaper, false},
+		{model.StrategyLifecycleRetired, model.StrategyLifecycleDraft, false},
+	}
+	for _, tt := range tests {
+		if got := database.CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+	takeProfit, otherTakeProfit := 2.0, 3.0
+	minFearGreed := 40
+	base := model.StrategyVersion{TradeDuration: 30, MovingAveMomentum: 0.5, TakeProfitPercentage: &takeProfit}
+
+	tests := []struct {
+		name   string
+		change func(*model.StrategyVersion)
+		want   []string
+	}{
+		{"unchanged", func(*model.StrategyVersion) {}, nil},
+		{"duration", func(v *model.StrategyVersion) { v.TradeDuration = 45 }, []string{"TradeDuration"}},
+		{"take profit", func(v *model.StrategyVersion) { v.TakeProfitPercentage = &otherTakeProfit }, []string{"TakeProfitPercentage"}},
+		{"bound added", func(v *model.StrategyVersion) { v.MinFearGreed = &minFearGreed }, []string{"MinFearGreed"}},
+		{"sentiments added", func(v *model.StrategyVersion) { v.AllowedSentiments = []string{"Greed"} }, []string{"AllowedSentiments"}},
+		{"two fields", func(v *model.StrategyVersion) { v.TradeDuration, v.MovingAveMomentum = 45, 1 }, []string{"TradeDuration", "MovingAveMomentum"}},
+	}
+	for _, tt := range tests {
+		next := base
+		tt.change(&next)
+
+		var fields []string
+		for _, change := range database.DiffVersions(&base, &next) {
+			fields = append(fields, change.Field)
+		}
+		if !reflect.DeepEqual(fields, tt.want) {
+			t.Errorf("%s: DiffVersions changed %v, want %v", tt.name, fields, tt.want)
+		}
+	}
+}
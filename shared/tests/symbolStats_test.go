@@ -0,0 +1,152 @@
+package shared_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+)
+
+const halfLife = 3 * 24 * 60 * 60
+
+func TestDecayTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		decayed     *model.DecayedStats
+		timestamp   int
+		wantScore   float64
+		wantUpdated int
+	}{
+		{"no stats yet", nil, 1000, 0, 1000},
+		{"no time passed", &model.DecayedStats{Score: 8, UpdatedAt: 1000}, 1000, 8, 1000},
+		{"one half life", &model.DecayedStats{Score: 8, UpdatedAt: 1000}, 1000 + halfLife, 4, 1000 + halfLife},
+		{"two half lives", &model.DecayedStats{Score: 8, UpdatedAt: 1000}, 1000 + 2*halfLife, 2, 1000 + 2*halfLife},
+		{"earlier timestamp", &model.DecayedStats{Score: 8, UpdatedAt: 1000}, 500, 8, 1000},
+	}
+	for _, tt := range tests {
+		got := database.DecayTo(tt.decayed, tt.timestamp)
+		if math.Abs(got.Score-tt.wantScore) > 1e-9 || got.UpdatedAt != tt.wantUpdated {
+			t.Errorf("%s: DecayTo = %+v, want score %v at %d", tt.name, got, tt.wantScore, tt.wantUpdated)
+		}
+		if tt.decayed != nil && got == tt.decayed {
+			t.Errorf("%s: DecayTo returned the stats it was given, want a copy", tt.name)
+		}
+	}
+}
+
+func TestAddAppearance(t *testing.T) {
+	day := 24 * 60 * 60
+	now := 100 * day
+
+	tests := []struct {
+		name        string
+		stats       model.SymbolStats
+		position    int
+		gain        float64
+		wantScore   float64
+		wantAvgGain float64
+		wantCount   int     // appearances at the position
+		wantMean    float64 // their mean gain
+		wantHistory int
+	}{
+		{
+			name:     "first appearance",
+			position: 1, gain: 4,
+			wantScore: 1, wantAvgGain: 4, wantCount: 1, wantMean: 4, wantHistory: 1,
+		},
+		{
+			name: "tenth weighs a tenth",
+			stats: model.SymbolStats{
+				PositionCounts: []*model.Mean{nil, nil, nil, nil, nil, nil, nil, nil, nil, {Count: 1, Avg: 2}},
+				Decayed:        &model.DecayedStats{Score: 1, AvgGain: 2, UpdatedAt: now},
+				History:        []*model.PositionAppearance{{Timestamp: now - day, Position: 10, Gain: 2}},
+			},
+			position: 10, gain: 13,
+			wantScore: 1.1, wantAvgGain: 3, wantCount: 2, wantMean: 7.5, wantHistory: 2,
+		},
+		{
+			name: "history older than 30 days dropped",
+			stats: model.SymbolStats{
+				History: []*model.PositionAppearance{
+					{Timestamp: now - 31*day, Position: 1, Gain: 1},
+					{Timestamp: now - 29*day, Position: 2, Gain: 1},
+				},
+			},
+			position: 1, gain: 4,
+			wantScore: 1, wantAvgGain: 4, wantCount: 1, wantMean: 4, wantHistory: 2,
+		},
+	}
+	for _, tt := range tests {
+		stats := tt.stats
+		for i, mean := range stats.PositionCounts {
+			if mean == nil {
+				stats.PositionCounts[i] = &model.Mean{}
+			}
+		}
+		database.AddAppearance(&stats, now, tt.position, tt.gain)
+
+		if math.Abs(stats.Decayed.Score-tt.wantScore) > 1e-9 || math.Abs(stats.Decayed.AvgGain-tt.wantAvgGain) > 1e-9 {
+			t.Errorf("%s: decayed = %+v, want score %v and average gain %v", tt.name, stats.Decayed, tt.wantScore, tt.wantAvgGain)
+		}
+		if len(stats.PositionCounts) != 10 {
+			t.Fatalf("%s: %d position counts, want 10", tt.name, len(stats.PositionCounts))
+		}
+		if mean := stats.PositionCounts[tt.position-1]; mean.Count != tt.wantCount || math.Abs(mean.Avg-tt.wantMean) > 1e-9 {
+			t.Errorf("%s: position %d = %+v, want %d averaging %v", tt.name, tt.position, mean, tt.wantCount, tt.wantMean)
+		}
+		if len(stats.History) != tt.wantHistory {
+			t.Errorf("%s: %d appearances in history, want %d", tt.name, len(stats.History), tt.wantHistory)
+		}
+		if last := stats.History[len(stats.History)-1]; last.Timestamp != now || last.Position != tt.position {
+			t.Errorf("%s: last appearance = %+v, want position %d at %d", tt.name, last, tt.position, now)
+		}
+	}
+}
+
+func TestFillSymbolStats(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) int { return int(now.Add(-ago).Unix()) }
+
+	stats := model.SymbolStats{
+		Decayed: &model.DecayedStats{Score: 2, UpdatedAt: at(3 * 24 * time.Hour)},
+		History: []*model.PositionAppearance{
+			{Timestamp: at(time.Hour), Position: 1, Gain: 6},
+			{Timestamp: at(2 * time.Hour), Position: 3, Gain: 2},
+			{Timestamp: at(3 * 24 * time.Hour), Position: 10, Gain: 1},
+			{Timestamp: at(20 * 24 * time.Hour), Position: 2, Gain: 3},
+		},
+	}
+	database.FillSymbolStats(&stats, now)
+
+	if math.Abs(stats.Decayed.Score-1) > 1e-9 || stats.Decayed.UpdatedAt != int(now.Unix()) {
+		t.Errorf("decayed = %+v, want score 1 aged to now", stats.Decayed)
+	}
+
+	tests := []struct {
+		window      model.MoverWindow
+		appearances int
+		score       float64
+		avgPosition float64
+		avgGain     float64
+	}{
+		{model.MoverWindowDay, 2, 1.8, 2, 4},
+		{model.MoverWindowWeek, 3, 1.9, 14.0 / 3, 3},
+		{model.MoverWindowMonth, 4, 2.8, 4, 3},
+	}
+	if len(stats.Windows) != len(tests) {
+		t.Fatalf("%d windows, want %d", len(stats.Windows), len(tests))
+	}
+	for i, tt := range tests {
+		got := stats.Windows[i]
+		if got.Window != tt.window || got.Appearances != tt.appearances ||
+			math.Abs(got.Score-tt.score) > 1e-9 || math.Abs(got.AvgPosition-tt.avgPosition) > 1e-9 || math.Abs(got.AvgGain-tt.avgGain) > 1e-9 {
+			t.Errorf("window %d = %+v, want %s with %d appearances, score %v, average position %v and gain %v",
+				i, got, tt.window, tt.appearances, tt.score, tt.avgPosition, tt.avgGain)
+		}
+	}
+	if counts := stats.Windows[0].PositionCounts; counts[0] != 1 || counts[2] != 1 || counts[9] != 0 {
+		t.Errorf("day position counts = %v, want first and third once", counts)
+	}
+}
@@ -0,0 +1,103 @@
+package shared_test
+
+import (
+	"reflect"
+	"testing"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/database"
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	value := "2025-05-23T10:00:00Z"
+	tests := []struct {
+		name  string
+		value *string
+		id    string
+	}{
+		{"with sort value", &value, "682a447cd7b150159071e942"},
+		{"without sort value", nil, "682a4629d7b150159071e944"},
+	}
+	for _, tt := range tests {
+		gotValue, gotID, err := database.DecodeCursor(database.EncodeCursor(tt.value, tt.id))
+		if err != nil {
+			t.Fatalf("%s: DecodeCursor: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(gotValue, tt.value) || gotID != tt.id {
+			t.Errorf("%s: round trip = (%v, %s), want (%v, %s)", tt.name, gotValue, gotID, tt.value, tt.id)
+		}
+	}
+
+	for _, cursor := range []string{"", "not a cursor", "e30"} {
+		if _, _, err := database.DecodeCursor(cursor); err == nil {
+			t.Errorf("DecodeCursor(%q) expected an error", cursor)
+		}
+	}
+}
+
+func TestBuildTaskFilter(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		filter  *model.TaskFilterInput
+		want    bson.M
+		wantErr bool
+	}{
+		{"no filter", nil, bson.M{}, false},
+		{
+			"fields",
+			&model.TaskFilterInput{Status: []string{"inbox", "nextAction"}, Labels: []string{"call"}, AssignedTo: str("Mark"), Department: str("sales"), ProjectID: str("p1")},
+			bson.M{
+				"status":     bson.M{"$in": []string{"inbox", "nextAction"}},
+				"labels":     bson.M{"$all": []string{"call"}},
+				"assignedTo": "Mark",
+				"department": "sales",
+				"projectId":  "p1",
+			},
+			false,
+		},
+		{
+			"due range by day",
+			&model.TaskFilterInput{DueFrom: str("2025-05-01"), DueTo: str("2025-05-31")},
+			bson.M{"dueDate": bson.M{"$gte": "2025-05-01", "$lt": "2025-06-01"}},
+			false,
+		},
+		{
+			"due before a time",
+			&model.TaskFilterInput{DueTo: str("2025-05-31T12:00:00Z")},
+			bson.M{"dueDate": bson.M{"$lte": "2025-05-31T12:00:00Z"}},
+			false,
+		},
+		{"invalid due date", &model.TaskFilterInput{DueFrom: str("last week")}, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := database.BuildTaskFilter(tt.filter)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: BuildTaskFilter error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: BuildTaskFilter = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildTaskFilterSearch(t *testing.T) {
+	search := "a.b (draft)"
+	got, err := database.BuildTaskFilter(&model.TaskFilterInput{Search: &search})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	or, ok := got["$or"].(bson.A)
+	if !ok || len(or) != 2 {
+		t.Fatalf("search filter = %v, want $or over title and description", got)
+	}
+	want := bson.M{"title": primitive.Regex{Pattern: `a\.b \(draft\)`, Options: "i"}}
+	if !reflect.DeepEqual(or[0], want) {
+		t.Errorf("title filter = %v, want %v", or[0], want)
+	}
+}
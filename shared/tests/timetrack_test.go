@@ -0,0 +1,129 @@
+package shared_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cryptobotmanager.com/cbm-backend/cbm-api/graph/model"
+	"cryptobotmanager.com/cbm-backend/cbm-api/gtd"
+	"cryptobotmanager.com/cbm-backend/cbm-api/timetrack"
+)
+
+func TestTimeEntrySeconds(t *testing.T) {
+	from := time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 24, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 5, 23, 12, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name  string
+		entry model.TimeEntry
+		want  int64
+	}{
+		{"inside the range", model.TimeEntry{StartedAt: "2025-05-20T09:00:00Z", StoppedAt: str("2025-05-20T10:30:00Z")}, 5400},
+		{"started before the range", model.TimeEntry{StartedAt: "2025-05-18T23:00:00Z", StoppedAt: str("2025-05-19T01:00:00Z")}, 3600},
+		{"stopped after the range", model.TimeEntry{StartedAt: "2025-05-23T23:30:00Z", StoppedAt: str("2025-05-24T02:00:00Z")}, 1800},
+		{"outside the range", model.TimeEntry{StartedAt: "2025-05-25T09:00:00Z", StoppedAt: str("2025-05-25T10:00:00Z")}, 0},
+		{"running until now", model.TimeEntry{StartedAt: "2025-05-23T11:00:00Z"}, 3600},
+		{"unparsable start", model.TimeEntry{StartedAt: "yesterday"}, 0},
+	}
+	for _, tt := range tests {
+		if got := timetrack.Seconds(&tt.entry, from, to, now); got != tt.want {
+			t.Errorf("%s: Seconds = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTimeReport(t *testing.T) {
+	from := time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 24, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+
+	tasks := map[string]*model.Task{
+		"t1": {ID: "t1", Department: str("sales"), AssignedTo: str("Mark"), ProjectID: str("p1")},
+		"t2": {ID: "t2", Department: str("programming"), AssignedTo: str("Ana")},
+	}
+	entries := []*model.TimeEntry{
+		{TaskID: "t1", UserID: "u1", StartedAt: "2025-05-20T09:00:00Z", StoppedAt: str("2025-05-20T10:00:00Z")},
+		{TaskID: "t1", UserID: "u2", StartedAt: "2025-05-21T09:00:00Z", StoppedAt: str("2025-05-21T09:30:00Z")},
+		{TaskID: "t2", UserID: "u1", StartedAt: "2025-05-22T09:00:00Z", StoppedAt: str("2025-05-22T09:20:00Z")},
+		{TaskID: "gone", UserID: "u2", StartedAt: "2025-05-22T10:00:00Z", StoppedAt: str("2025-05-22T10:10:00Z")},
+	}
+
+	report := timetrack.Report(entries, tasks, map[string]string{"p1": "Launch"}, from, to, to)
+
+	if report.TotalMinutes != 120 {
+		t.Errorf("TotalMinutes = %d, want 120", report.TotalMinutes)
+	}
+
+	type total struct {
+		key, name string
+		minutes   int
+	}
+	flatten := func(totals []*model.TimeTotal) []total {
+		var out []total
+		for _, tt := range totals {
+			var key, name string
+			if tt.Key != nil {
+				key, name = *tt.Key, *tt.Name
+			}
+			out = append(out, total{key, name, tt.Minutes})
+		}
+		return out
+	}
+
+	checks := []struct {
+		name   string
+		totals []*model.TimeTotal
+		want   []total
+	}{
+		{"byDepartment", report.ByDepartment, []total{{"sales", "sales", 90}, {"programming", "programming", 20}}},
+		{"byAssignee", report.ByAssignee, []total{{"Mark", "Mark", 90}, {"Ana", "Ana", 20}}},
+		{"byUser", report.ByUser, []total{{"u1", "u1", 80}, {"u2", "u2", 40}}},
+		{"byProject", report.ByProject, []total{{"p1", "Launch", 90}, {"", "", 20}}},
+	}
+	for _, c := range checks {
+		if got := flatten(c.totals); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestWorkload(t *testing.T) {
+	now := time.Date(2025, 5, 23, 12, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	tasks := []*model.Task{
+		{Status: gtd.NextAction, AssignedTo: str("Mark"), Estimate: num(120), Duration: num(30), DueDate: str("2025-05-22")},
+		{Status: gtd.WaitingFor, AssignedTo: str("Mark"), Estimate: num(60), DueDate: str("2025-05-27")},
+		{Status: gtd.Inbox, AssignedTo: str("Mark"), Estimate: num(45)},
+		{Status: gtd.Scheduled, AssignedTo: str("Mark"), DueDate: str("2025-05-24")},
+		{Status: gtd.NextAction, AssignedTo: str("Ana"), Estimate: num(30), Duration: num(50), DueDate: str("2025-06-30")},
+		{Status: gtd.Complete, AssignedTo: str("Ana"), Estimate: num(600)},
+		{Status: gtd.SomedayMaybe, Estimate: num(600)},
+	}
+
+	got := timetrack.Workload(tasks, now, 7)
+	if len(got) != 2 {
+		t.Fatalf("Workload returned %d assignees, want 2", len(got))
+	}
+
+	mark := got[0]
+	if *mark.AssignedTo != "Mark" || mark.OpenTasks != 4 || mark.UnestimatedTasks != 1 {
+		t.Errorf("Mark = %+v, want 4 open tasks, 1 unestimated", mark)
+	}
+	if mark.RemainingMinutes != 195 || mark.OverdueMinutes != 90 || mark.DueSoonMinutes != 60 || mark.LaterMinutes != 45 {
+		t.Errorf("Mark minutes = remaining %d, overdue %d, due soon %d, later %d, want 195, 90, 60, 45",
+			mark.RemainingMinutes, mark.OverdueMinutes, mark.DueSoonMinutes, mark.LaterMinutes)
+	}
+	if mark.NextDueDate == nil || *mark.NextDueDate != "2025-05-22" {
+		t.Errorf("Mark NextDueDate = %v, want 2025-05-22", mark.NextDueDate)
+	}
+
+	ana := got[1]
+	if *ana.AssignedTo != "Ana" || ana.OpenTasks != 1 || ana.RemainingMinutes != 0 {
+		t.Errorf("Ana = %+v, want 1 open task with nothing remaining", ana)
+	}
+}